@@ -3,19 +3,29 @@ package main
 import (
 	"context"
 	"database/sql"
-	"log"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/joho/godotenv"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/configs"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/infrastructure/grpcserver"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/infrastructure/metrics"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/infrastructure/migrations"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/infrastructure/repositories"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/controllers"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"google.golang.org/grpc"
 )
 
 // --- Адаптеры для DI ---
@@ -29,132 +39,204 @@ func (a *userRepoForRegister) GetByEmail(ctx context.Context, email string) (*en
 	return u, err
 }
 
-type productRepoForDelete struct {
-	*repositories.PGProductRepository
-}
-
-func (a *productRepoForDelete) Delete(ctx context.Context, id uuid.UUID) error {
-	_, err := a.PGProductRepository.DeleteLast(ctx, id)
-	return err
-}
-
-type receptionRepoForClose struct {
-	*repositories.PGReceptionRepository
-}
-
-func (a *receptionRepoForClose) GetActive(ctx context.Context, pvzID uuid.UUID) (*entities.Reception, error) {
-	return a.PGReceptionRepository.GetActive(ctx, pvzID)
-}
-func (a *receptionRepoForClose) Save(ctx context.Context, rec entities.Reception) (entities.Reception, error) {
-	return a.PGReceptionRepository.Save(ctx, rec)
-}
-func (a *receptionRepoForClose) CloseLast(ctx context.Context, pvzID uuid.UUID) (entities.Reception, error) {
-	err := a.PGReceptionRepository.CloseLast(ctx, pvzID, time.Now().UTC())
-	if err != nil {
-		return entities.Reception{}, err
-	}
-	return entities.Reception{}, nil // TODO: вернуть реальный rec, если нужно
-}
-
-type productRepoForList struct {
-	*repositories.PGProductRepository
-}
-
-func (a *productRepoForList) Save(ctx context.Context, p entities.Product) (entities.Product, error) {
-	return a.PGProductRepository.Save(ctx, p)
-}
-func (a *productRepoForList) DeleteLast(ctx context.Context, id uuid.UUID) error {
-	_, err := a.PGProductRepository.DeleteLast(ctx, id)
-	return err
-}
-func (a *productRepoForList) ListByReception(ctx context.Context, recID uuid.UUID) ([]entities.Product, error) {
-	return a.PGProductRepository.ListByReception(ctx, recID)
-}
-
-type receptionRepoForList struct {
-	*repositories.PGReceptionRepository
-}
-
-func (a *receptionRepoForList) GetActive(ctx context.Context, pvzID uuid.UUID) (*entities.Reception, error) {
-	return a.PGReceptionRepository.GetActive(ctx, pvzID)
-}
-func (a *receptionRepoForList) Save(ctx context.Context, rec entities.Reception) (entities.Reception, error) {
-	return a.PGReceptionRepository.Save(ctx, rec)
-}
-func (a *receptionRepoForList) CloseLast(ctx context.Context, pvzID uuid.UUID) (entities.Reception, error) {
-	err := a.PGReceptionRepository.CloseLast(ctx, pvzID, time.Now().UTC())
-	if err != nil {
-		return entities.Reception{}, err
-	}
-	return entities.Reception{}, nil // TODO: вернуть реальный rec, если нужно
-}
-
 func main() {
 	_ = godotenv.Load()
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrate(os.Args[2:]))
+	}
 	cfg := configs.LoadConfig()
 
+	// --- Логгер ---
+	log := logger.New(cfg.LogLevel, cfg.LogFormat)
+	slog.SetDefault(log)
+
 	// --- Postgres ---
 	db, err := sql.Open("pgx", cfg.PGDSN)
 	if err != nil {
-		log.Fatalf("failed to connect to db: %v", err)
+		log.Error("failed to connect to db", slog.Any("error", err))
+		os.Exit(1)
 	}
-	defer db.Close()
 
 	// --- Репозитории ---
 	userRepo := repositories.NewPGUserRepository(db)
 	pvzRepo := repositories.NewPGPVZRepository(db)
 	receptionRepo := repositories.NewPGReceptionRepository(db)
 	productRepo := repositories.NewPGProductRepository(db)
+	txManager := repositories.NewPGTxManager(db)
+	catalogRepo := repositories.NewPGCatalogRepository(db)
+	sessionRepo := repositories.NewPGSessionRepository(db)
+	staffRepo := repositories.NewPGStaffAssignmentRepository(db)
+	catalogCache := usecases.NewCatalogCache(catalogRepo, cfg.CatalogCacheTTL)
+
+	// --- Метрики ---
+	promExporter := metrics.NewPrometheusExporter()
 
 	// --- Usecase ---
-	dummyLoginUC := usecases.NewDummyLoginUseCase(cfg)
+	authz := usecases.NewAuthorizer(cfg.RolePermissions, staffRepo)
+	tokenIssuer := usecases.NewTokenIssuer(sessionRepo, cfg)
+	dummyLoginUC := usecases.NewDummyLoginUseCase(userRepo, tokenIssuer)
 	registerUC := usecases.NewRegisterUseCase(&userRepoForRegister{userRepo})
-	loginUC := usecases.NewLoginUseCase(userRepo, cfg)
-	createPVZUC := usecases.NewCreatePVZUseCase(pvzRepo)
-	listPVZsUC := usecases.NewListPVZsUseCase(pvzRepo, &receptionRepoForList{receptionRepo}, &productRepoForList{productRepo})
-	closeReceptionUC := usecases.NewCloseReceptionUseCase(&receptionRepoForClose{receptionRepo})
-	deleteLastProductUC := usecases.NewDeleteLastProductUseCase(&productRepoForDelete{productRepo}, &receptionRepoForClose{receptionRepo})
-	addProductUC := usecases.NewAddProductUseCase(productRepo, receptionRepo)
-	createReceptionUC := usecases.NewCreateReceptionUseCase(receptionRepo)
+	loginUC := usecases.NewLoginUseCase(userRepo, tokenIssuer)
+	refreshTokenUC := usecases.NewRefreshTokenUseCase(sessionRepo, tokenIssuer, txManager)
+	logoutUC := usecases.NewLogoutUseCase(sessionRepo)
+	revokeSessionsUC := usecases.NewRevokeUserSessionsUseCase(sessionRepo, authz)
+	createPVZUC := usecases.NewCreatePVZUseCase(pvzRepo, catalogCache, promExporter, authz)
+	listPVZsUC := usecases.NewListPVZsUseCase(pvzRepo, authz)
+	closeReceptionUC := usecases.NewCloseReceptionUseCase(receptionRepo, txManager, authz)
+	deleteLastProductUC := usecases.NewDeleteLastProductUseCase(productRepo, receptionRepo, txManager, authz)
+	deleteProductUC := usecases.NewDeleteProductUseCase(productRepo, receptionRepo, txManager, authz)
+	reopenReceptionUC := usecases.NewReopenReceptionUseCase(receptionRepo, pvzRepo, txManager, cfg.ReopenWindow, authz)
+	listTransitionsUC := usecases.NewListReceptionTransitionsUseCase(receptionRepo, authz)
+	changeReceptionStatusUC := usecases.NewChangeReceptionStatusUseCase(receptionRepo, productRepo, txManager, authz)
+	addProductUC := usecases.NewAddProductUseCase(productRepo, receptionRepo, catalogCache, txManager, promExporter, authz)
+	addProductsBatchUC := usecases.NewAddProductsBatchUseCase(productRepo, receptionRepo, catalogCache, txManager, promExporter, authz)
+	createReceptionUC := usecases.NewCreateReceptionUseCase(receptionRepo, pvzRepo, txManager, promExporter, authz)
+	getPVZUC := usecases.NewGetPVZUseCase(pvzRepo, authz)
+	updatePVZUC := usecases.NewUpdatePVZUseCase(pvzRepo, catalogCache, txManager, authz)
+	archivePVZUC := usecases.NewArchivePVZUseCase(pvzRepo, receptionRepo, txManager, authz)
+	findNearestPVZsUC := usecases.NewFindNearestPVZsUseCase(pvzRepo, authz)
+	getReceptionUC := usecases.NewGetReceptionUseCase(receptionRepo, productRepo, authz)
+	listReceptionsUC := usecases.NewListReceptionsUseCase(pvzRepo, receptionRepo, authz)
+	findProductsUC := usecases.NewFindProductsByBarcodeUseCase(productRepo, authz)
+	listCatalogUC := usecases.NewListCatalogUseCase(catalogRepo, authz)
+	addCatalogEntryUC := usecases.NewAddCatalogEntryUseCase(catalogRepo, catalogCache, authz)
+	updateCatalogEntryUC := usecases.NewUpdateCatalogEntryUseCase(catalogRepo, catalogCache, authz)
+	assignStaffUC := usecases.NewAssignStaffUseCase(staffRepo, userRepo, pvzRepo, txManager, authz)
+	listPVZStaffUC := usecases.NewListPVZStaffUseCase(pvzRepo, staffRepo, authz)
 
 	// --- Контроллеры ---
-	authCtrl := controllers.NewAuthController(dummyLoginUC, registerUC, loginUC)
-	pvzCtrl := controllers.NewPVZController(createPVZUC, listPVZsUC, closeReceptionUC, deleteLastProductUC)
-	productCtrl := controllers.NewProductController(addProductUC)
-	receptionCtrl := controllers.NewReceptionController(createReceptionUC)
-
-	r := gin.Default()
+	tokenVerifier := controllers.NewTokenVerifier(cfg, sessionRepo)
+	authCtrl := controllers.NewAuthController(dummyLoginUC, registerUC, loginUC, refreshTokenUC, logoutUC, revokeSessionsUC)
+	pvzCtrl := controllers.NewPVZController(createPVZUC, listPVZsUC, closeReceptionUC, deleteLastProductUC, getPVZUC, updatePVZUC, archivePVZUC, findNearestPVZsUC, assignStaffUC, listPVZStaffUC)
+	productCtrl := controllers.NewProductController(addProductUC, findProductsUC, addProductsBatchUC)
+	receptionCtrl := controllers.NewReceptionController(createReceptionUC, getReceptionUC, listReceptionsUC, deleteProductUC, reopenReceptionUC, listTransitionsUC, changeReceptionStatusUC)
+	catalogCtrl := controllers.NewCatalogController(listCatalogUC, addCatalogEntryUC, updateCatalogEntryUC)
+
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		log.Error("failed to load migrations", slog.Any("error", err))
+		os.Exit(1)
+	}
+	readinessUC := usecases.NewCheckReadinessUseCase(repositories.NewPGHealthRepository(db, migrator.Latest()))
+	healthCtrl := controllers.NewHealthController(readinessUC)
+	jwksCtrl := controllers.NewJWKSController(cfg.PublicKeys())
 
-	// --- Auth ---
-	r.POST("/dummyLogin", authCtrl.DummyLogin)
-	r.POST("/register", authCtrl.Register)
-	r.POST("/login", authCtrl.Login)
+	r := gin.New()
+	r.Use(gin.Recovery(), controllers.RequestLoggerMiddleware(log), promExporter.GinMiddleware())
 
-	// --- Защищённые эндпоинты ---
-	authMW := controllers.JWTAuthMiddleware(cfg.JWTSecret)
-	pvz := r.Group("/pvz", authMW)
-	pvz.POST("/", pvzCtrl.Create)
-	pvz.GET("/", pvzCtrl.List)
-	pvz.POST("/:pvzId/close_last_reception", pvzCtrl.CloseLastReception)
-	pvz.POST("/:pvzId/delete_last_product", pvzCtrl.DeleteLastProduct)
+	// --- HTTP API (маршруты сгенерированы по swagger.yaml) ---
+	controllers.RegisterRoutes(r, controllers.NewServer(authCtrl, pvzCtrl, productCtrl, receptionCtrl, catalogCtrl), tokenVerifier, authz)
 
-	product := r.Group("/products", authMW)
-	product.POST("/", productCtrl.Add)
+	// --- Пробы: /health/live, /health/ready, /ping ---
+	controllers.RegisterHealthRoutes(r, healthCtrl)
 
-	reception := r.Group("/receptions", authMW)
-	reception.POST("/", receptionCtrl.Create)
+	// --- Открытые ключи подписи JWT: /.well-known/jwks.json ---
+	controllers.RegisterJWKSRoutes(r, jwksCtrl)
 
-	// Healthcheck
-	r.GET("/ping", func(c *gin.Context) {
-		c.JSON(200, gin.H{"message": "pong"})
-	})
+	// Ошибка любого из серверов завершает процесс так же, как SIGTERM
+	serveErr := make(chan error, 3)
 
+	// --- gRPC ---
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "3000"
+	}
+	pvzGrpcService := grpcserver.NewPVZGrpcService(pvzRepo, createPVZUC, createReceptionUC, addProductUC, deleteLastProductUC, closeReceptionUC)
+	grpcSrv := grpcserver.NewServer(pvzGrpcService, tokenVerifier, log)
+	go func() {
+		log.Info("starting gRPC server", slog.String("port", grpcPort))
+		if err := grpcserver.StartServer(grpcSrv, grpcPort); err != nil {
+			serveErr <- fmt.Errorf("grpc server: %w", err)
+		}
+	}()
+
+	// --- Prometheus ---
+	metricsPort := os.Getenv("METRICS_PORT")
+	if metricsPort == "" {
+		metricsPort = "9000"
+	}
+	metricsSrv := promExporter.NewServer(metricsPort)
+	go func() {
+		log.Info("starting metrics server", slog.String("port", metricsPort))
+		if err := metricsSrv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- fmt.Errorf("metrics server: %w", err)
+		}
+	}()
+
+	// --- HTTP ---
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8080"
 	}
-	log.Printf("Starting HTTP server on :%s", port)
-	if err := r.Run(":" + port); err != nil {
-		log.Fatalf("failed to start server: %v", err)
+	httpSrv := &http.Server{
+		Addr:              ":" + port,
+		Handler:           r,
+		ReadTimeout:       cfg.HTTPReadTimeout,
+		ReadHeaderTimeout: cfg.HTTPReadTimeout,
+		WriteTimeout:      cfg.HTTPWriteTimeout,
+		IdleTimeout:       cfg.HTTPIdleTimeout,
+	}
+	go func() {
+		log.Info("starting HTTP server", slog.String("port", port))
+		if err := httpSrv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- fmt.Errorf("http server: %w", err)
+		}
+	}()
+
+	// --- Остановка по SIGINT/SIGTERM ---
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	exitCode := 0
+	select {
+	case <-ctx.Done():
+		log.Info("shutdown signal received")
+	case err := <-serveErr:
+		log.Error("server failed", slog.Any("error", err))
+		exitCode = 1
+	}
+	shutdown(log, cfg.ShutdownDrainDelay, cfg.ShutdownTimeout, readinessUC, httpSrv, grpcSrv, metricsSrv, db)
+	os.Exit(exitCode)
+}
+
+// shutdown останавливает сервис: readiness уходит в 503, порты остаются открытыми drainDelay, чтобы
+// балансировщик увидел 503 и перестал слать запросы, затем HTTP и gRPC дорабатывают текущие
+// запросы (не дольше timeout), после чего закрываются сервер метрик и пул БД
+func shutdown(log *slog.Logger, drainDelay, timeout time.Duration, readiness *usecases.CheckReadinessUseCase, httpSrv *http.Server, grpcSrv *grpc.Server, metricsSrv *http.Server, db *sql.DB) {
+	readiness.BeginShutdown()
+	log.Info("draining before closing listeners", slog.Duration("drain_delay", drainDelay))
+	time.Sleep(drainDelay)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if err := httpSrv.Shutdown(ctx); err != nil {
+			log.Error("http server shutdown", slog.Any("error", err))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		stopped := make(chan struct{})
+		go func() {
+			grpcSrv.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			log.Error("grpc server shutdown timed out, forcing stop")
+			grpcSrv.Stop()
+		}
+	}()
+	wg.Wait()
+
+	if err := metricsSrv.Shutdown(ctx); err != nil {
+		log.Error("metrics server shutdown", slog.Any("error", err))
+	}
+	if err := db.Close(); err != nil {
+		log.Error("db close", slog.Any("error", err))
 	}
+	log.Info("shutdown complete")
 }
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/configs"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/infrastructure/migrations"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
+)
+
+const migrateUsage = `usage: app migrate <command>
+
+commands:
+  up        apply all pending migrations
+  down      roll back the last applied migration
+  status    show applied and pending migrations
+  to N      migrate up or down to version N (0 rolls back everything)`
+
+// runMigrate — подкоманда `app migrate up|down|status|to N`, возвращает код выхода
+func runMigrate(args []string) int {
+	log := logger.New(os.Getenv("LOG_LEVEL"), os.Getenv("LOG_FORMAT"))
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+	dsn := configs.GetPGDSN()
+	if dsn == "" {
+		fmt.Fprintln(os.Stderr, "PG_DSN env var is required")
+		return 2
+	}
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		log.Error("failed to connect to db", slog.Any("error", err))
+		return 1
+	}
+	defer db.Close()
+	m, err := migrations.NewMigrator(db)
+	if err != nil {
+		log.Error("failed to load migrations", slog.Any("error", err))
+		return 1
+	}
+	ctx := logger.WithContext(context.Background(), log)
+
+	switch {
+	case args[0] == "up" && len(args) == 1:
+		err = m.Up(ctx)
+	case args[0] == "down" && len(args) == 1:
+		err = m.Down(ctx)
+	case args[0] == "to" && len(args) == 2:
+		version, perr := strconv.ParseInt(args[1], 10, 64)
+		if perr != nil || version < 0 {
+			fmt.Fprintln(os.Stderr, "to: version must be a non-negative integer")
+			return 2
+		}
+		err = m.To(ctx, version)
+	case args[0] == "status" && len(args) == 1:
+		err = printStatus(ctx, m)
+	default:
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+	if err != nil {
+		log.Error("migrate failed", slog.String("command", args[0]), slog.Any("error", err))
+		return 1
+	}
+	return 0
+}
+
+func printStatus(ctx context.Context, m *migrations.Migrator) error {
+	st, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "VERSION\tNAME\tAPPLIED AT\n")
+	for _, a := range st.Applied {
+		fmt.Fprintf(w, "%d\t%s\t%s\n", a.Version, a.Name, a.AppliedAt.UTC().Format("2006-01-02 15:04:05Z"))
+	}
+	for _, p := range st.Pending {
+		fmt.Fprintf(w, "%d\t%s\tpending\n", p.Version, p.Name)
+	}
+	fmt.Fprintf(w, "\ncurrent: %d, latest: %d\n", st.Current, st.Latest)
+	return w.Flush()
+}
@@ -2,28 +2,107 @@ package configs
 
 import (
 	"os"
+	"time"
+
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
 )
 
 // Config — конфиг приложения
 type Config struct {
-	JWTSecret string
-	PGDSN     string
+	JWTSecret    string            // JWT_SECRET — единственный ключ подписи (kid DefaultJWTKeyID), если JWT_KEYS не задан
+	JWTKeys      map[string]string // JWT_KEYS — kid:секрет через запятую; токены проверяются любым из ключей
+	JWTKeyFiles  map[string]JWTKey // JWT_PRIVATE_KEY_FILES / JWT_PUBLIC_KEY_FILES — kid:путь к PEM-ключу RS256/EdDSA через запятую
+	JWTActiveKID string            // JWT_ACTIVE_KID — kid ключа, которым подписываются новые токены
+	JWTIssuer    string            // JWT_ISSUER — claim iss, по умолчанию pvz-service
+	JWTAudience  string            // JWT_AUDIENCE — claim aud, по умолчанию pvz-api
+	PGDSN        string
+	LogLevel     string // debug/info/warn/error, по умолчанию info
+	LogFormat    string // json/text, по умолчанию json
+
+	HTTPReadTimeout  time.Duration // HTTP_READ_TIMEOUT, по умолчанию 5s
+	HTTPWriteTimeout time.Duration // HTTP_WRITE_TIMEOUT, по умолчанию 10s
+	HTTPIdleTimeout  time.Duration // HTTP_IDLE_TIMEOUT, по умолчанию 60s
+	ShutdownTimeout  time.Duration // SHUTDOWN_TIMEOUT — сколько ждать завершения запросов при остановке, по умолчанию 15s
+	// SHUTDOWN_DRAIN_DELAY — сколько после сигнала остановки /health/ready отвечает 503 при ещё открытых портах,
+	// чтобы балансировщик успел снять реплику; не меньше периода readiness-пробы, по умолчанию 5s
+	ShutdownDrainDelay time.Duration
+	CatalogCacheTTL    time.Duration // CATALOG_CACHE_TTL — через сколько видны изменения справочников с других реплик, по умолчанию 1m
+	ReopenWindow       time.Duration // RECEPTION_REOPEN_WINDOW — сколько после закрытия модератор может переоткрыть приёмку, по умолчанию 30m
+	AccessTokenTTL     time.Duration // ACCESS_TOKEN_TTL — время жизни access-токена, по умолчанию 15m
+	RefreshTokenTTL    time.Duration // REFRESH_TOKEN_TTL — время жизни refresh-токена, сессия без обновлений дольше него завершается, по умолчанию 720h
+
+	RolePermissions map[entities.UserRole][]entities.Permission // RBAC_POLICY_FILE — JSON роль → права, по умолчанию entities.DefaultRolePermissions
 }
 
 // LoadConfig загружает конфиг из переменных окружения
 func LoadConfig() *Config {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		panic("JWT_SECRET env var is required")
-	}
 	pgDsn := os.Getenv("PG_DSN")
 	if pgDsn == "" {
 		panic("PG_DSN env var is required")
 	}
-	return &Config{
-		JWTSecret: secret,
-		PGDSN:     pgDsn,
+	logLevel := os.Getenv("LOG_LEVEL")
+	if logLevel == "" {
+		logLevel = "info"
+	}
+	logFormat := os.Getenv("LOG_FORMAT")
+	if logFormat == "" {
+		logFormat = "json"
+	}
+	cfg := &Config{
+		JWTSecret:    os.Getenv("JWT_SECRET"),
+		JWTKeys:      kidListEnv("JWT_KEYS"),
+		JWTKeyFiles:  jwtKeyFilesEnv("JWT_PRIVATE_KEY_FILES", "JWT_PUBLIC_KEY_FILES"),
+		JWTActiveKID: os.Getenv("JWT_ACTIVE_KID"),
+		JWTIssuer:    stringEnv("JWT_ISSUER", "pvz-service"),
+		JWTAudience:  stringEnv("JWT_AUDIENCE", "pvz-api"),
+		PGDSN:        pgDsn,
+		LogLevel:     logLevel,
+		LogFormat:    logFormat,
+
+		HTTPReadTimeout:    durationEnv("HTTP_READ_TIMEOUT", 5*time.Second),
+		HTTPWriteTimeout:   durationEnv("HTTP_WRITE_TIMEOUT", 10*time.Second),
+		HTTPIdleTimeout:    durationEnv("HTTP_IDLE_TIMEOUT", 60*time.Second),
+		ShutdownTimeout:    durationEnv("SHUTDOWN_TIMEOUT", 15*time.Second),
+		ShutdownDrainDelay: durationEnv("SHUTDOWN_DRAIN_DELAY", 5*time.Second),
+		CatalogCacheTTL:    durationEnv("CATALOG_CACHE_TTL", time.Minute),
+		ReopenWindow:       durationEnv("RECEPTION_REOPEN_WINDOW", 30*time.Minute),
+		AccessTokenTTL:     durationEnv("ACCESS_TOKEN_TTL", 15*time.Minute),
+		RefreshTokenTTL:    durationEnv("REFRESH_TOKEN_TTL", 30*24*time.Hour),
+
+		RolePermissions: rolePermissionsEnv("RBAC_POLICY_FILE"),
+	}
+	cfg.validateJWTKeys()
+	return cfg
+}
+
+// DefaultJWTKeyID — kid ключа из JWT_SECRET
+const DefaultJWTKeyID = "default"
+
+// stringEnv читает строку из переменной окружения, пустая — значение по умолчанию
+func stringEnv(name, def string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
 	}
+	return def
+}
+
+// durationEnv читает длительность из переменной окружения (формат time.ParseDuration: 5s, 1m)
+func durationEnv(name string, def time.Duration) time.Duration {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		panic(name + " env var must be a positive duration, got " + v)
+	}
+	return d
+}
+
+// GetPGDSN возвращает строку подключения к БД из PG_DSN без проверки остальных настроек
+// (нужна подкоманде migrate, которой JWT_SECRET не нужен)
+func GetPGDSN() string {
+	return os.Getenv("PG_DSN")
 }
 
 // GetTestPGDSN возвращает строку подключения к тестовой БД из переменной окружения TEST_PG_DSN
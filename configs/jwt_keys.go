@@ -0,0 +1,203 @@
+package configs
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Алгоритмы подписи JWT, которые принимает сервис
+const (
+	JWTAlgHS256 = "HS256"
+	JWTAlgRS256 = "RS256"
+	JWTAlgEdDSA = "EdDSA"
+)
+
+// rsaMinBits — минимальная длина RSA-ключа
+const rsaMinBits = 2048
+
+// JWTKey — ключ JWT: kid, алгоритм, ключ подписи и ключ проверки.
+// У HS256 оба ключа — общий секрет ([]byte). У RS256/EdDSA ключ подписи — закрытый
+// (*rsa.PrivateKey, ed25519.PrivateKey), проверки — открытый; у ключа только для проверки Sign == nil
+type JWTKey struct {
+	KID    string
+	Alg    string
+	Sign   any
+	Verify any
+}
+
+// IsAsymmetric — ключ RS256/EdDSA, открытую часть можно публиковать в JWKS
+func (k JWTKey) IsAsymmetric() bool {
+	return k.Alg != JWTAlgHS256
+}
+
+// SigningKeys возвращает ключи JWT по kid и kid активного ключа, которым подписываются новые токены.
+// HS256-ключи — из JWTKeys, без них — JWTSecret с kid DefaultJWTKeyID; RS256/EdDSA — из JWTKeyFiles
+func (c *Config) SigningKeys() (map[string]JWTKey, string) {
+	keys := make(map[string]JWTKey, len(c.JWTKeys)+len(c.JWTKeyFiles)+1)
+	if len(c.JWTKeys) == 0 && c.JWTSecret != "" {
+		keys[DefaultJWTKeyID] = hmacKey(DefaultJWTKeyID, c.JWTSecret)
+	}
+	for kid, secret := range c.JWTKeys {
+		keys[kid] = hmacKey(kid, secret)
+	}
+	for kid, key := range c.JWTKeyFiles {
+		keys[kid] = key
+	}
+	active := c.JWTActiveKID
+	if active == "" {
+		active = DefaultJWTKeyID
+	}
+	return keys, active
+}
+
+// PublicKeys возвращает ключи RS256/EdDSA, отсортированные по kid, — их публикует /.well-known/jwks.json
+func (c *Config) PublicKeys() []JWTKey {
+	keys, _ := c.SigningKeys()
+	var public []JWTKey
+	for _, key := range keys {
+		if key.IsAsymmetric() {
+			public = append(public, key)
+		}
+	}
+	sort.Slice(public, func(i, j int) bool { return public[i].KID < public[j].KID })
+	return public
+}
+
+// validateJWTKeys проверяет, что ключи заданы, kid не повторяются и активный ключ умеет подписывать
+func (c *Config) validateJWTKeys() {
+	for kid := range c.JWTKeyFiles {
+		if _, dup := c.JWTKeys[kid]; dup {
+			panic("JWT_KEYS and JWT key files have the same kid " + kid)
+		}
+	}
+	if len(c.JWTKeys) > 0 && c.JWTActiveKID == "" {
+		panic("JWT_ACTIVE_KID env var is required with JWT_KEYS")
+	}
+	keys, active := c.SigningKeys()
+	if len(keys) == 0 {
+		panic("JWT_SECRET, JWT_KEYS or JWT_PRIVATE_KEY_FILES env var is required")
+	}
+	if key, ok := keys[active]; !ok || key.Sign == nil {
+		panic("JWT_ACTIVE_KID env var must name a signing key from JWT_KEYS or JWT_PRIVATE_KEY_FILES")
+	}
+}
+
+func hmacKey(kid, secret string) JWTKey {
+	return JWTKey{KID: kid, Alg: JWTAlgHS256, Sign: []byte(secret), Verify: []byte(secret)}
+}
+
+// jwtKeyFilesEnv читает PEM-файлы ключей из переменных в формате kid:путь,kid:путь.
+// privateName — закрытые ключи (PKCS#8 или PKCS#1), publicName — открытые ключи только для проверки
+// (PKIX или PKCS#1), например выведенные из ротации
+func jwtKeyFilesEnv(privateName, publicName string) map[string]JWTKey {
+	keys := map[string]JWTKey{}
+	for kid, path := range kidListEnv(privateName) {
+		key, err := loadPrivateKey(kid, path)
+		if err != nil {
+			panic(privateName + ": " + err.Error())
+		}
+		keys[kid] = key
+	}
+	for kid, path := range kidListEnv(publicName) {
+		if _, dup := keys[kid]; dup {
+			panic(publicName + " env var has kid " + kid + " already set in " + privateName)
+		}
+		key, err := loadPublicKey(kid, path)
+		if err != nil {
+			panic(publicName + ": " + err.Error())
+		}
+		keys[kid] = key
+	}
+	return keys
+}
+
+// kidListEnv читает список kid:значение через запятую
+func kidListEnv(name string) map[string]string {
+	v := os.Getenv(name)
+	if v == "" {
+		return nil
+	}
+	list := map[string]string{}
+	for _, pair := range strings.Split(v, ",") {
+		kid, value, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || kid == "" || value == "" {
+			panic(name + " env var must be a comma-separated list of kid:value")
+		}
+		if _, dup := list[kid]; dup {
+			panic(name + " env var has duplicate kid " + kid)
+		}
+		list[kid] = value
+	}
+	return list
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM block", path)
+	}
+	return block, nil
+}
+
+func loadPrivateKey(kid, path string) (JWTKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return JWTKey{}, err
+	}
+	priv, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		if rsaKey, errRSA := x509.ParsePKCS1PrivateKey(block.Bytes); errRSA == nil {
+			priv, err = rsaKey, nil
+		}
+	}
+	if err != nil {
+		return JWTKey{}, fmt.Errorf("%s: %w", path, err)
+	}
+	switch k := priv.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < rsaMinBits {
+			return JWTKey{}, fmt.Errorf("%s: RSA key must be at least %d bits", path, rsaMinBits)
+		}
+		return JWTKey{KID: kid, Alg: JWTAlgRS256, Sign: k, Verify: &k.PublicKey}, nil
+	case ed25519.PrivateKey:
+		return JWTKey{KID: kid, Alg: JWTAlgEdDSA, Sign: k, Verify: k.Public()}, nil
+	}
+	return JWTKey{}, fmt.Errorf("%s: only RSA and Ed25519 keys are supported", path)
+}
+
+func loadPublicKey(kid, path string) (JWTKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return JWTKey{}, err
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		if rsaKey, errRSA := x509.ParsePKCS1PublicKey(block.Bytes); errRSA == nil {
+			pub, err = rsaKey, nil
+		}
+	}
+	if err != nil {
+		return JWTKey{}, fmt.Errorf("%s: %w", path, err)
+	}
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		if k.N.BitLen() < rsaMinBits {
+			return JWTKey{}, fmt.Errorf("%s: RSA key must be at least %d bits", path, rsaMinBits)
+		}
+		return JWTKey{KID: kid, Alg: JWTAlgRS256, Verify: k}, nil
+	case ed25519.PublicKey:
+		return JWTKey{KID: kid, Alg: JWTAlgEdDSA, Verify: k}, nil
+	}
+	return JWTKey{}, errors.New(path + ": only RSA and Ed25519 keys are supported")
+}
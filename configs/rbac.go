@@ -0,0 +1,34 @@
+package configs
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+)
+
+// rolePermissionsEnv читает политику роль → права из JSON-файла {"роль": ["право", ...]}.
+// Без файла — встроенная политика entities.DefaultRolePermissions. Неизвестное право — ошибка старта:
+// опечатка в политике не должна молча закрывать или открывать доступ
+func rolePermissionsEnv(name string) map[entities.UserRole][]entities.Permission {
+	path := os.Getenv(name)
+	if path == "" {
+		return entities.DefaultRolePermissions()
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		panic(name + ": " + err.Error())
+	}
+	var policy map[entities.UserRole][]entities.Permission
+	if err := json.Unmarshal(data, &policy); err != nil {
+		panic(name + ": " + path + ": " + err.Error())
+	}
+	for role, perms := range policy {
+		for _, p := range perms {
+			if !entities.ValidatePermission(p) {
+				panic(name + ": role " + string(role) + " has unknown permission " + string(p))
+			}
+		}
+	}
+	return policy
+}
@@ -0,0 +1,37 @@
+package entities
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// CatalogKind — справочник, значения которого управляются модератором без релиза
+type CatalogKind string
+
+const (
+	CatalogCity        CatalogKind = "city"
+	CatalogProductType CatalogKind = "product_type"
+)
+
+// CatalogEntryMaxLen — максимальная длина названия элемента справочника (в символах)
+const CatalogEntryMaxLen = 100
+
+// CatalogEntry — элемент справочника
+// active — можно ли выбрать для новых ПВЗ/товаров; отключение не трогает уже созданные данные
+type CatalogEntry struct {
+	ID     int64       `json:"id"`
+	Kind   CatalogKind `json:"kind"`
+	Name   string      `json:"name"`
+	Active bool        `json:"active"`
+}
+
+// Проверяет, существует ли такой справочник
+func ValidateCatalogKind(kind CatalogKind) bool {
+	return kind == CatalogCity || kind == CatalogProductType
+}
+
+// NormalizeCatalogName убирает пробелы по краям; ok=false, если название пустое или длиннее CatalogEntryMaxLen
+func NormalizeCatalogName(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	return name, name != "" && utf8.RuneCountInString(name) <= CatalogEntryMaxLen
+}
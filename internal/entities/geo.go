@@ -0,0 +1,61 @@
+package entities
+
+import "math"
+
+// EarthRadiusMeters — средний радиус Земли для расчёта расстояний
+const EarthRadiusMeters = 6371008.8
+
+// GeoPoint — координаты в градусах (WGS 84)
+type GeoPoint struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// Проверяет, что широта в [-90, 90], а долгота в [-180, 180]
+func (p GeoPoint) Valid() bool {
+	return p.Lat >= -90 && p.Lat <= 90 && p.Lon >= -180 && p.Lon <= 180
+}
+
+// DistanceTo — расстояние по большому кругу (формула гаверсинусов), в метрах
+func (p GeoPoint) DistanceTo(q GeoPoint) float64 {
+	lat1, lat2 := radians(p.Lat), radians(q.Lat)
+	dLat, dLon := lat2-lat1, radians(q.Lon-p.Lon)
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLon/2), 2)
+	return 2 * EarthRadiusMeters * math.Asin(math.Sqrt(math.Min(1, h)))
+}
+
+// GeoBox — ограничивающий прямоугольник; WholeLon — ограничения по долготе нет
+// (круг захватывает полюс или пересекает 180-й меридиан)
+type GeoBox struct {
+	MinLat, MaxLat float64
+	MinLon, MaxLon float64
+	WholeLon       bool
+}
+
+// BoundingBox возвращает прямоугольник, в который гарантированно попадают все точки
+// не дальше radius метров от p. Нужен, чтобы отсечь кандидатов по индексу до точного расчёта
+func (p GeoPoint) BoundingBox(radius float64) GeoBox {
+	dLat := degrees(radius / EarthRadiusMeters)
+	box := GeoBox{MinLat: p.Lat - dLat, MaxLat: p.Lat + dLat, MinLon: -180, MaxLon: 180, WholeLon: true}
+	if box.MinLat <= -90 || box.MaxLat >= 90 {
+		box.MinLat, box.MaxLat = math.Max(box.MinLat, -90), math.Min(box.MaxLat, 90)
+		return box
+	}
+	// размах по долготе — по касательным меридианам к окружности (Matuschek)
+	dLon := degrees(math.Asin(math.Min(1, math.Sin(radius/EarthRadiusMeters)/math.Cos(radians(p.Lat)))))
+	if p.Lon-dLon < -180 || p.Lon+dLon > 180 {
+		return box
+	}
+	box.MinLon, box.MaxLon, box.WholeLon = p.Lon-dLon, p.Lon+dLon, false
+	return box
+}
+
+// NearestPVZ — ПВЗ и расстояние до него в метрах (read model для GET /pvz/nearest)
+type NearestPVZ struct {
+	PVZ      PVZ
+	Distance float64
+}
+
+func radians(deg float64) float64 { return deg * math.Pi / 180 }
+
+func degrees(rad float64) float64 { return rad * 180 / math.Pi }
@@ -0,0 +1,66 @@
+package entities
+
+// Permission — право на действие в формате ресурс:действие. Usecase'ы проверяют права,
+// а не роли; какие права у роли — задаёт политика (DefaultRolePermissions или RBAC_POLICY_FILE)
+type Permission string
+
+const (
+	PermPVZCreate             Permission = "pvz:create"
+	PermPVZRead               Permission = "pvz:read"    // список и карточка ПВЗ с приёмками
+	PermPVZNearest            Permission = "pvz:nearest" // поиск ближайших ПВЗ
+	PermPVZUpdate             Permission = "pvz:update"
+	PermPVZArchive            Permission = "pvz:archive"      // архивация и возврат из архива
+	PermPVZManageStaff        Permission = "pvz:manage_staff" // назначение сотрудников на ПВЗ и просмотр назначенных
+	PermReceptionCreate       Permission = "reception:create"
+	PermReceptionRead         Permission = "reception:read" // приёмки, их история переходов
+	PermReceptionClose        Permission = "reception:close"
+	PermReceptionChangeStatus Permission = "reception:change_status" // пауза, возобновление, отмена
+	PermReceptionReopen       Permission = "reception:reopen"
+	PermProductAdd            Permission = "product:add" // по одному и пакетом
+	PermProductDelete         Permission = "product:delete"
+	PermProductRead           Permission = "product:read" // поиск по штрихкоду
+	PermCatalogRead           Permission = "catalog:read"
+	PermCatalogManage         Permission = "catalog:manage" // добавление, изменение и просмотр отключённых элементов
+	PermSessionRevoke         Permission = "session:revoke" // отзыв всех сессий другого пользователя
+)
+
+// AllPermissions — каталог прав: всё, что проверяет сервис
+func AllPermissions() []Permission {
+	return []Permission{
+		PermPVZCreate, PermPVZRead, PermPVZNearest, PermPVZUpdate, PermPVZArchive, PermPVZManageStaff,
+		PermReceptionCreate, PermReceptionRead, PermReceptionClose, PermReceptionChangeStatus, PermReceptionReopen,
+		PermProductAdd, PermProductDelete, PermProductRead,
+		PermCatalogRead, PermCatalogManage,
+		PermSessionRevoke,
+	}
+}
+
+// ValidatePermission проверяет, что право есть в каталоге
+func ValidatePermission(p Permission) bool {
+	for _, known := range AllPermissions() {
+		if p == known {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultRolePermissions — встроенная политика, если RBAC_POLICY_FILE не задан
+func DefaultRolePermissions() map[UserRole][]Permission {
+	return map[UserRole][]Permission{
+		UserRoleClient: {PermPVZNearest, PermCatalogRead},
+		UserRolePVZStaff: {
+			PermPVZRead, PermPVZNearest,
+			PermReceptionCreate, PermReceptionRead, PermReceptionClose, PermReceptionChangeStatus,
+			PermProductAdd, PermProductDelete, PermProductRead,
+			PermCatalogRead,
+		},
+		UserRoleModerator: {
+			PermPVZCreate, PermPVZRead, PermPVZNearest, PermPVZUpdate, PermPVZArchive, PermPVZManageStaff,
+			PermReceptionRead, PermReceptionReopen,
+			PermProductRead,
+			PermCatalogRead, PermCatalogManage,
+			PermSessionRevoke,
+		},
+	}
+}
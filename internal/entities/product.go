@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,11 +10,14 @@ import (
 // Product — товар, принимаемый на ПВЗ
 // id — UUID
 // receptionId — UUID
-// type — электроника/одежда/обувь
+// type — активный элемент справочника product_type (см. CatalogEntry)
 // dateTime — дата и время приёма товара (момент добавления в систему)
+// position — порядковый номер товара в приёмке (1, 2, ...), задаёт порядок LIFO
+// barcode — штрихкод посылки, пустая строка — товар принят без штрихкода
 
 type ProductType string
 
+// Начальное наполнение справочника product_type (миграция 4_catalogs)
 const (
 	ProductElectronics ProductType = "электроника"
 	ProductClothes     ProductType = "одежда"
@@ -25,9 +29,40 @@ type Product struct {
 	ReceptionID uuid.UUID   `json:"receptionId"`
 	Type        ProductType `json:"type"`
 	DateTime    time.Time   `json:"dateTime"`
+	Position    int         `json:"position"`
+	Barcode     string      `json:"barcode,omitempty"`
 }
 
-// Проверяет, валиден ли тип товара
-func ValidateProductType(t ProductType) bool {
-	return t == ProductElectronics || t == ProductClothes || t == ProductShoes
+// ProductBarcodeMaxLen — максимальная длина штрихкода (Code 128, EAN, DataMatrix укладываются)
+const ProductBarcodeMaxLen = 64
+
+// NormalizeBarcode обрезает пробелы по краям; ok=false, если штрихкод длиннее ProductBarcodeMaxLen
+// или содержит что-то кроме печатных символов ASCII. Пустая строка допустима — штрихкода нет
+func NormalizeBarcode(barcode string) (string, bool) {
+	barcode = strings.TrimSpace(barcode)
+	if len(barcode) > ProductBarcodeMaxLen {
+		return "", false
+	}
+	for i := 0; i < len(barcode); i++ {
+		if barcode[i] < '!' || barcode[i] > '~' {
+			return "", false
+		}
+	}
+	return barcode, true
+}
+
+// ProductLocation — где находится посылка: товар, его приёмка и ПВЗ
+type ProductLocation struct {
+	Product   Product
+	Reception Reception
+	PVZ       PVZ
+}
+
+// ProductRemoval — запись журнала удалений: какой товар убран из приёмки, кем и когда
+type ProductRemoval struct {
+	ID             uuid.UUID
+	Product        Product
+	RemovedBy      uuid.UUID
+	RemovedByEmail string
+	RemovedAt      time.Time
 }
@@ -1,42 +1,80 @@
 package entities
 
 import (
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
 
 // PVZ — пункт выдачи заказов
-// Может быть только в городах: Москва, Санкт-Петербург, Казань
+// Город — активный элемент справочника city (см. CatalogEntry)
 // registrationDate — дата регистрации
 // receptions — список приёмок (UUID)
+// archivedAt — когда ПВЗ выведен из работы; nil — работает
 type PVZ struct {
 	ID               uuid.UUID   `json:"id"`
 	RegistrationDate time.Time   `json:"registrationDate"`
 	City             City        `json:"city"`
 	Receptions       []uuid.UUID `json:"receptions"`
+	ArchivedAt       *time.Time  `json:"archivedAt,omitempty"`
+	PVZDetails
+}
+
+// Максимальная длина текстовых полей PVZDetails (в символах)
+const (
+	PVZNameMaxLen         = 100
+	PVZAddressMaxLen      = 300
+	PVZWorkingHoursMaxLen = 100
+)
+
+// PVZDetails — описание ПВЗ для курьеров и клиентов, все поля необязательны
+// location — nil, если координаты не заданы (такой ПВЗ не участвует в поиске ближайших)
+type PVZDetails struct {
+	Name         string    `json:"name,omitempty"`
+	Address      string    `json:"address,omitempty"`
+	WorkingHours string    `json:"workingHours,omitempty"`
+	Location     *GeoPoint `json:"location,omitempty"`
+}
+
+// Normalize убирает пробелы по краям текстовых полей; ok=false, если поле длиннее лимита
+func (d PVZDetails) Normalize() (PVZDetails, bool) {
+	d.Name = strings.TrimSpace(d.Name)
+	d.Address = strings.TrimSpace(d.Address)
+	d.WorkingHours = strings.TrimSpace(d.WorkingHours)
+	ok := utf8.RuneCountInString(d.Name) <= PVZNameMaxLen &&
+		utf8.RuneCountInString(d.Address) <= PVZAddressMaxLen &&
+		utf8.RuneCountInString(d.WorkingHours) <= PVZWorkingHoursMaxLen
+	return d, ok
+}
+
+// Проверяет, выведен ли ПВЗ из работы
+func (p *PVZ) IsArchived() bool {
+	return p.ArchivedAt != nil
+}
+
+// Выводит ПВЗ из работы; повторный вызов не меняет дату архивации
+func (p *PVZ) Archive(at time.Time) {
+	if p.ArchivedAt == nil {
+		p.ArchivedAt = &at
+	}
+}
+
+// Возвращает ПВЗ в работу
+func (p *PVZ) Unarchive() {
+	p.ArchivedAt = nil
 }
 
 type City string
 
+// Начальное наполнение справочника city (миграция 4_catalogs)
 const (
 	CityMoscow City = "Москва"
 	CitySPB    City = "Санкт-Петербург"
 	CityKazan  City = "Казань"
 )
 
-var AllowedPVZCities = []City{CityMoscow, CitySPB, CityKazan}
-
-// Проверяет, разрешён ли город для PVZ
-func ValidateCity(city City) bool {
-	for _, allowed := range AllowedPVZCities {
-		if city == allowed {
-			return true
-		}
-	}
-	return false
-}
-
 // GenerateUUID возвращает новый UUID
 func GenerateUUID() uuid.UUID {
 	return uuid.New()
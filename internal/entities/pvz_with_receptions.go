@@ -0,0 +1,13 @@
+package entities
+
+// PVZWithReceptions — ПВЗ вместе с приёмками и товарами (read model для GET /pvz)
+type PVZWithReceptions struct {
+	PVZ        PVZ
+	Receptions []ReceptionWithProducts
+}
+
+// ReceptionWithProducts — приёмка вместе с товарами (в порядке добавления)
+type ReceptionWithProducts struct {
+	Reception Reception
+	Products  []Product
+}
@@ -1,7 +1,10 @@
 package entities
 
 import (
+	"fmt"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"errors"
 
@@ -11,30 +14,95 @@ import (
 // Reception — приёмка товаров в ПВЗ
 // id — UUID
 // pvzId — UUID
-// products — список товаров (UUID)
-// status — in_progress/close
+// products — список товаров (UUID) в порядке позиции, последний удаляется первым
+// status — in_progress/paused/close/cancelled (переходы — receptionTransitions)
 // dateTime — дата и время приёмки
+// closedAt — когда приёмку закрыли, nil у открытой (и у закрытых до появления поля)
 
 type ReceptionStatus string
 
 const (
 	ReceptionInProgress ReceptionStatus = "in_progress"
+	ReceptionPaused     ReceptionStatus = "paused" // сканирование остановлено, ПВЗ остаётся занят приёмкой
 	ReceptionClosed     ReceptionStatus = "close"
+	ReceptionCancelled  ReceptionStatus = "cancelled" // машина уехала, товары приёмки аннулированы; конечный статус
 )
 
+// receptionTransitions — допустимые переходы статусов приёмки.
+// Та же таблица задана в БД (CHECK reception_transition_allowed и триггер на reception), менять вместе
+var receptionTransitions = map[ReceptionStatus][]ReceptionStatus{
+	ReceptionInProgress: {ReceptionPaused, ReceptionClosed, ReceptionCancelled},
+	ReceptionPaused:     {ReceptionInProgress, ReceptionClosed, ReceptionCancelled},
+	ReceptionClosed:     {ReceptionInProgress},
+}
+
+// Проверяет, существует ли такой статус приёмки
+func ValidateReceptionStatus(status ReceptionStatus) bool {
+	switch status {
+	case ReceptionInProgress, ReceptionPaused, ReceptionClosed, ReceptionCancelled:
+		return true
+	}
+	return false
+}
+
+// Проверяет, можно ли перевести приёмку из статуса from в статус to
+func CanTransition(from, to ReceptionStatus) bool {
+	for _, allowed := range receptionTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
 type Reception struct {
 	ID       uuid.UUID       `json:"id"`
 	PVZID    uuid.UUID       `json:"pvzId"`
 	Products []uuid.UUID     `json:"products"`
 	Status   ReceptionStatus `json:"status"`
 	DateTime time.Time       `json:"dateTime"`
+	ClosedAt *time.Time      `json:"closedAt,omitempty"`
 }
 
-// Проверяет, открыта ли приёмка
+// ReceptionReasonMaxLen — максимальная длина причины смены статуса (в символах)
+const ReceptionReasonMaxLen = 500
+
+// ReceptionTransition — запись истории статусов приёмки: кто, когда и почему сменил статус
+type ReceptionTransition struct {
+	ID          uuid.UUID
+	ReceptionID uuid.UUID
+	From        ReceptionStatus
+	To          ReceptionStatus
+	ChangedBy   uuid.UUID
+	Reason      string
+	ChangedAt   time.Time
+}
+
+// NormalizeReceptionReason убирает пробелы по краям; ok=false, если причина пустая или длиннее ReceptionReasonMaxLen
+func NormalizeReceptionReason(reason string) (string, bool) {
+	reason = strings.TrimSpace(reason)
+	return reason, reason != "" && utf8.RuneCountInString(reason) <= ReceptionReasonMaxLen
+}
+
+// Проверяет, открыта ли приёмка (можно сканировать и удалять товары)
 func (r *Reception) IsOpen() bool {
 	return r.Status == ReceptionInProgress
 }
 
+// Проверяет, занимает ли приёмка ПВЗ: открыта или на паузе. На ПВЗ может быть только одна такая приёмка
+func (r *Reception) IsActive() bool {
+	return r.Status == ReceptionInProgress || r.Status == ReceptionPaused
+}
+
+// transition переводит приёмку в статус to, если переход допустим
+func (r *Reception) transition(to ReceptionStatus) error {
+	if !CanTransition(r.Status, to) {
+		return fmt.Errorf("нельзя перевести приёмку из статуса %s в %s", r.Status, to)
+	}
+	r.Status = to
+	return nil
+}
+
 // Добавляет товар в приёмку, если она открыта
 func (r *Reception) AddProduct(productID uuid.UUID) error {
 	if !r.IsOpen() {
@@ -57,11 +125,47 @@ func (r *Reception) RemoveLastProduct() (uuid.UUID, error) {
 	return last, nil
 }
 
-// Закрывает приёмку, если она открыта
+// Закрывает открытую или приостановленную приёмку и запоминает время закрытия
 func (r *Reception) Close() error {
-	if !r.IsOpen() {
-		return errors.New("приёмка уже закрыта")
+	if err := r.transition(ReceptionClosed); err != nil {
+		return err
 	}
-	r.Status = ReceptionClosed
+	now := time.Now().UTC()
+	r.ClosedAt = &now
 	return nil
 }
+
+// Проверяет, закрыта ли приёмка не раньше чем window назад от now
+func (r *Reception) ClosedWithin(now time.Time, window time.Duration) bool {
+	return r.Status == ReceptionClosed && r.ClosedAt != nil && !now.After(r.ClosedAt.Add(window))
+}
+
+// Снова открывает закрытую приёмку
+func (r *Reception) Reopen() error {
+	if r.Status != ReceptionClosed {
+		return fmt.Errorf("переоткрыть можно только закрытую приёмку, статус %s", r.Status)
+	}
+	r.ClosedAt = nil
+	return r.transition(ReceptionInProgress)
+}
+
+// Ставит открытую приёмку на паузу: сканирование запрещено, ПВЗ остаётся занят
+func (r *Reception) Pause() error {
+	if r.Status != ReceptionInProgress {
+		return fmt.Errorf("на паузу можно поставить только открытую приёмку, статус %s", r.Status)
+	}
+	return r.transition(ReceptionPaused)
+}
+
+// Снимает приёмку с паузы
+func (r *Reception) Resume() error {
+	if r.Status != ReceptionPaused {
+		return fmt.Errorf("продолжить можно только приёмку на паузе, статус %s", r.Status)
+	}
+	return r.transition(ReceptionInProgress)
+}
+
+// Отменяет открытую или приостановленную приёмку, ПВЗ освобождается
+func (r *Reception) Cancel() error {
+	return r.transition(ReceptionCancelled)
+}
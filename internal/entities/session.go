@@ -0,0 +1,37 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session — сессия входа: создаётся при /login и /dummyLogin, живёт, пока её обновляют refresh-токенами.
+// Access-токен ссылается на сессию claim'ом sid; отозванная сессия (logout) не принимается middleware
+type Session struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Role      UserRole
+	Email     string
+	CreatedAt time.Time
+	RevokedAt *time.Time
+}
+
+// Проверяет, отозвана ли сессия
+func (s *Session) IsRevoked() bool {
+	return s.RevokedAt != nil
+}
+
+// RefreshToken — одноразовый refresh-токен сессии. Хранится только хэш: сам токен знает лишь клиент
+type RefreshToken struct {
+	Hash      string
+	SessionID uuid.UUID
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+	UsedAt    *time.Time // когда токен обменяли на новую пару; повторный обмен — признак кражи
+}
+
+// Проверяет, истёк ли refresh-токен к моменту now
+func (t *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
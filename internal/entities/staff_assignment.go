@@ -0,0 +1,16 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StaffAssignment — назначение сотрудника на ПВЗ: работать с приёмками и товарами ПВЗ
+// сотрудник может, только пока назначен на него
+type StaffAssignment struct {
+	PVZID      uuid.UUID
+	UserID     uuid.UUID
+	AssignedAt time.Time
+	AssignedBy uuid.UUID // модератор, назначивший сотрудника
+}
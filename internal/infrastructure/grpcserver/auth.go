@@ -0,0 +1,65 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/pb/pvz_v1"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type userCtxKey struct{}
+
+// TokenVerifier — проверка access-токена: пользователь и id сессии или ошибка ErrUnauthorized.
+// Реализуется controllers.TokenVerifier, общим с HTTP; gRPC-слой от gin не зависит
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (entities.User, uuid.UUID, error)
+}
+
+// publicMethods — методы, которые не требуют авторизации
+var publicMethods = map[string]bool{
+	pvz_v1.PVZService_GetPVZList_FullMethodName: true,
+}
+
+// AuthUnaryInterceptor проверяет bearer-токен из метаданных gRPC и кладёт
+// пользователя в контекст (аналог SecuredRoutesAuthMiddleware для HTTP)
+func AuthUnaryInterceptor(verifier TokenVerifier) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if publicMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get("authorization")
+		if len(values) == 0 || !strings.HasPrefix(values[0], "Bearer ") {
+			return nil, status.Error(codes.Unauthenticated, "missing or invalid authorization metadata")
+		}
+		user, _, err := verifier.Verify(ctx, strings.TrimPrefix(values[0], "Bearer "))
+		if err != nil {
+			if errors.Is(err, usecases.ErrUnauthorized) {
+				logger.FromContext(ctx).Warn("token rejected", slog.Any("error", err))
+			}
+			return nil, toStatus(ctx, err)
+		}
+		return handler(ContextWithUser(ctx, user), req)
+	}
+}
+
+// ContextWithUser возвращает контекст с пользователем
+func ContextWithUser(ctx context.Context, user entities.User) context.Context {
+	return context.WithValue(ctx, userCtxKey{}, user)
+}
+
+// UserFromContext достаёт пользователя, положенного AuthUnaryInterceptor
+func UserFromContext(ctx context.Context) (entities.User, bool) {
+	user, ok := ctx.Value(userCtxKey{}).(entities.User)
+	return user, ok
+}
@@ -0,0 +1,37 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorCodes — категория ошибки usecase -> gRPC-код (аналог controllers.MapError для HTTP)
+var errorCodes = []struct {
+	kind error
+	code codes.Code
+}{
+	{usecases.ErrValidation, codes.InvalidArgument},
+	{usecases.ErrUnauthorized, codes.Unauthenticated},
+	{usecases.ErrForbidden, codes.PermissionDenied},
+	{usecases.ErrNotFound, codes.NotFound},
+	{usecases.ErrConflict, codes.AlreadyExists},
+	{usecases.ErrNoOpenReception, codes.FailedPrecondition},
+}
+
+// toStatus преобразует ошибку usecase в gRPC-статус.
+// Неизвестные ошибки — Internal без деталей
+func toStatus(ctx context.Context, err error) error {
+	for _, m := range errorCodes {
+		if errors.Is(err, m.kind) {
+			return status.Error(m.code, err.Error())
+		}
+	}
+	logger.FromContext(ctx).Error("request failed", slog.Any("error", err))
+	return status.Error(codes.Internal, "internal error")
+}
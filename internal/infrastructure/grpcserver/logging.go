@@ -0,0 +1,48 @@
+package grpcserver
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// requestIDMetadataKey — ключ метаданных с идентификатором запроса (аналог X-Request-ID)
+const requestIDMetadataKey = "x-request-id"
+
+// LoggingUnaryInterceptor присваивает вызову request_id, кладёт логгер в контекст
+// и логирует завершение вызова с gRPC-кодом
+func LoggingUnaryInterceptor(base *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		requestID := ""
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(requestIDMetadataKey); len(values) > 0 {
+				requestID = values[0]
+			}
+		}
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadataKey, requestID))
+
+		l := base.With(slog.String("request_id", requestID), slog.String("grpc_method", info.FullMethod))
+		resp, err := handler(logger.WithContext(ctx, l), req)
+
+		attrs := []any{
+			slog.String("code", status.Code(err).String()),
+			slog.Duration("latency", time.Since(start)),
+		}
+		if err != nil {
+			l.Warn("grpc call completed", append(attrs, slog.Any("error", err))...)
+		} else {
+			l.Info("grpc call completed", attrs...)
+		}
+		return resp, err
+	}
+}
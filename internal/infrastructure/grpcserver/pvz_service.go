@@ -0,0 +1,217 @@
+package grpcserver
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/pb/pvz_v1"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// PVZRepository — интерфейс для получения списка ПВЗ (без фильтров и пагинации)
+type PVZRepository interface {
+	List(ctx context.Context, startDate, endDate *time.Time, page, limit int, includeArchived bool) ([]entities.PVZ, error)
+}
+
+// PVZGrpcService — реализация gRPC-сервиса PVZService (см. pvz.proto)
+// GetPVZList не требует авторизации, остальные методы вызывают те же usecase, что и gin-контроллеры
+type PVZGrpcService struct {
+	pvz_v1.UnimplementedPVZServiceServer
+	repo              PVZRepository
+	createPVZUC       usecases.CreatePVZUseCaseIface
+	createReceptionUC usecases.CreateReceptionUseCaseIface
+	addProductUC      usecases.AddProductUseCaseIface
+	deleteLastUC      usecases.DeleteLastProductUseCaseIface
+	closeReceptionUC  usecases.CloseReceptionUseCaseIface
+}
+
+// NewPVZGrpcService создаёт новый PVZGrpcService
+func NewPVZGrpcService(
+	repo PVZRepository,
+	createPVZ usecases.CreatePVZUseCaseIface,
+	createReception usecases.CreateReceptionUseCaseIface,
+	addProduct usecases.AddProductUseCaseIface,
+	deleteLast usecases.DeleteLastProductUseCaseIface,
+	closeReception usecases.CloseReceptionUseCaseIface,
+) *PVZGrpcService {
+	return &PVZGrpcService{
+		repo:              repo,
+		createPVZUC:       createPVZ,
+		createReceptionUC: createReception,
+		addProductUC:      addProduct,
+		deleteLastUC:      deleteLast,
+		closeReceptionUC:  closeReception,
+	}
+}
+
+// GetPVZList возвращает все работающие ПВЗ (архивные не возвращаются)
+func (s *PVZGrpcService) GetPVZList(ctx context.Context, _ *pvz_v1.GetPVZListRequest) (*pvz_v1.GetPVZListResponse, error) {
+	// page = 0 и limit = 0 — без пагинации, все записи
+	pvzs, err := s.repo.List(ctx, nil, nil, 0, 0, false)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	resp := &pvz_v1.GetPVZListResponse{Pvzs: make([]*pvz_v1.PVZ, 0, len(pvzs))}
+	for _, p := range pvzs {
+		resp.Pvzs = append(resp.Pvzs, toProtoPVZ(p))
+	}
+	return resp, nil
+}
+
+// CreatePVZ создаёт ПВЗ (только модератор)
+func (s *PVZGrpcService) CreatePVZ(ctx context.Context, req *pvz_v1.CreatePVZRequest) (*pvz_v1.PVZ, error) {
+	user, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	details := entities.PVZDetails{
+		Name:         req.GetName(),
+		Address:      req.GetAddress(),
+		WorkingHours: req.GetWorkingHours(),
+	}
+	if loc := req.GetLocation(); loc != nil {
+		details.Location = &entities.GeoPoint{Lat: loc.GetLat(), Lon: loc.GetLon()}
+	}
+	pvz, err := s.createPVZUC.Execute(ctx, user, entities.City(req.GetCity()), details)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toProtoPVZ(pvz), nil
+}
+
+// CreateReception создаёт приёмку (только pvz_staff)
+func (s *PVZGrpcService) CreateReception(ctx context.Context, req *pvz_v1.CreateReceptionRequest) (*pvz_v1.Reception, error) {
+	user, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pvzID, err := parsePVZID(req.GetPvzId())
+	if err != nil {
+		return nil, err
+	}
+	rec, err := s.createReceptionUC.Execute(ctx, user, pvzID)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toProtoReception(rec), nil
+}
+
+// AddProduct добавляет товар в открытую приёмку (только pvz_staff)
+func (s *PVZGrpcService) AddProduct(ctx context.Context, req *pvz_v1.AddProductRequest) (*pvz_v1.Product, error) {
+	user, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pvzID, err := parsePVZID(req.GetPvzId())
+	if err != nil {
+		return nil, err
+	}
+	product, err := s.addProductUC.Execute(ctx, user, pvzID, entities.ProductType(req.GetType()), req.GetBarcode())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toProtoProduct(product), nil
+}
+
+// DeleteLastProduct удаляет последний товар из открытой приёмки (LIFO, только pvz_staff)
+func (s *PVZGrpcService) DeleteLastProduct(ctx context.Context, req *pvz_v1.DeleteLastProductRequest) (*pvz_v1.DeleteLastProductResponse, error) {
+	user, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pvzID, err := parsePVZID(req.GetPvzId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.deleteLastUC.Execute(ctx, user, pvzID); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &pvz_v1.DeleteLastProductResponse{}, nil
+}
+
+// CloseLastReception закрывает открытую приёмку ПВЗ (только pvz_staff)
+func (s *PVZGrpcService) CloseLastReception(ctx context.Context, req *pvz_v1.CloseLastReceptionRequest) (*pvz_v1.Reception, error) {
+	user, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pvzID, err := parsePVZID(req.GetPvzId())
+	if err != nil {
+		return nil, err
+	}
+	rec, err := s.closeReceptionUC.Execute(ctx, user, pvzID)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toProtoReception(rec), nil
+}
+
+// requireUser достаёт пользователя из контекста (кладётся AuthUnaryInterceptor)
+func requireUser(ctx context.Context) (entities.User, error) {
+	user, ok := UserFromContext(ctx)
+	if !ok {
+		return entities.User{}, status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	return user, nil
+}
+
+// parsePVZID парсит идентификатор ПВЗ из запроса
+func parsePVZID(s string) (uuid.UUID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.InvalidArgument, "bad pvzId")
+	}
+	return id, nil
+}
+
+// toProtoPVZ преобразует доменную модель PVZ в protobuf-сообщение
+func toProtoPVZ(p entities.PVZ) *pvz_v1.PVZ {
+	res := &pvz_v1.PVZ{
+		Id:               p.ID.String(),
+		RegistrationDate: timestamppb.New(p.RegistrationDate),
+		City:             string(p.City),
+		Name:             p.Name,
+		Address:          p.Address,
+		WorkingHours:     p.WorkingHours,
+	}
+	if p.Location != nil {
+		res.Location = &pvz_v1.GeoPoint{Lat: p.Location.Lat, Lon: p.Location.Lon}
+	}
+	return res
+}
+
+// toProtoReception преобразует доменную модель Reception в protobuf-сообщение
+func toProtoReception(r entities.Reception) *pvz_v1.Reception {
+	st := pvz_v1.ReceptionStatus_RECEPTION_STATUS_IN_PROGRESS
+	switch r.Status {
+	case entities.ReceptionPaused:
+		st = pvz_v1.ReceptionStatus_RECEPTION_STATUS_PAUSED
+	case entities.ReceptionClosed:
+		st = pvz_v1.ReceptionStatus_RECEPTION_STATUS_CLOSED
+	case entities.ReceptionCancelled:
+		st = pvz_v1.ReceptionStatus_RECEPTION_STATUS_CANCELLED
+	}
+	return &pvz_v1.Reception{
+		Id:       r.ID.String(),
+		DateTime: timestamppb.New(r.DateTime),
+		PvzId:    r.PVZID.String(),
+		Status:   st,
+	}
+}
+
+// toProtoProduct преобразует доменную модель Product в protobuf-сообщение
+func toProtoProduct(p entities.Product) *pvz_v1.Product {
+	return &pvz_v1.Product{
+		Id:          p.ID.String(),
+		DateTime:    timestamppb.New(p.DateTime),
+		Type:        string(p.Type),
+		ReceptionId: p.ReceptionID.String(),
+		Position:    int32(p.Position),
+		Barcode:     p.Barcode,
+	}
+}
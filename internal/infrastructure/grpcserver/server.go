@@ -0,0 +1,31 @@
+package grpcserver
+
+import (
+	"log/slog"
+	"net"
+
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/pb/pvz_v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// NewServer создаёт gRPC-сервер с зарегистрированным PVZService,
+// интерсепторами логирования и JWT-авторизации
+func NewServer(pvzService pvz_v1.PVZServiceServer, verifier TokenVerifier, log *slog.Logger) *grpc.Server {
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		LoggingUnaryInterceptor(log),
+		AuthUnaryInterceptor(verifier),
+	))
+	pvz_v1.RegisterPVZServiceServer(srv, pvzService)
+	reflection.Register(srv)
+	return srv
+}
+
+// StartServer запускает gRPC-сервер на указанном порту (блокирующий вызов)
+func StartServer(srv *grpc.Server, port string) error {
+	lis, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		return err
+	}
+	return srv.Serve(lis)
+}
@@ -0,0 +1,101 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// PrometheusExporter — технические (HTTP) и бизнес-метрики сервиса (см. CA_c4_class.puml)
+// Реализует usecases.BusinessMetrics
+type PrometheusExporter struct {
+	registry *prometheus.Registry
+
+	requestsTotal   *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+
+	pvzCreated        prometheus.Counter
+	receptionsCreated prometheus.Counter
+	productsAdded     prometheus.Counter
+}
+
+// NewPrometheusExporter создаёт и регистрирует все метрики в отдельном registry
+func NewPrometheusExporter() *PrometheusExporter {
+	e := &PrometheusExporter{
+		registry: prometheus.NewRegistry(),
+		requestsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Количество HTTP-запросов",
+		}, []string{"method", "route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name: "http_request_duration_seconds",
+			Help: "Время ответа на HTTP-запрос",
+			// 0.1 — граница SLI в 100 мс
+			Buckets: []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"method", "route", "status"}),
+		pvzCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "pvz_created_total",
+			Help: "Количество созданных ПВЗ",
+		}),
+		receptionsCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "receptions_created_total",
+			Help: "Количество созданных приёмок",
+		}),
+		productsAdded: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "products_added_total",
+			Help: "Количество добавленных товаров",
+		}),
+	}
+	e.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		e.requestsTotal,
+		e.requestDuration,
+		e.pvzCreated,
+		e.receptionsCreated,
+		e.productsAdded,
+	)
+	return e
+}
+
+// GinMiddleware считает запросы и время ответа, метки — метод, шаблон маршрута gin и статус
+func (e *PrometheusExporter) GinMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+		route := ctx.FullPath()
+		if route == "" {
+			// Не плодим метки для несуществующих путей
+			route = "unmatched"
+		}
+		status := strconv.Itoa(ctx.Writer.Status())
+		e.requestsTotal.WithLabelValues(ctx.Request.Method, route, status).Inc()
+		e.requestDuration.WithLabelValues(ctx.Request.Method, route, status).Observe(time.Since(start).Seconds())
+	}
+}
+
+// IncPVZCreated увеличивает счётчик созданных ПВЗ
+func (e *PrometheusExporter) IncPVZCreated() { e.pvzCreated.Inc() }
+
+// IncReceptionCreated увеличивает счётчик созданных приёмок
+func (e *PrometheusExporter) IncReceptionCreated() { e.receptionsCreated.Inc() }
+
+// IncProductAdded увеличивает счётчик добавленных товаров
+func (e *PrometheusExporter) IncProductAdded() { e.productsAdded.Inc() }
+
+// Handler возвращает http.Handler для GET /metrics
+func (e *PrometheusExporter) Handler() http.Handler {
+	return promhttp.HandlerFor(e.registry, promhttp.HandlerOpts{Registry: e.registry})
+}
+
+// NewServer возвращает отдельный HTTP-сервер с /metrics; запуск и остановка — на вызывающем
+func (e *PrometheusExporter) NewServer(port string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", e.Handler())
+	return &http.Server{Addr: ":" + port, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+}
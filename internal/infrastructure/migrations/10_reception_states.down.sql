@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS reception_status_transition ON reception;
+DROP FUNCTION IF EXISTS reception_status_transition_check();
+ALTER TABLE reception_transition DROP CONSTRAINT IF EXISTS reception_transition_allowed;
+DROP INDEX IF EXISTS one_open_reception_per_pvz;
+CREATE UNIQUE INDEX IF NOT EXISTS one_open_reception_per_pvz ON reception(pvz_id) WHERE status = 'in_progress';
+ALTER TABLE reception DROP CONSTRAINT IF EXISTS reception_status_check;
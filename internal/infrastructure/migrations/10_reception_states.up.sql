@@ -0,0 +1,33 @@
+-- статусы приёмки paused (сканирование остановлено, ПВЗ занят) и cancelled (товары аннулированы).
+-- Допустимые переходы — те же, что в entities.receptionTransitions
+ALTER TABLE reception ADD CONSTRAINT reception_status_check
+    CHECK (status IN ('in_progress', 'paused', 'close', 'cancelled'));
+
+-- приёмка на паузе тоже занимает ПВЗ: на нём может быть только одна открытая или приостановленная приёмка
+DROP INDEX IF EXISTS one_open_reception_per_pvz;
+CREATE UNIQUE INDEX IF NOT EXISTS one_open_reception_per_pvz ON reception(pvz_id) WHERE status IN ('in_progress', 'paused');
+
+ALTER TABLE reception_transition ADD CONSTRAINT reception_transition_allowed CHECK ((from_status, to_status) IN (
+    ('in_progress', 'paused'), ('in_progress', 'close'), ('in_progress', 'cancelled'),
+    ('paused', 'in_progress'), ('paused', 'close'), ('paused', 'cancelled'),
+    ('close', 'in_progress')
+));
+
+-- смена статуса самой приёмки проверяется триггером: CHECK не видит старое значение строки
+CREATE OR REPLACE FUNCTION reception_status_transition_check() RETURNS trigger AS $$
+BEGIN
+    IF NEW.status IS DISTINCT FROM OLD.status AND (OLD.status, NEW.status) NOT IN (
+        ('in_progress', 'paused'), ('in_progress', 'close'), ('in_progress', 'cancelled'),
+        ('paused', 'in_progress'), ('paused', 'close'), ('paused', 'cancelled'),
+        ('close', 'in_progress')
+    ) THEN
+        RAISE EXCEPTION 'недопустимая смена статуса приёмки: % -> %', OLD.status, NEW.status
+            USING ERRCODE = 'check_violation', CONSTRAINT = 'reception_status_transition';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS reception_status_transition ON reception;
+CREATE TRIGGER reception_status_transition BEFORE UPDATE OF status ON reception
+    FOR EACH ROW EXECUTE FUNCTION reception_status_transition_check();
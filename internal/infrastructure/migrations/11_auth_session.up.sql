@@ -0,0 +1,21 @@
+-- сессии входа: access-токен несёт id сессии (claim sid), отозванная сессия (revoked_at) не принимается.
+-- user_id — id из users; у /dummyLogin это постоянный тестовый пользователь роли (DummyLoginUseCase сохраняет его в users)
+CREATE TABLE IF NOT EXISTS auth_session (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    role TEXT NOT NULL,
+    email TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS auth_session_user_id_idx ON auth_session(user_id);
+
+-- одноразовые refresh-токены: хранится только sha256, использованный токен помечается used_at
+CREATE TABLE IF NOT EXISTS refresh_token (
+    hash TEXT PRIMARY KEY,
+    session_id UUID NOT NULL REFERENCES auth_session(id) ON DELETE CASCADE,
+    issued_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS refresh_token_session_id_idx ON refresh_token(session_id);
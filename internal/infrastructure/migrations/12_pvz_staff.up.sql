@@ -0,0 +1,9 @@
+-- назначения сотрудников на ПВЗ: приёмки и товары ПВЗ доступны только назначенным.
+CREATE TABLE IF NOT EXISTS pvz_staff (
+    pvz_id UUID NOT NULL REFERENCES pvz(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    assigned_at TIMESTAMPTZ NOT NULL,
+    assigned_by UUID NOT NULL,
+    PRIMARY KEY (user_id, pvz_id)
+);
+CREATE INDEX IF NOT EXISTS pvz_staff_pvz_id_idx ON pvz_staff(pvz_id);
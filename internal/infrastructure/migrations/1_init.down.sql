@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS product;
+DROP TABLE IF EXISTS reception;
+DROP TABLE IF EXISTS pvz;
+DROP TABLE IF EXISTS users;
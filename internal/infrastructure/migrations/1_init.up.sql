@@ -1,9 +1,3 @@
-DO $$ 
-BEGIN
-    IF NOT EXISTS (SELECT 1 FROM pg_database WHERE datname = 'pvz_service') THEN
-        CREATE DATABASE pvz_service;
-    END IF;
-END $$; 
 -- users table migration
 CREATE TABLE IF NOT EXISTS users (
     id UUID PRIMARY KEY,
@@ -35,4 +29,4 @@ CREATE TABLE IF NOT EXISTS product (
     reception_id UUID NOT NULL REFERENCES reception(id),
     type TEXT NOT NULL,
     date_time TIMESTAMPTZ NOT NULL
-);
\ No newline at end of file
+);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS product_reception_id_date_time_idx;
+DROP INDEX IF EXISTS reception_pvz_id_date_time_idx;
+DROP INDEX IF EXISTS pvz_registration_date_idx;
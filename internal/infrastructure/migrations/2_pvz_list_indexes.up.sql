@@ -0,0 +1,4 @@
+-- индексы под агрегированный листинг GET /pvz и LIFO-удаление товаров
+CREATE INDEX IF NOT EXISTS pvz_registration_date_idx ON pvz(registration_date, id);
+CREATE INDEX IF NOT EXISTS reception_pvz_id_date_time_idx ON reception(pvz_id, date_time);
+CREATE INDEX IF NOT EXISTS product_reception_id_date_time_idx ON product(reception_id, date_time);
@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS product_reception_id_date_time_idx ON product(reception_id, date_time);
+DROP INDEX IF EXISTS product_reception_id_position_idx;
+ALTER TABLE product DROP COLUMN IF EXISTS position;
@@ -0,0 +1,14 @@
+-- порядковый номер товара в приёмке: LIFO не зависит от точности и рассинхрона часов
+ALTER TABLE product ADD COLUMN IF NOT EXISTS position INT;
+
+UPDATE product p
+SET position = numbered.rn
+FROM (
+    SELECT id, row_number() OVER (PARTITION BY reception_id ORDER BY date_time, id) AS rn
+    FROM product
+) numbered
+WHERE p.id = numbered.id;
+
+ALTER TABLE product ALTER COLUMN position SET NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS product_reception_id_position_idx ON product(reception_id, position);
+DROP INDEX IF EXISTS product_reception_id_date_time_idx;
@@ -0,0 +1,4 @@
+ALTER TABLE product DROP CONSTRAINT IF EXISTS product_type_fkey;
+ALTER TABLE pvz DROP CONSTRAINT IF EXISTS pvz_city_fkey;
+DROP TABLE IF EXISTS product_type;
+DROP TABLE IF EXISTS city;
@@ -0,0 +1,21 @@
+-- справочники городов и типов товаров: новые значения добавляет модератор, без релиза
+CREATE TABLE IF NOT EXISTS city (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    active BOOLEAN NOT NULL DEFAULT true
+);
+INSERT INTO city (name) VALUES ('Москва'), ('Санкт-Петербург'), ('Казань') ON CONFLICT (name) DO NOTHING;
+-- города уже созданных ПВЗ тоже попадают в справочник, иначе внешний ключ не создать
+INSERT INTO city (name) SELECT DISTINCT city FROM pvz ON CONFLICT (name) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS product_type (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    active BOOLEAN NOT NULL DEFAULT true
+);
+INSERT INTO product_type (name) VALUES ('электроника'), ('одежда'), ('обувь') ON CONFLICT (name) DO NOTHING;
+INSERT INTO product_type (name) SELECT DISTINCT type FROM product ON CONFLICT (name) DO NOTHING;
+
+-- переименование элемента справочника переименовывает его и в уже созданных строках
+ALTER TABLE pvz ADD CONSTRAINT pvz_city_fkey FOREIGN KEY (city) REFERENCES city(name) ON UPDATE CASCADE;
+ALTER TABLE product ADD CONSTRAINT product_type_fkey FOREIGN KEY (type) REFERENCES product_type(name) ON UPDATE CASCADE;
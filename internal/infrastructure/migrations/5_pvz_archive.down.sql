@@ -0,0 +1 @@
+ALTER TABLE pvz DROP COLUMN IF EXISTS archived_at;
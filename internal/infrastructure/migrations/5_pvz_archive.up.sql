@@ -0,0 +1,2 @@
+-- выведенный из работы ПВЗ: не принимает приёмки и не показывается в листинге по умолчанию
+ALTER TABLE pvz ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ;
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS pvz_location_idx;
+ALTER TABLE pvz DROP CONSTRAINT IF EXISTS pvz_location_check;
+ALTER TABLE pvz
+    DROP COLUMN IF EXISTS longitude,
+    DROP COLUMN IF EXISTS latitude,
+    DROP COLUMN IF EXISTS working_hours,
+    DROP COLUMN IF EXISTS address,
+    DROP COLUMN IF EXISTS name;
@@ -0,0 +1,17 @@
+-- описание ПВЗ для курьеров и клиентов; у ранее созданных ПВЗ поля пустые, координат нет
+ALTER TABLE pvz
+    ADD COLUMN IF NOT EXISTS name TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS address TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS working_hours TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION;
+
+ALTER TABLE pvz ADD CONSTRAINT pvz_location_check CHECK (
+    (latitude IS NULL) = (longitude IS NULL)
+    AND latitude BETWEEN -90 AND 90
+    AND longitude BETWEEN -180 AND 180
+);
+
+-- поиск ближайших: сначала ограничивающий прямоугольник по индексу, потом точное расстояние
+CREATE INDEX IF NOT EXISTS pvz_location_idx ON pvz(latitude, longitude)
+    WHERE archived_at IS NULL AND latitude IS NOT NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS product_barcode_idx;
+DROP INDEX IF EXISTS product_reception_id_barcode_idx;
+ALTER TABLE product DROP COLUMN IF EXISTS barcode;
@@ -0,0 +1,7 @@
+-- штрихкод посылки; у ранее принятых товаров его нет
+ALTER TABLE product ADD COLUMN IF NOT EXISTS barcode VARCHAR(64);
+
+-- один штрихкод не сканируется в приёмку дважды
+CREATE UNIQUE INDEX IF NOT EXISTS product_reception_id_barcode_idx ON product(reception_id, barcode);
+-- поиск посылки по штрихкоду (GET /products?barcode=)
+CREATE INDEX IF NOT EXISTS product_barcode_idx ON product(barcode) WHERE barcode IS NOT NULL;
@@ -0,0 +1,15 @@
+-- журнал удалений товаров из открытых приёмок (DELETE /receptions/{id}/products/{productId}):
+-- товар удаляется из product, его копия и автор удаления остаются здесь
+CREATE TABLE IF NOT EXISTS product_removal (
+    id UUID PRIMARY KEY,
+    product_id UUID NOT NULL,
+    reception_id UUID NOT NULL REFERENCES reception(id),
+    type TEXT NOT NULL,
+    barcode VARCHAR(64),
+    position INT NOT NULL,
+    added_at TIMESTAMPTZ NOT NULL,
+    removed_by UUID NOT NULL,
+    removed_by_email TEXT NOT NULL DEFAULT '',
+    removed_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS product_removal_reception_id_idx ON product_removal(reception_id, removed_at);
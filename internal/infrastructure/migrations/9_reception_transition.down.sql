@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS reception_transition;
+ALTER TABLE reception DROP COLUMN IF EXISTS closed_at;
@@ -0,0 +1,15 @@
+-- время закрытия приёмки: от него отсчитывается окно, в которое модератор может её переоткрыть.
+-- У приёмок, закрытых до миграции, время неизвестно (NULL) — их переоткрыть нельзя
+ALTER TABLE reception ADD COLUMN IF NOT EXISTS closed_at TIMESTAMPTZ;
+
+-- история смены статусов приёмки: кто, когда и почему закрыл или переоткрыл
+CREATE TABLE IF NOT EXISTS reception_transition (
+    id UUID PRIMARY KEY,
+    reception_id UUID NOT NULL REFERENCES reception(id),
+    from_status TEXT NOT NULL,
+    to_status TEXT NOT NULL,
+    changed_by UUID NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    changed_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS reception_transition_reception_id_idx ON reception_transition(reception_id, changed_at);
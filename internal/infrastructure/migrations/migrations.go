@@ -0,0 +1,320 @@
+// Package migrations — схема БД и встроенный раннер миграций.
+// SQL-файлы вшиваются в бинарник (embed), применённые версии хранятся в таблице schema_versions.
+// Формат имён: <версия>_<название>.up.sql / <версия>_<название>.down.sql
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+const (
+	versionsTable = "schema_versions"
+	// legacyTable — таблица golang-migrate, которым схема накатывалась раньше
+	legacyTable = "schema_migrations"
+	// lockKey — ключ pg_advisory_lock: параллельные раннеры (несколько подов, тестовые пакеты) ждут друг друга
+	lockKey = 20250415
+)
+
+var fileName = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Migration — одна версия схемы
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Applied — применённая версия
+type Applied struct {
+	Version   int64
+	Name      string
+	AppliedAt time.Time
+}
+
+// Status — состояние схемы: текущая и последняя известная бинарнику версии
+type Status struct {
+	Current int64
+	Latest  int64
+	Applied []Applied
+	Pending []Migration
+}
+
+// Load разбирает встроенные SQL-файлы. У каждой версии должны быть и up, и down
+func Load() ([]Migration, error) {
+	return load(files)
+}
+
+func load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	byVersion := map[int64]*Migration{}
+	for _, e := range entries {
+		m := fileName.FindStringSubmatch(e.Name())
+		if m == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s: bad version", e.Name())
+		}
+		body, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, err
+		}
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		}
+		if mig.Name != m[2] {
+			return nil, fmt.Errorf("migration %d: name mismatch %q vs %q", version, mig.Name, m[2])
+		}
+		if m[3] == "up" {
+			mig.Up = string(body)
+		} else {
+			mig.Down = string(body)
+		}
+	}
+	res := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.Up == "" || mig.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s: both up and down files are required", mig.Version, mig.Name)
+		}
+		res = append(res, *mig)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Version < res[j].Version })
+	return res, nil
+}
+
+// Migrator применяет и откатывает встроенные миграции. Каждая версия — в своей транзакции
+// вместе с записью в schema_versions, поэтому «грязного» состояния не бывает
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// NewMigrator создаёт Migrator со встроенными миграциями
+func NewMigrator(db *sql.DB) (*Migrator, error) {
+	migs, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migs}, nil
+}
+
+// Latest возвращает последнюю версию, известную бинарнику
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Up применяет все неприменённые миграции
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.To(ctx, m.Latest())
+}
+
+// Down откатывает последнюю применённую миграцию
+func (m *Migrator) Down(ctx context.Context) error {
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		current, err := m.current(ctx, conn)
+		if err != nil || current == 0 {
+			return err
+		}
+		return m.migrate(ctx, conn, current, m.previous(current))
+	})
+}
+
+// To приводит схему к версии target: вперёд или назад. 0 — откатить всё
+func (m *Migrator) To(ctx context.Context, target int64) error {
+	if target != 0 && m.find(target) == nil {
+		return fmt.Errorf("unknown migration version %d", target)
+	}
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		current, err := m.current(ctx, conn)
+		if err != nil {
+			return err
+		}
+		return m.migrate(ctx, conn, current, target)
+	})
+}
+
+// Status возвращает применённые и ожидающие версии
+func (m *Migrator) Status(ctx context.Context) (Status, error) {
+	st := Status{Latest: m.Latest()}
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		rows, err := conn.QueryContext(ctx, "SELECT version, name, applied_at FROM "+versionsTable+" ORDER BY version")
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		applied := map[int64]bool{}
+		for rows.Next() {
+			var a Applied
+			if err := rows.Scan(&a.Version, &a.Name, &a.AppliedAt); err != nil {
+				return err
+			}
+			st.Applied = append(st.Applied, a)
+			st.Current = a.Version
+			applied[a.Version] = true
+		}
+		for _, mig := range m.migrations {
+			if !applied[mig.Version] {
+				st.Pending = append(st.Pending, mig)
+			}
+		}
+		return rows.Err()
+	})
+	return st, err
+}
+
+// migrate шагает от current к target по одной версии
+func (m *Migrator) migrate(ctx context.Context, conn *sql.Conn, current, target int64) error {
+	log := logger.FromContext(ctx)
+	for _, mig := range m.migrations {
+		if mig.Version <= current || mig.Version > target {
+			continue
+		}
+		if err := m.step(ctx, conn, mig.Up, "INSERT INTO "+versionsTable+" (version, name) VALUES ($1, $2)", mig.Version, mig.Name); err != nil {
+			return fmt.Errorf("migration %d_%s up: %w", mig.Version, mig.Name, err)
+		}
+		log.Info("migration applied", slog.Int64("version", mig.Version), slog.String("name", mig.Name))
+	}
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		mig := m.migrations[i]
+		if mig.Version > current || mig.Version <= target {
+			continue
+		}
+		if err := m.step(ctx, conn, mig.Down, "DELETE FROM "+versionsTable+" WHERE version = $1", mig.Version); err != nil {
+			return fmt.Errorf("migration %d_%s down: %w", mig.Version, mig.Name, err)
+		}
+		log.Info("migration rolled back", slog.Int64("version", mig.Version), slog.String("name", mig.Name))
+	}
+	return nil
+}
+
+// step выполняет SQL миграции и обновляет schema_versions в одной транзакции
+func (m *Migrator) step(ctx context.Context, conn *sql.Conn, script, bookkeeping string, args ...any) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, bookkeeping, args...); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// withLock выполняет fn на выделенном соединении под advisory-блокировкой,
+// предварительно создав schema_versions и перенеся версию из schema_migrations
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+		return err
+	}
+	defer func() {
+		// ctx мог уже истечь, блокировку всё равно нужно снять
+		_, _ = conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey)
+	}()
+	if _, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+versionsTable+` (
+    version BIGINT PRIMARY KEY,
+    name TEXT NOT NULL,
+    applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+)`); err != nil {
+		return err
+	}
+	if err := m.adoptLegacy(ctx, conn); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+// adoptLegacy переносит версию из schema_migrations (golang-migrate), если schema_versions ещё пуста:
+// уже накатанная схема не применяется повторно
+func (m *Migrator) adoptLegacy(ctx context.Context, conn *sql.Conn) error {
+	var hasVersions bool
+	if err := conn.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM "+versionsTable+")").Scan(&hasVersions); err != nil {
+		return err
+	}
+	var legacy sql.NullString
+	if err := conn.QueryRowContext(ctx, "SELECT to_regclass($1)::text", legacyTable).Scan(&legacy); err != nil {
+		return err
+	}
+	if hasVersions || !legacy.Valid {
+		return nil
+	}
+	var version int64
+	var dirty bool
+	err := conn.QueryRowContext(ctx, "SELECT version, dirty FROM "+legacyTable+" LIMIT 1").Scan(&version, &dirty)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("%s is dirty at version %d: fix the schema by hand before migrating", legacyTable, version)
+	}
+	for _, mig := range m.migrations {
+		if mig.Version > version {
+			break
+		}
+		if _, err := conn.ExecContext(ctx, "INSERT INTO "+versionsTable+" (version, name) VALUES ($1, $2)", mig.Version, mig.Name); err != nil {
+			return err
+		}
+	}
+	logger.FromContext(ctx).Info("adopted legacy schema version", slog.Int64("version", version))
+	return nil
+}
+
+func (m *Migrator) current(ctx context.Context, conn *sql.Conn) (int64, error) {
+	var v int64
+	err := conn.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM "+versionsTable).Scan(&v)
+	return v, err
+}
+
+func (m *Migrator) previous(version int64) int64 {
+	var prev int64
+	for _, mig := range m.migrations {
+		if mig.Version < version {
+			prev = mig.Version
+		}
+	}
+	return prev
+}
+
+func (m *Migrator) find(version int64) *Migration {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
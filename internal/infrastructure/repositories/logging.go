@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
+)
+
+// logSQLError логирует ошибку SQL-запроса с текстом запроса и дополнительными полями.
+// Аргументы запроса не логируются (там могут быть хэши паролей)
+func logSQLError(ctx context.Context, op string, q squirrel.Sqlizer, err error, attrs ...any) {
+	query, _, _ := q.ToSql()
+	attrs = append(attrs,
+		slog.String("op", op),
+		slog.String("query", query),
+		slog.Any("error", err),
+	)
+	logger.FromContext(ctx).Error("sql query failed", attrs...)
+}
@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+)
+
+// uniqueViolation — SQLSTATE нарушения уникальности
+const uniqueViolation = "23505"
+
+// catalogTables — таблица справочника по его виду (имена таблиц не приходят от клиента)
+var catalogTables = map[entities.CatalogKind]string{
+	entities.CatalogCity:        "city",
+	entities.CatalogProductType: "product_type",
+}
+
+// PGCatalogRepository — реализация usecases.CatalogRepository для PostgreSQL
+type PGCatalogRepository struct {
+	db *sql.DB
+	qb squirrel.StatementBuilderType
+}
+
+// NewPGCatalogRepository создаёт новый PGCatalogRepository
+func NewPGCatalogRepository(db *sql.DB) *PGCatalogRepository {
+	return &PGCatalogRepository{
+		db: db,
+		qb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// List возвращает все элементы справочника, включая отключённые, по названию
+func (r *PGCatalogRepository) List(ctx context.Context, kind entities.CatalogKind) ([]entities.CatalogEntry, error) {
+	table, err := catalogTable(kind)
+	if err != nil {
+		return nil, err
+	}
+	q := r.qb.Select("id", "name", "active").From(table).OrderBy("name")
+	rows, err := q.RunWith(conn(ctx, r.db)).QueryContext(ctx)
+	if err != nil {
+		logSQLError(ctx, "PGCatalogRepository.List", q, err)
+		return nil, err
+	}
+	defer rows.Close()
+	var res []entities.CatalogEntry
+	for rows.Next() {
+		e := entities.CatalogEntry{Kind: kind}
+		if err := rows.Scan(&e.ID, &e.Name, &e.Active); err != nil {
+			return nil, err
+		}
+		res = append(res, e)
+	}
+	return res, rows.Err()
+}
+
+// Add добавляет активный элемент; занятое название — usecases.ErrCatalogEntryExists
+func (r *PGCatalogRepository) Add(ctx context.Context, kind entities.CatalogKind, name string) (entities.CatalogEntry, error) {
+	table, err := catalogTable(kind)
+	if err != nil {
+		return entities.CatalogEntry{}, err
+	}
+	q := r.qb.Insert(table).
+		Columns("name").
+		Values(name).
+		Suffix("RETURNING id, name, active")
+	e := entities.CatalogEntry{Kind: kind}
+	if err := q.RunWith(conn(ctx, r.db)).QueryRowContext(ctx).Scan(&e.ID, &e.Name, &e.Active); err != nil {
+		if isUniqueViolation(err) {
+			return entities.CatalogEntry{}, usecases.ErrCatalogEntryExists
+		}
+		logSQLError(ctx, "PGCatalogRepository.Add", q, err)
+		return entities.CatalogEntry{}, err
+	}
+	return e, nil
+}
+
+// Update меняет переданные поля элемента. Новое имя каскадно переносится в pvz.city / product.type
+func (r *PGCatalogRepository) Update(ctx context.Context, kind entities.CatalogKind, id int64, patch usecases.CatalogEntryPatch) (*entities.CatalogEntry, error) {
+	table, err := catalogTable(kind)
+	if err != nil {
+		return nil, err
+	}
+	q := r.qb.Update(table).
+		Where(squirrel.Eq{"id": id}).
+		Suffix("RETURNING id, name, active")
+	if patch.Name != nil {
+		q = q.Set("name", *patch.Name)
+	}
+	if patch.Active != nil {
+		q = q.Set("active", *patch.Active)
+	}
+	e := entities.CatalogEntry{Kind: kind}
+	if err := q.RunWith(conn(ctx, r.db)).QueryRowContext(ctx).Scan(&e.ID, &e.Name, &e.Active); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		if isUniqueViolation(err) {
+			return nil, usecases.ErrCatalogEntryExists
+		}
+		logSQLError(ctx, "PGCatalogRepository.Update", q, err, slog.Int64("id", id))
+		return nil, err
+	}
+	return &e, nil
+}
+
+func catalogTable(kind entities.CatalogKind) (string, error) {
+	table, ok := catalogTables[kind]
+	if !ok {
+		return "", fmt.Errorf("unknown catalog %q", kind)
+	}
+	return table, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+)
+
+// PGHealthRepository — реализация usecases.HealthRepository для PostgreSQL
+type PGHealthRepository struct {
+	db     *sql.DB
+	qb     squirrel.StatementBuilderType
+	latest int64
+}
+
+// NewPGHealthRepository создаёт новый PGHealthRepository.
+// latest — последняя версия схемы, встроенная в бинарник (migrations.Migrator.Latest)
+func NewPGHealthRepository(db *sql.DB, latest int64) *PGHealthRepository {
+	return &PGHealthRepository{
+		db:     db,
+		qb:     squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+		latest: latest,
+	}
+}
+
+// Ping проверяет, что пул может выдать живое соединение
+func (r *PGHealthRepository) Ping(ctx context.Context) error {
+	return r.db.PingContext(ctx)
+}
+
+// MigrationState возвращает применённую версию схемы из schema_versions (см. пакет migrations)
+func (r *PGHealthRepository) MigrationState(ctx context.Context) (usecases.MigrationState, error) {
+	q := r.qb.Select("COALESCE(MAX(version), 0)").From("schema_versions")
+	state := usecases.MigrationState{Latest: r.latest}
+	if err := q.RunWith(r.db).QueryRowContext(ctx).Scan(&state.Version); err != nil {
+		logSQLError(ctx, "PGHealthRepository.MigrationState", q, err)
+		return usecases.MigrationState{}, err
+	}
+	return state, nil
+}
@@ -3,12 +3,53 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
 )
 
+// productColumns — колонки product в порядке scanProduct
+var productColumns = []string{"id", "reception_id", "type", "date_time", "position", "barcode"}
+
+// productBarcodeIndex — уникальный индекс (reception_id, barcode) из миграции 7_product_barcode
+const productBarcodeIndex = "product_reception_id_barcode_idx"
+
+// scanProduct читает колонки productColumns и, если передано, дополнительные значения после них
+func scanProduct(row squirrel.RowScanner, extra ...any) (entities.Product, error) {
+	var p entities.Product
+	var typ string
+	var barcode sql.NullString
+	dest := append([]any{&p.ID, &p.ReceptionID, &typ, &p.DateTime, &p.Position, &barcode}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return entities.Product{}, err
+	}
+	p.Type = entities.ProductType(typ)
+	p.Barcode = barcode.String
+	return p, nil
+}
+
+// isDuplicateBarcode — нарушение уникального индекса (reception_id, barcode)
+func isDuplicateBarcode(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation && pgErr.ConstraintName == productBarcodeIndex
+}
+
+// prefixed добавляет к колонкам псевдоним таблицы: prefixed("p", "id") = "p.id"
+func prefixed(alias string, columns []string) []string {
+	res := make([]string, len(columns))
+	for i, c := range columns {
+		res[i] = alias + "." + c
+	}
+	return res
+}
+
 // PGProductRepository — реализация ProductRepository для PostgreSQL (Squirrel, без ORM)
 type PGProductRepository struct {
 	db *sql.DB
@@ -23,74 +64,232 @@ func NewPGProductRepository(db *sql.DB) *PGProductRepository {
 	}
 }
 
-// Save сохраняет (insert) товар
+// Save сохраняет (insert) товар. Позиция назначается следующей за последней в приёмке;
+// параллельные вставки в одну приёмку упорядочивает блокировка приёмки в usecase,
+// уникальный индекс (reception_id, position) страхует от дублей.
+// Штрихкод, уже отсканированный в эту приёмку, — usecases.ErrDuplicateBarcode
 func (r *PGProductRepository) Save(ctx context.Context, p entities.Product) (entities.Product, error) {
+	nextPosition := squirrel.Expr("(SELECT COALESCE(MAX(position), 0) + 1 FROM product WHERE reception_id = ?)", p.ReceptionID)
 	q := r.qb.Insert("product").
-		Columns("id", "reception_id", "type", "date_time").
-		Values(p.ID, p.ReceptionID, p.Type, p.DateTime).
-		Suffix("RETURNING id")
-	row := q.RunWith(r.db).QueryRowContext(ctx)
-	var id uuid.UUID
-	if err := row.Scan(&id); err != nil {
+		Columns(productColumns...).
+		Values(p.ID, p.ReceptionID, p.Type, p.DateTime, nextPosition, sql.NullString{String: p.Barcode, Valid: p.Barcode != ""}).
+		Suffix("RETURNING id, position")
+	row := q.RunWith(conn(ctx, r.db)).QueryRowContext(ctx)
+	if err := row.Scan(&p.ID, &p.Position); err != nil {
+		if isDuplicateBarcode(err) {
+			return entities.Product{}, usecases.ErrDuplicateBarcode
+		}
+		logSQLError(ctx, "PGProductRepository.Save", q, err, slog.String("reception_id", p.ReceptionID.String()))
 		return entities.Product{}, err
 	}
-	p.ID = id
 	return p, nil
 }
 
+// SaveBatch вставляет товары одной приёмки одним многострочным INSERT, позиции продолжают последнюю в приёмке.
+// Пакет не больше usecases.ProductBatchMaxSize: 6 колонок × 1000 строк укладываются в лимит 65535 параметров PostgreSQL.
+// Вызывается в транзакции под блокировкой приёмки (см. AddProductsBatchUseCase), иначе позиции могут пересечься.
+// Штрихкод, уже отсканированный в эту приёмку, — usecases.ErrDuplicateBarcode
+func (r *PGProductRepository) SaveBatch(ctx context.Context, receptionID uuid.UUID, products []entities.Product) ([]entities.Product, error) {
+	last := r.qb.Select("COALESCE(MAX(position), 0)").From("product").Where(squirrel.Eq{"reception_id": receptionID})
+	var position int
+	if err := last.RunWith(conn(ctx, r.db)).QueryRowContext(ctx).Scan(&position); err != nil {
+		logSQLError(ctx, "PGProductRepository.SaveBatch", last, err, slog.String("reception_id", receptionID.String()))
+		return nil, err
+	}
+	res := make([]entities.Product, len(products))
+	q := r.qb.Insert("product").Columns(productColumns...)
+	for i, p := range products {
+		position++
+		p.ReceptionID, p.Position = receptionID, position
+		q = q.Values(p.ID, p.ReceptionID, p.Type, p.DateTime, p.Position, sql.NullString{String: p.Barcode, Valid: p.Barcode != ""})
+		res[i] = p
+	}
+	if _, err := q.RunWith(conn(ctx, r.db)).ExecContext(ctx); err != nil {
+		if isDuplicateBarcode(err) {
+			return nil, usecases.ErrDuplicateBarcode
+		}
+		logSQLError(ctx, "PGProductRepository.SaveBatch", q, err, slog.String("reception_id", receptionID.String()), slog.Int("count", len(products)))
+		return nil, err
+	}
+	return res, nil
+}
+
+// ExistingBarcodes возвращает те из переданных штрихкодов, что уже есть в приёмке
+func (r *PGProductRepository) ExistingBarcodes(ctx context.Context, receptionID uuid.UUID, barcodes []string) ([]string, error) {
+	if len(barcodes) == 0 {
+		return nil, nil
+	}
+	q := r.qb.Select("barcode").
+		From("product").
+		Where(squirrel.Eq{"reception_id": receptionID, "barcode": barcodes})
+	rows, err := q.RunWith(conn(ctx, r.db)).QueryContext(ctx)
+	if err != nil {
+		logSQLError(ctx, "PGProductRepository.ExistingBarcodes", q, err, slog.String("reception_id", receptionID.String()))
+		return nil, err
+	}
+	defer rows.Close()
+	var res []string
+	for rows.Next() {
+		var barcode string
+		if err := rows.Scan(&barcode); err != nil {
+			return nil, err
+		}
+		res = append(res, barcode)
+	}
+	return res, rows.Err()
+}
+
+// GetByID возвращает товар по id, nil — если его нет
+func (r *PGProductRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Product, error) {
+	q := r.qb.Select(productColumns...).From("product").Where(squirrel.Eq{"id": id})
+	p, err := scanProduct(q.RunWith(conn(ctx, r.db)).QueryRowContext(ctx))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		logSQLError(ctx, "PGProductRepository.GetByID", q, err, slog.String("product_id", id.String()))
+		return nil, err
+	}
+	return &p, nil
+}
+
+// VoidByReception аннулирует все товары приёмки одним запросом: удаляет их из product
+// и переносит в журнал product_removal с автором и временем. Возвращает число аннулированных товаров
+func (r *PGProductRepository) VoidByReception(ctx context.Context, receptionID, removedBy uuid.UUID, removedByEmail string, removedAt time.Time) (int, error) {
+	voided := squirrel.Select("gen_random_uuid()", "id", "reception_id", "type", "barcode", "position", "date_time").
+		Column("?::uuid", removedBy).
+		Column("?::text", removedByEmail).
+		Column("?::timestamptz", removedAt).
+		From("voided")
+	q := r.qb.Insert("product_removal").
+		Prefix("WITH voided AS (DELETE FROM product WHERE reception_id = ? RETURNING id, reception_id, type, barcode, position, date_time)", receptionID).
+		Columns("id", "product_id", "reception_id", "type", "barcode", "position", "added_at", "removed_by", "removed_by_email", "removed_at").
+		Select(voided)
+	res, err := q.RunWith(conn(ctx, r.db)).ExecContext(ctx)
+	if err != nil {
+		logSQLError(ctx, "PGProductRepository.VoidByReception", q, err, slog.String("reception_id", receptionID.String()))
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+// SaveRemoval записывает удалённый товар и автора удаления в журнал product_removal
+func (r *PGProductRepository) SaveRemoval(ctx context.Context, removal entities.ProductRemoval) error {
+	p := removal.Product
+	q := r.qb.Insert("product_removal").
+		Columns("id", "product_id", "reception_id", "type", "barcode", "position", "added_at", "removed_by", "removed_by_email", "removed_at").
+		Values(removal.ID, p.ID, p.ReceptionID, p.Type, sql.NullString{String: p.Barcode, Valid: p.Barcode != ""}, p.Position, p.DateTime,
+			removal.RemovedBy, removal.RemovedByEmail, removal.RemovedAt)
+	if _, err := q.RunWith(conn(ctx, r.db)).ExecContext(ctx); err != nil {
+		logSQLError(ctx, "PGProductRepository.SaveRemoval", q, err, slog.String("product_id", p.ID.String()))
+		return err
+	}
+	return nil
+}
+
 // Delete удаляет товар по его идентификатору
 func (r *PGProductRepository) Delete(ctx context.Context, productID uuid.UUID) error {
 	q := r.qb.Delete("product").Where(squirrel.Eq{"id": productID})
-	_, err := q.RunWith(r.db).ExecContext(ctx)
-	return err
+	if _, err := q.RunWith(conn(ctx, r.db)).ExecContext(ctx); err != nil {
+		logSQLError(ctx, "PGProductRepository.Delete", q, err, slog.String("product_id", productID.String()))
+		return err
+	}
+	return nil
 }
 
-// DeleteLast удаляет последний добавленный товар по приёмке (LIFO)
+// DeleteLast удаляет товар с наибольшей позицией в приёмке (LIFO) одним запросом:
+// выбор и удаление атомарны, строка товара блокируется (FOR UPDATE), поэтому
+// два параллельных вызова не удалят один и тот же товар
 func (r *PGProductRepository) DeleteLast(ctx context.Context, receptionID uuid.UUID) (*entities.Product, error) {
-	q := r.qb.Select("id", "reception_id", "type", "date_time").
+	last := squirrel.Select("id").
 		From("product").
 		Where(squirrel.Eq{"reception_id": receptionID}).
-		OrderBy("date_time DESC").
-		Limit(1)
-	row := q.RunWith(r.db).QueryRowContext(ctx)
-	var p entities.Product
-	var typ string
-	if err := row.Scan(&p.ID, &p.ReceptionID, &typ, &p.DateTime); err != nil {
+		OrderBy("position DESC").
+		Limit(1).
+		Suffix("FOR UPDATE")
+	q := r.qb.Delete("product").
+		Where(squirrel.Expr("id = (?)", last)).
+		Suffix("RETURNING " + strings.Join(productColumns, ", "))
+	p, err := scanProduct(q.RunWith(conn(ctx, r.db)).QueryRowContext(ctx))
+	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
-		return nil, err
-	}
-	p.Type = entities.ProductType(typ)
-	// Удаляем найденный товар
-	delQ := r.qb.Delete("product").Where(squirrel.Eq{"id": p.ID})
-	_, err := delQ.RunWith(r.db).ExecContext(ctx)
-	if err != nil {
+		logSQLError(ctx, "PGProductRepository.DeleteLast", q, err, slog.String("reception_id", receptionID.String()))
 		return nil, err
 	}
 	return &p, nil
 }
 
-// ListByReception возвращает все товары по приёмке
+// ListByReception возвращает все товары по приёмке в порядке добавления (по позиции)
 func (r *PGProductRepository) ListByReception(ctx context.Context, receptionID uuid.UUID) ([]entities.Product, error) {
-	q := r.qb.Select("id", "reception_id", "type", "date_time").
+	q := r.qb.Select(productColumns...).
 		From("product").
 		Where(squirrel.Eq{"reception_id": receptionID}).
-		OrderBy("date_time ASC")
-	rows, err := q.RunWith(r.db).QueryContext(ctx)
+		OrderBy("position ASC")
+	rows, err := q.RunWith(conn(ctx, r.db)).QueryContext(ctx)
 	if err != nil {
+		logSQLError(ctx, "PGProductRepository.ListByReception", q, err, slog.String("reception_id", receptionID.String()))
 		return nil, err
 	}
 	defer rows.Close()
 	var res []entities.Product
 	for rows.Next() {
-		var p entities.Product
-		var typ string
-		if err := rows.Scan(&p.ID, &p.ReceptionID, &typ, &p.DateTime); err != nil {
+		p, err := scanProduct(rows)
+		if err != nil {
 			return nil, err
 		}
-		p.Type = entities.ProductType(typ)
 		res = append(res, p)
 	}
 	return res, rows.Err()
 }
+
+// FindByBarcode возвращает все приёмы посылки с таким штрихкодом вместе с приёмкой и ПВЗ, новые первыми
+func (r *PGProductRepository) FindByBarcode(ctx context.Context, barcode string) ([]entities.ProductLocation, error) {
+	columns := append(prefixed("z", pvzColumns), prefixed("p", productColumns)...)
+	q := r.qb.Select(append(columns, "r.pvz_id", "r.status", "r.date_time", "r.closed_at")...).
+		From("product p").
+		Join("reception r ON r.id = p.reception_id").
+		Join("pvz z ON z.id = r.pvz_id").
+		Where(squirrel.Eq{"p.barcode": barcode}).
+		OrderBy("p.date_time DESC", "p.id DESC")
+	rows, err := q.RunWith(conn(ctx, r.db)).QueryContext(ctx)
+	if err != nil {
+		logSQLError(ctx, "PGProductRepository.FindByBarcode", q, err)
+		return nil, err
+	}
+	defer rows.Close()
+	res := []entities.ProductLocation{}
+	for rows.Next() {
+		loc, err := scanProductLocation(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, loc)
+	}
+	return res, rows.Err()
+}
+
+// scanProductLocation читает строку FindByBarcode: колонки pvzColumns, productColumns, затем pvz_id, status, date_time, closed_at приёмки
+func scanProductLocation(row squirrel.RowScanner) (entities.ProductLocation, error) {
+	var loc entities.ProductLocation
+	var typ, status string
+	var barcode sql.NullString
+	var closedAt sql.NullTime
+	pvz, err := scanPVZ(row,
+		&loc.Product.ID, &loc.Product.ReceptionID, &typ, &loc.Product.DateTime, &loc.Product.Position, &barcode,
+		&loc.Reception.PVZID, &status, &loc.Reception.DateTime, &closedAt)
+	if err != nil {
+		return entities.ProductLocation{}, err
+	}
+	loc.PVZ = pvz
+	loc.Product.Type = entities.ProductType(typ)
+	loc.Product.Barcode = barcode.String
+	loc.Reception.ID = loc.Product.ReceptionID
+	loc.Reception.Status = entities.ReceptionStatus(status)
+	if closedAt.Valid {
+		loc.Reception.ClosedAt = &closedAt.Time
+	}
+	return loc, nil
+}
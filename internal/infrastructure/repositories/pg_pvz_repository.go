@@ -3,6 +3,9 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/Masterminds/squirrel"
@@ -26,22 +29,227 @@ func NewPGPVZRepository(db *sql.DB) *PGPVZRepository {
 
 // Save сохраняет (insert) PVZ
 func (r *PGPVZRepository) Save(ctx context.Context, pvz entities.PVZ) (entities.PVZ, error) {
+	lat, lon := locationArgs(pvz.Location)
 	q := r.qb.Insert("pvz").
-		Columns("id", "registration_date", "city").
-		Values(pvz.ID, pvz.RegistrationDate, pvz.City).
-		Suffix("ON CONFLICT (id) DO UPDATE SET registration_date = EXCLUDED.registration_date, city = EXCLUDED.city RETURNING id")
-	row := q.RunWith(r.db).QueryRowContext(ctx)
+		Columns("id", "registration_date", "city", "name", "address", "working_hours", "latitude", "longitude").
+		Values(pvz.ID, pvz.RegistrationDate, pvz.City, pvz.Name, pvz.Address, pvz.WorkingHours, lat, lon).
+		Suffix(`ON CONFLICT (id) DO UPDATE SET registration_date = EXCLUDED.registration_date, city = EXCLUDED.city,
+name = EXCLUDED.name, address = EXCLUDED.address, working_hours = EXCLUDED.working_hours,
+latitude = EXCLUDED.latitude, longitude = EXCLUDED.longitude RETURNING id`)
+	row := q.RunWith(conn(ctx, r.db)).QueryRowContext(ctx)
 	var id uuid.UUID
 	if err := row.Scan(&id); err != nil {
+		logSQLError(ctx, "PGPVZRepository.Save", q, err, slog.String("pvz_id", pvz.ID.String()))
 		return entities.PVZ{}, err
 	}
 	pvz.ID = id
 	return pvz, nil
 }
 
-// List возвращает список PVZ с фильтрами по дате и пагинацией
-func (r *PGPVZRepository) List(ctx context.Context, startDate, endDate *time.Time, page, limit int) ([]entities.PVZ, error) {
-	q := r.qb.Select("id", "registration_date", "city").From("pvz")
+// pvzColumns — колонки pvz в порядке scanPVZ
+var pvzColumns = []string{"id", "registration_date", "city", "archived_at", "name", "address", "working_hours", "latitude", "longitude"}
+
+// scanPVZ читает колонки pvzColumns и, если передано, дополнительные значения после них
+func scanPVZ(row squirrel.RowScanner, extra ...any) (entities.PVZ, error) {
+	var pvz entities.PVZ
+	var archivedAt sql.NullTime
+	var lat, lon sql.NullFloat64
+	dest := append([]any{&pvz.ID, &pvz.RegistrationDate, &pvz.City, &archivedAt, &pvz.Name, &pvz.Address, &pvz.WorkingHours, &lat, &lon}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return entities.PVZ{}, err
+	}
+	if archivedAt.Valid {
+		pvz.ArchivedAt = &archivedAt.Time
+	}
+	if lat.Valid && lon.Valid {
+		pvz.Location = &entities.GeoPoint{Lat: lat.Float64, Lon: lon.Float64}
+	}
+	return pvz, nil
+}
+
+// locationArgs — значения колонок latitude и longitude, NULL без координат
+func locationArgs(p *entities.GeoPoint) (lat, lon sql.NullFloat64) {
+	if p == nil {
+		return
+	}
+	return sql.NullFloat64{Float64: p.Lat, Valid: true}, sql.NullFloat64{Float64: p.Lon, Valid: true}
+}
+
+// GetByID возвращает PVZ по id, nil — если его нет
+func (r *PGPVZRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.PVZ, error) {
+	return r.getByID(ctx, "PGPVZRepository.GetByID", id, "")
+}
+
+// GetByIDForUpdate — GetByID с блокировкой строки до конца транзакции (изменение и архивация ПВЗ)
+func (r *PGPVZRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.PVZ, error) {
+	return r.getByID(ctx, "PGPVZRepository.GetByIDForUpdate", id, "FOR UPDATE")
+}
+
+// GetByIDForShare — GetByID с разделяемой блокировкой: архивация ждёт, пока создаётся приёмка
+func (r *PGPVZRepository) GetByIDForShare(ctx context.Context, id uuid.UUID) (*entities.PVZ, error) {
+	return r.getByID(ctx, "PGPVZRepository.GetByIDForShare", id, "FOR SHARE")
+}
+
+func (r *PGPVZRepository) getByID(ctx context.Context, op string, id uuid.UUID, lock string) (*entities.PVZ, error) {
+	q := r.qb.Select(pvzColumns...).From("pvz").Where(squirrel.Eq{"id": id})
+	if lock != "" {
+		q = q.Suffix(lock)
+	}
+	pvz, err := scanPVZ(q.RunWith(conn(ctx, r.db)).QueryRowContext(ctx))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		logSQLError(ctx, op, q, err, slog.String("pvz_id", id.String()))
+		return nil, err
+	}
+	return &pvz, nil
+}
+
+// Update сохраняет изменяемые поля PVZ: город, описание и дату архивации
+func (r *PGPVZRepository) Update(ctx context.Context, pvz entities.PVZ) (entities.PVZ, error) {
+	lat, lon := locationArgs(pvz.Location)
+	q := r.qb.Update("pvz").
+		Set("city", pvz.City).
+		Set("name", pvz.Name).
+		Set("address", pvz.Address).
+		Set("working_hours", pvz.WorkingHours).
+		Set("latitude", lat).
+		Set("longitude", lon).
+		Set("archived_at", pvz.ArchivedAt).
+		Where(squirrel.Eq{"id": pvz.ID}).
+		Suffix("RETURNING " + strings.Join(pvzColumns, ", "))
+	updated, err := scanPVZ(q.RunWith(conn(ctx, r.db)).QueryRowContext(ctx))
+	if err != nil {
+		logSQLError(ctx, "PGPVZRepository.Update", q, err, slog.String("pvz_id", pvz.ID.String()))
+		return entities.PVZ{}, err
+	}
+	return updated, nil
+}
+
+// List возвращает список PVZ с фильтрами по дате и пагинацией.
+// Архивные PVZ — только при includeArchived
+func (r *PGPVZRepository) List(ctx context.Context, startDate, endDate *time.Time, page, limit int, includeArchived bool) ([]entities.PVZ, error) {
+	q := r.pageQuery(startDate, endDate, page, limit, includeArchived)
+	rows, err := q.RunWith(conn(ctx, r.db)).QueryContext(ctx)
+	if err != nil {
+		logSQLError(ctx, "PGPVZRepository.List", q, err, slog.Int("page", page), slog.Int("limit", limit))
+		return nil, err
+	}
+	defer rows.Close()
+	var res []entities.PVZ
+	for rows.Next() {
+		pvz, err := scanPVZ(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, pvz)
+	}
+	return res, rows.Err()
+}
+
+// distanceExpr — расстояние по формуле гаверсинусов от точки до ПВЗ в метрах;
+// аргументы: радиус Земли, широта, широта, долгота точки.
+// LEAST защищает asin от значений чуть больше 1 из-за погрешности округления
+const distanceExpr = `2 * ?::float8 * asin(LEAST(1, sqrt(
+	power(sin(radians(latitude - ?::float8) / 2), 2) +
+	cos(radians(?::float8)) * cos(radians(latitude)) * power(sin(radians(longitude - ?::float8) / 2), 2)
+)))`
+
+// Nearest возвращает работающие ПВЗ с координатами не дальше radius метров от точки,
+// ближайшие первыми. Без PostGIS: кандидаты отбираются по индексу pvz_location_idx
+// в ограничивающем прямоугольнике, точное расстояние считается только для них
+func (r *PGPVZRepository) Nearest(ctx context.Context, point entities.GeoPoint, radius float64, limit int) ([]entities.NearestPVZ, error) {
+	box := point.BoundingBox(radius)
+	distance := squirrel.Expr(distanceExpr, entities.EarthRadiusMeters, point.Lat, point.Lat, point.Lon)
+	inner := r.qb.Select(pvzColumns...).
+		Column(squirrel.Alias(distance, "distance")).
+		From("pvz").
+		Where(squirrel.Eq{"archived_at": nil}).
+		Where(squirrel.NotEq{"latitude": nil}).
+		Where(squirrel.GtOrEq{"latitude": box.MinLat}).
+		Where(squirrel.LtOrEq{"latitude": box.MaxLat})
+	if !box.WholeLon {
+		inner = inner.Where(squirrel.GtOrEq{"longitude": box.MinLon}).Where(squirrel.LtOrEq{"longitude": box.MaxLon})
+	}
+	q := r.qb.Select(pvzColumns...).
+		Column("distance").
+		FromSelect(inner, "candidates").
+		Where(squirrel.LtOrEq{"distance": radius}).
+		OrderBy("distance", "id").
+		Limit(uint64(limit))
+	rows, err := q.RunWith(conn(ctx, r.db)).QueryContext(ctx)
+	if err != nil {
+		logSQLError(ctx, "PGPVZRepository.Nearest", q, err, slog.Float64("radius", radius), slog.Int("limit", limit))
+		return nil, err
+	}
+	defer rows.Close()
+	res := []entities.NearestPVZ{}
+	for rows.Next() {
+		var item entities.NearestPVZ
+		item.PVZ, err = scanPVZ(rows, &item.Distance)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, item)
+	}
+	return res, rows.Err()
+}
+
+// ListWithReceptions возвращает страницу PVZ вместе с приёмками и товарами.
+// Ровно три запроса на страницу независимо от числа приёмок и товаров:
+// страница PVZ, приёмки этих PVZ, товары этих приёмок
+func (r *PGPVZRepository) ListWithReceptions(ctx context.Context, startDate, endDate *time.Time, page, limit int, includeArchived bool) ([]entities.PVZWithReceptions, error) {
+	pvzs, err := r.List(ctx, startDate, endDate, page, limit, includeArchived)
+	if err != nil {
+		return nil, err
+	}
+	if len(pvzs) == 0 {
+		return []entities.PVZWithReceptions{}, nil
+	}
+	pvzIDs := make([]uuid.UUID, 0, len(pvzs))
+	for _, pvz := range pvzs {
+		pvzIDs = append(pvzIDs, pvz.ID)
+	}
+
+	receptions, err := r.listReceptions(ctx, pvzIDs)
+	if err != nil {
+		return nil, err
+	}
+	products, err := r.listProducts(ctx, pvzIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	productsByReception := make(map[uuid.UUID][]entities.Product, len(receptions))
+	for _, p := range products {
+		productsByReception[p.ReceptionID] = append(productsByReception[p.ReceptionID], p)
+	}
+	receptionsByPVZ := make(map[uuid.UUID][]entities.ReceptionWithProducts, len(pvzs))
+	for _, rec := range receptions {
+		products := productsByReception[rec.ID]
+		for _, p := range products {
+			rec.Products = append(rec.Products, p.ID)
+		}
+		receptionsByPVZ[rec.PVZID] = append(receptionsByPVZ[rec.PVZID], entities.ReceptionWithProducts{
+			Reception: rec,
+			Products:  products,
+		})
+	}
+	res := make([]entities.PVZWithReceptions, 0, len(pvzs))
+	for _, pvz := range pvzs {
+		res = append(res, entities.PVZWithReceptions{PVZ: pvz, Receptions: receptionsByPVZ[pvz.ID]})
+	}
+	return res, nil
+}
+
+// pageQuery — выборка PVZ с фильтрами по дате регистрации и пагинацией.
+// Сортировка по (registration_date, id), чтобы страницы не пересекались
+func (r *PGPVZRepository) pageQuery(startDate, endDate *time.Time, page, limit int, includeArchived bool) squirrel.SelectBuilder {
+	q := r.qb.Select(pvzColumns...).From("pvz").OrderBy("registration_date", "id")
+	if !includeArchived {
+		q = q.Where(squirrel.Eq{"archived_at": nil})
+	}
 	if startDate != nil {
 		q = q.Where(squirrel.GtOrEq{"registration_date": *startDate})
 	}
@@ -55,18 +263,55 @@ func (r *PGPVZRepository) List(ctx context.Context, startDate, endDate *time.Tim
 		offset := uint64((page - 1) * limit)
 		q = q.Offset(offset)
 	}
-	rows, err := q.RunWith(r.db).QueryContext(ctx)
+	return q
+}
+
+// listReceptions возвращает приёмки всех переданных PVZ одним запросом
+func (r *PGPVZRepository) listReceptions(ctx context.Context, pvzIDs []uuid.UUID) ([]entities.Reception, error) {
+	q := r.qb.Select("id", "pvz_id", "status", "date_time", "closed_at").
+		From("reception").
+		Where(squirrel.Eq{"pvz_id": pvzIDs}).
+		OrderBy("date_time", "id")
+	rows, err := q.RunWith(conn(ctx, r.db)).QueryContext(ctx)
 	if err != nil {
+		logSQLError(ctx, "PGPVZRepository.listReceptions", q, err, slog.Int("pvz_count", len(pvzIDs)))
 		return nil, err
 	}
 	defer rows.Close()
-	var res []entities.PVZ
+	var res []entities.Reception
 	for rows.Next() {
-		var pvz entities.PVZ
-		if err := rows.Scan(&pvz.ID, &pvz.RegistrationDate, &pvz.City); err != nil {
+		var rec entities.Reception
+		var status string
+		if err := rows.Scan(&rec.ID, &rec.PVZID, &status, &rec.DateTime, &rec.ClosedAt); err != nil {
 			return nil, err
 		}
-		res = append(res, pvz)
+		rec.Status = entities.ReceptionStatus(status)
+		res = append(res, rec)
+	}
+	return res, rows.Err()
+}
+
+// listProducts возвращает товары всех приёмок переданных PVZ одним запросом (join по reception),
+// внутри приёмки — по возрастанию позиции
+func (r *PGPVZRepository) listProducts(ctx context.Context, pvzIDs []uuid.UUID) ([]entities.Product, error) {
+	q := r.qb.Select(prefixed("p", productColumns)...).
+		From("product p").
+		Join("reception r ON r.id = p.reception_id").
+		Where(squirrel.Eq{"r.pvz_id": pvzIDs}).
+		OrderBy("p.reception_id", "p.position")
+	rows, err := q.RunWith(conn(ctx, r.db)).QueryContext(ctx)
+	if err != nil {
+		logSQLError(ctx, "PGPVZRepository.listProducts", q, err, slog.Int("pvz_count", len(pvzIDs)))
+		return nil, err
+	}
+	defer rows.Close()
+	var res []entities.Product
+	for rows.Next() {
+		p, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, p)
 	}
 	return res, rows.Err()
 }
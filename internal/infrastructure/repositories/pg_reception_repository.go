@@ -3,13 +3,29 @@ package repositories
 import (
 	"context"
 	"database/sql"
-	"time"
+	"errors"
+	"log/slog"
+	"strings"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
 )
 
+// receptionProductIDs — id товаров приёмки через запятую в порядке позиции (заполняет Reception.Products)
+const receptionProductIDs = "COALESCE((SELECT string_agg(p.id::text, ',' ORDER BY p.position) FROM product p WHERE p.reception_id = reception.id), '')"
+
+// openReceptionIndex — уникальный индекс, не дающий открыть на ПВЗ вторую приёмку (открытую или на паузе)
+const openReceptionIndex = "one_open_reception_per_pvz"
+
+// activeReceptionStatuses — статусы, в которых приёмка занимает ПВЗ (условие индекса openReceptionIndex)
+var activeReceptionStatuses = []entities.ReceptionStatus{entities.ReceptionInProgress, entities.ReceptionPaused}
+
+// receptionColumns — колонки приёмки в порядке scanReception
+var receptionColumns = []string{"id", "pvz_id", "status", "date_time", "closed_at", receptionProductIDs}
+
 // PGReceptionRepository — реализация ReceptionRepository для PostgreSQL (Squirrel, без ORM)
 type PGReceptionRepository struct {
 	db *sql.DB
@@ -27,73 +43,175 @@ func NewPGReceptionRepository(db *sql.DB) *PGReceptionRepository {
 // Save сохраняет (insert/update) приёмку
 func (r *PGReceptionRepository) Save(ctx context.Context, rec entities.Reception) (entities.Reception, error) {
 	q := r.qb.Insert("reception").
-		Columns("id", "pvz_id", "status", "date_time").
-		Values(rec.ID, rec.PVZID, rec.Status, rec.DateTime).
-		Suffix("ON CONFLICT (id) DO UPDATE SET pvz_id = EXCLUDED.pvz_id, status = EXCLUDED.status, date_time = EXCLUDED.date_time RETURNING id")
-	row := q.RunWith(r.db).QueryRowContext(ctx)
+		Columns("id", "pvz_id", "status", "date_time", "closed_at").
+		Values(rec.ID, rec.PVZID, rec.Status, rec.DateTime, rec.ClosedAt).
+		Suffix("ON CONFLICT (id) DO UPDATE SET pvz_id = EXCLUDED.pvz_id, status = EXCLUDED.status, date_time = EXCLUDED.date_time, closed_at = EXCLUDED.closed_at RETURNING id")
+	row := q.RunWith(conn(ctx, r.db)).QueryRowContext(ctx)
 	var id uuid.UUID
 	if err := row.Scan(&id); err != nil {
+		if isOpenReceptionConflict(err) {
+			return entities.Reception{}, usecases.ErrReceptionAlreadyOpen
+		}
+		logSQLError(ctx, "PGReceptionRepository.Save", q, err, slog.String("reception_id", rec.ID.String()), slog.String("pvz_id", rec.PVZID.String()))
 		return entities.Reception{}, err
 	}
 	rec.ID = id
 	return rec, nil
 }
 
-// GetActive возвращает открытую приёмку по PVZ (status = in_progress)
+// GetActive возвращает приёмку, занимающую PVZ: открытую или на паузе (status in_progress/paused)
 func (r *PGReceptionRepository) GetActive(ctx context.Context, pvzID uuid.UUID) (*entities.Reception, error) {
-	q := r.qb.Select("id", "pvz_id", "status", "date_time").
+	return r.getActive(ctx, "PGReceptionRepository.GetActive", pvzID, "")
+}
+
+// GetActiveForUpdate возвращает открытую или приостановленную приёмку по PVZ и блокирует её строку (SELECT ... FOR UPDATE)
+// до конца транзакции. Вызывать внутри TxManager.WithinTx, иначе блокировка снимется сразу
+func (r *PGReceptionRepository) GetActiveForUpdate(ctx context.Context, pvzID uuid.UUID) (*entities.Reception, error) {
+	return r.getActive(ctx, "PGReceptionRepository.GetActiveForUpdate", pvzID, "FOR UPDATE")
+}
+
+func (r *PGReceptionRepository) getActive(ctx context.Context, op string, pvzID uuid.UUID, suffix string) (*entities.Reception, error) {
+	q := r.qb.Select(receptionColumns...).
 		From("reception").
-		Where(squirrel.Eq{"pvz_id": pvzID, "status": entities.ReceptionInProgress})
-	row := q.RunWith(r.db).QueryRowContext(ctx)
-	var rec entities.Reception
-	var status string
-	if err := row.Scan(&rec.ID, &rec.PVZID, &status, &rec.DateTime); err != nil {
+		Where(squirrel.Eq{"pvz_id": pvzID, "status": activeReceptionStatuses}).
+		Suffix(suffix)
+	row := q.RunWith(conn(ctx, r.db)).QueryRowContext(ctx)
+	rec, err := scanReception(row)
+	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
+		logSQLError(ctx, op, q, err, slog.String("pvz_id", pvzID.String()))
 		return nil, err
 	}
-	rec.Status = entities.ReceptionStatus(status)
 	return &rec, nil
 }
 
-// CloseLast закрывает последнюю открытую приёмку по PVZ (status = in_progress → close, date_time обновляется)
-func (r *PGReceptionRepository) CloseLast(ctx context.Context, pvzID uuid.UUID, closedAt time.Time) error {
-	q := r.qb.Update("reception").
-		Set("status", entities.ReceptionClosed).
-		Set("date_time", closedAt).
-		Where(squirrel.Eq{"pvz_id": pvzID, "status": entities.ReceptionInProgress})
-	res, err := q.RunWith(r.db).ExecContext(ctx)
+// GetByID возвращает приёмку по id, nil — если её нет
+func (r *PGReceptionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Reception, error) {
+	return r.getByID(ctx, "PGReceptionRepository.GetByID", id, "")
+}
+
+// GetByIDForUpdate — GetByID с блокировкой строки приёмки до конца транзакции (как GetActiveForUpdate)
+func (r *PGReceptionRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.Reception, error) {
+	return r.getByID(ctx, "PGReceptionRepository.GetByIDForUpdate", id, "FOR UPDATE")
+}
+
+func (r *PGReceptionRepository) getByID(ctx context.Context, op string, id uuid.UUID, suffix string) (*entities.Reception, error) {
+	q := r.qb.Select(receptionColumns...).
+		From("reception").
+		Where(squirrel.Eq{"id": id}).
+		Suffix(suffix)
+	rec, err := scanReception(q.RunWith(conn(ctx, r.db)).QueryRowContext(ctx))
 	if err != nil {
-		return err
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		logSQLError(ctx, op, q, err, slog.String("reception_id", id.String()))
+		return nil, err
+	}
+	return &rec, nil
+}
+
+// ListByPVZ возвращает историю приёмок PVZ, новые первыми, с фильтрами по статусу и дате.
+// Limit = 0 — все приёмки без пагинации. Идёт по индексу reception_pvz_id_date_time_idx
+func (r *PGReceptionRepository) ListByPVZ(ctx context.Context, pvzID uuid.UUID, filter usecases.ReceptionFilter) ([]entities.Reception, error) {
+	q := r.qb.Select(receptionColumns...).
+		From("reception").
+		Where(squirrel.Eq{"pvz_id": pvzID}).
+		OrderBy("date_time DESC", "id DESC")
+	if filter.Status != nil {
+		q = q.Where(squirrel.Eq{"status": *filter.Status})
+	}
+	if filter.StartDate != nil {
+		q = q.Where(squirrel.GtOrEq{"date_time": *filter.StartDate})
 	}
-	n, err := res.RowsAffected()
+	if filter.EndDate != nil {
+		q = q.Where(squirrel.LtOrEq{"date_time": *filter.EndDate})
+	}
+	if filter.Limit > 0 {
+		q = q.Limit(uint64(filter.Limit))
+		if filter.Page > 1 {
+			q = q.Offset(uint64((filter.Page - 1) * filter.Limit))
+		}
+	}
+	rows, err := q.RunWith(conn(ctx, r.db)).QueryContext(ctx)
 	if err != nil {
-		return err
+		logSQLError(ctx, "PGReceptionRepository.ListByPVZ", q, err, slog.String("pvz_id", pvzID.String()))
+		return nil, err
+	}
+	defer rows.Close()
+	res := []entities.Reception{}
+	for rows.Next() {
+		rec, err := scanReception(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, rec)
 	}
-	if n == 0 {
-		return sql.ErrNoRows
+	return res, rows.Err()
+}
+
+// SaveTransition записывает смену статуса приёмки в историю reception_transition
+func (r *PGReceptionRepository) SaveTransition(ctx context.Context, t entities.ReceptionTransition) error {
+	q := r.qb.Insert("reception_transition").
+		Columns("id", "reception_id", "from_status", "to_status", "changed_by", "reason", "changed_at").
+		Values(t.ID, t.ReceptionID, t.From, t.To, t.ChangedBy, t.Reason, t.ChangedAt)
+	if _, err := q.RunWith(conn(ctx, r.db)).ExecContext(ctx); err != nil {
+		logSQLError(ctx, "PGReceptionRepository.SaveTransition", q, err, slog.String("reception_id", t.ReceptionID.String()))
+		return err
 	}
 	return nil
 }
 
-// ListByPVZ возвращает все приёмки по PVZ
-func (r *PGReceptionRepository) ListByPVZ(ctx context.Context, pvzID uuid.UUID) ([]entities.Reception, error) {
-	q := r.qb.Select("id", "pvz_id", "status", "date_time").From("reception").Where(squirrel.Eq{"pvz_id": pvzID})
-	rows, err := q.RunWith(r.db).QueryContext(ctx)
+// ListTransitions возвращает историю статусов приёмки по порядку
+func (r *PGReceptionRepository) ListTransitions(ctx context.Context, receptionID uuid.UUID) ([]entities.ReceptionTransition, error) {
+	q := r.qb.Select("id", "reception_id", "from_status", "to_status", "changed_by", "reason", "changed_at").
+		From("reception_transition").
+		Where(squirrel.Eq{"reception_id": receptionID}).
+		OrderBy("changed_at", "id")
+	rows, err := q.RunWith(conn(ctx, r.db)).QueryContext(ctx)
 	if err != nil {
+		logSQLError(ctx, "PGReceptionRepository.ListTransitions", q, err, slog.String("reception_id", receptionID.String()))
 		return nil, err
 	}
 	defer rows.Close()
-	var res []entities.Reception
+	res := []entities.ReceptionTransition{}
 	for rows.Next() {
-		var rec entities.Reception
-		var status string
-		if err := rows.Scan(&rec.ID, &rec.PVZID, &status, &rec.DateTime); err != nil {
+		var t entities.ReceptionTransition
+		var from, to string
+		if err := rows.Scan(&t.ID, &t.ReceptionID, &from, &to, &t.ChangedBy, &t.Reason, &t.ChangedAt); err != nil {
 			return nil, err
 		}
-		rec.Status = entities.ReceptionStatus(status)
-		res = append(res, rec)
+		t.From, t.To = entities.ReceptionStatus(from), entities.ReceptionStatus(to)
+		res = append(res, t)
 	}
 	return res, rows.Err()
 }
+
+// isOpenReceptionConflict — нарушение индекса «одна открытая приёмка на ПВЗ»
+func isOpenReceptionConflict(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation && pgErr.ConstraintName == openReceptionIndex
+}
+
+// scanReception читает строку приёмки вместе со списком id товаров (receptionProductIDs)
+func scanReception(row squirrel.RowScanner) (entities.Reception, error) {
+	var rec entities.Reception
+	var status, productIDs string
+	if err := row.Scan(&rec.ID, &rec.PVZID, &status, &rec.DateTime, &rec.ClosedAt, &productIDs); err != nil {
+		return entities.Reception{}, err
+	}
+	rec.Status = entities.ReceptionStatus(status)
+	if productIDs == "" {
+		return rec, nil
+	}
+	for _, s := range strings.Split(productIDs, ",") {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return entities.Reception{}, err
+		}
+		rec.Products = append(rec.Products, id)
+	}
+	return rec, nil
+}
@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+)
+
+// PGSessionRepository — сессии входа и их refresh-токены в PostgreSQL (Squirrel, без ORM)
+type PGSessionRepository struct {
+	db *sql.DB
+	qb squirrel.StatementBuilderType
+}
+
+// NewPGSessionRepository создаёт новый PGSessionRepository
+func NewPGSessionRepository(db *sql.DB) *PGSessionRepository {
+	return &PGSessionRepository{
+		db: db,
+		qb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// CreateSession сохраняет новую сессию
+func (r *PGSessionRepository) CreateSession(ctx context.Context, s entities.Session) error {
+	q := r.qb.Insert("auth_session").
+		Columns("id", "user_id", "role", "email", "created_at").
+		Values(s.ID, s.UserID, s.Role, s.Email, s.CreatedAt)
+	if _, err := q.RunWith(conn(ctx, r.db)).ExecContext(ctx); err != nil {
+		logSQLError(ctx, "PGSessionRepository.CreateSession", q, err, slog.String("user_id", s.UserID.String()))
+		return err
+	}
+	return nil
+}
+
+// GetSession ищет сессию по id, nil — если не найдена
+func (r *PGSessionRepository) GetSession(ctx context.Context, id uuid.UUID) (*entities.Session, error) {
+	q := r.qb.Select("id", "user_id", "role", "email", "created_at", "revoked_at").
+		From("auth_session").
+		Where(squirrel.Eq{"id": id})
+	var s entities.Session
+	err := q.RunWith(conn(ctx, r.db)).QueryRowContext(ctx).Scan(&s.ID, &s.UserID, &s.Role, &s.Email, &s.CreatedAt, &s.RevokedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		logSQLError(ctx, "PGSessionRepository.GetSession", q, err, slog.String("session_id", id.String()))
+		return nil, err
+	}
+	return &s, nil
+}
+
+// IsSessionActive проверяет, что сессия существует и не отозвана (вызывается на каждый защищённый запрос)
+func (r *PGSessionRepository) IsSessionActive(ctx context.Context, id uuid.UUID) (bool, error) {
+	q := r.qb.Select("1").
+		From("auth_session").
+		Where(squirrel.Eq{"id": id, "revoked_at": nil})
+	var one int
+	if err := q.RunWith(conn(ctx, r.db)).QueryRowContext(ctx).Scan(&one); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		logSQLError(ctx, "PGSessionRepository.IsSessionActive", q, err, slog.String("session_id", id.String()))
+		return false, err
+	}
+	return true, nil
+}
+
+// RevokeSession отзывает сессию; у уже отозванной время отзыва не меняется
+func (r *PGSessionRepository) RevokeSession(ctx context.Context, id uuid.UUID, revokedAt time.Time) error {
+	q := r.qb.Update("auth_session").
+		Set("revoked_at", revokedAt).
+		Where(squirrel.Eq{"id": id, "revoked_at": nil})
+	if _, err := q.RunWith(conn(ctx, r.db)).ExecContext(ctx); err != nil {
+		logSQLError(ctx, "PGSessionRepository.RevokeSession", q, err, slog.String("session_id", id.String()))
+		return err
+	}
+	return nil
+}
+
+// RevokeUserSessions отзывает все активные сессии пользователя (индекс auth_session_user_id_idx)
+// и возвращает, сколько сессий отозвано
+func (r *PGSessionRepository) RevokeUserSessions(ctx context.Context, userID uuid.UUID, revokedAt time.Time) (int64, error) {
+	q := r.qb.Update("auth_session").
+		Set("revoked_at", revokedAt).
+		Where(squirrel.Eq{"user_id": userID, "revoked_at": nil})
+	res, err := q.RunWith(conn(ctx, r.db)).ExecContext(ctx)
+	if err != nil {
+		logSQLError(ctx, "PGSessionRepository.RevokeUserSessions", q, err, slog.String("user_id", userID.String()))
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// SaveRefreshToken сохраняет хэш нового refresh-токена сессии
+func (r *PGSessionRepository) SaveRefreshToken(ctx context.Context, t entities.RefreshToken) error {
+	q := r.qb.Insert("refresh_token").
+		Columns("hash", "session_id", "issued_at", "expires_at").
+		Values(t.Hash, t.SessionID, t.IssuedAt, t.ExpiresAt)
+	if _, err := q.RunWith(conn(ctx, r.db)).ExecContext(ctx); err != nil {
+		logSQLError(ctx, "PGSessionRepository.SaveRefreshToken", q, err, slog.String("session_id", t.SessionID.String()))
+		return err
+	}
+	return nil
+}
+
+// GetRefreshTokenForUpdate ищет refresh-токен по хэшу и блокирует строку до конца транзакции,
+// чтобы два параллельных обмена одного токена не выдали две пары. nil — если не найден
+func (r *PGSessionRepository) GetRefreshTokenForUpdate(ctx context.Context, hash string) (*entities.RefreshToken, error) {
+	q := r.qb.Select("hash", "session_id", "issued_at", "expires_at", "used_at").
+		From("refresh_token").
+		Where(squirrel.Eq{"hash": hash}).
+		Suffix("FOR UPDATE")
+	var t entities.RefreshToken
+	err := q.RunWith(conn(ctx, r.db)).QueryRowContext(ctx).Scan(&t.Hash, &t.SessionID, &t.IssuedAt, &t.ExpiresAt, &t.UsedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		logSQLError(ctx, "PGSessionRepository.GetRefreshTokenForUpdate", q, err)
+		return nil, err
+	}
+	return &t, nil
+}
+
+// MarkRefreshTokenUsed помечает refresh-токен использованным
+func (r *PGSessionRepository) MarkRefreshTokenUsed(ctx context.Context, hash string, usedAt time.Time) error {
+	q := r.qb.Update("refresh_token").
+		Set("used_at", usedAt).
+		Where(squirrel.Eq{"hash": hash})
+	if _, err := q.RunWith(conn(ctx, r.db)).ExecContext(ctx); err != nil {
+		logSQLError(ctx, "PGSessionRepository.MarkRefreshTokenUsed", q, err)
+		return err
+	}
+	return nil
+}
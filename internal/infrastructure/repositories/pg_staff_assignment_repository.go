@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+)
+
+// PGStaffAssignmentRepository — назначения сотрудников на ПВЗ в PostgreSQL (Squirrel, без ORM)
+type PGStaffAssignmentRepository struct {
+	db *sql.DB
+	qb squirrel.StatementBuilderType
+}
+
+// NewPGStaffAssignmentRepository создаёт новый PGStaffAssignmentRepository
+func NewPGStaffAssignmentRepository(db *sql.DB) *PGStaffAssignmentRepository {
+	return &PGStaffAssignmentRepository{
+		db: db,
+		qb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// Assign назначает сотрудника на ПВЗ; повторное назначение ничего не меняет (остаются первые assigned_at/assigned_by)
+func (r *PGStaffAssignmentRepository) Assign(ctx context.Context, a entities.StaffAssignment) error {
+	q := r.qb.Insert("pvz_staff").
+		Columns("pvz_id", "user_id", "assigned_at", "assigned_by").
+		Values(a.PVZID, a.UserID, a.AssignedAt, a.AssignedBy).
+		Suffix("ON CONFLICT (user_id, pvz_id) DO NOTHING")
+	if _, err := q.RunWith(conn(ctx, r.db)).ExecContext(ctx); err != nil {
+		logSQLError(ctx, "PGStaffAssignmentRepository.Assign", q, err, slog.String("pvz_id", a.PVZID.String()), slog.String("user_id", a.UserID.String()))
+		return err
+	}
+	return nil
+}
+
+// Unassign снимает сотрудника с ПВЗ; false — если он не был назначен
+func (r *PGStaffAssignmentRepository) Unassign(ctx context.Context, pvzID, userID uuid.UUID) (bool, error) {
+	q := r.qb.Delete("pvz_staff").
+		Where(squirrel.Eq{"pvz_id": pvzID, "user_id": userID})
+	res, err := q.RunWith(conn(ctx, r.db)).ExecContext(ctx)
+	if err != nil {
+		logSQLError(ctx, "PGStaffAssignmentRepository.Unassign", q, err, slog.String("pvz_id", pvzID.String()), slog.String("user_id", userID.String()))
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// IsAssigned проверяет, что сотрудник назначен на ПВЗ (вызывается на каждую операцию с приёмками и товарами)
+func (r *PGStaffAssignmentRepository) IsAssigned(ctx context.Context, userID, pvzID uuid.UUID) (bool, error) {
+	q := r.qb.Select("1").
+		From("pvz_staff").
+		Where(squirrel.Eq{"user_id": userID, "pvz_id": pvzID})
+	var one int
+	if err := q.RunWith(conn(ctx, r.db)).QueryRowContext(ctx).Scan(&one); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		logSQLError(ctx, "PGStaffAssignmentRepository.IsAssigned", q, err, slog.String("pvz_id", pvzID.String()), slog.String("user_id", userID.String()))
+		return false, err
+	}
+	return true, nil
+}
+
+// ListByPVZ возвращает назначения на ПВЗ в порядке назначения
+func (r *PGStaffAssignmentRepository) ListByPVZ(ctx context.Context, pvzID uuid.UUID) ([]entities.StaffAssignment, error) {
+	q := r.qb.Select("pvz_id", "user_id", "assigned_at", "assigned_by").
+		From("pvz_staff").
+		Where(squirrel.Eq{"pvz_id": pvzID}).
+		OrderBy("assigned_at", "user_id")
+	rows, err := q.RunWith(conn(ctx, r.db)).QueryContext(ctx)
+	if err != nil {
+		logSQLError(ctx, "PGStaffAssignmentRepository.ListByPVZ", q, err, slog.String("pvz_id", pvzID.String()))
+		return nil, err
+	}
+	defer rows.Close()
+	list := []entities.StaffAssignment{}
+	for rows.Next() {
+		var a entities.StaffAssignment
+		if err := rows.Scan(&a.PVZID, &a.UserID, &a.AssignedAt, &a.AssignedBy); err != nil {
+			return nil, err
+		}
+		list = append(list, a)
+	}
+	return list, rows.Err()
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
 )
 
 // PGUserRepository — реализация UserRepository для PostgreSQL (Squirrel, без ORM)
@@ -30,27 +31,64 @@ func (r *PGUserRepository) Create(ctx context.Context, user entities.User, passw
 		Columns("id", "email", "role", "registration_date", "password_hash").
 		Values(user.ID, user.Email, user.Role, user.RegistrationDate, passwordHash).
 		Suffix("RETURNING id")
-	row := q.RunWith(r.db).QueryRowContext(ctx)
+	row := q.RunWith(conn(ctx, r.db)).QueryRowContext(ctx)
 	var id uuid.UUID
 	if err := row.Scan(&id); err != nil {
+		logSQLError(ctx, "PGUserRepository.Create", q, err)
 		return entities.User{}, err
 	}
 	user.ID = id
 	return user, nil
 }
 
+// EnsureUser сохраняет пользователя без пароля, если его ещё нет (тестовые пользователи /dummyLogin).
+// Пустой password_hash не совпадает ни с одним паролем, поэтому через /login такой пользователь не войдёт.
+// Конфликт гасится только по id: email, занятый другим пользователем, — usecases.ErrEmailTaken
+func (r *PGUserRepository) EnsureUser(ctx context.Context, user entities.User) error {
+	q := r.qb.Insert("users").
+		Columns("id", "email", "role", "registration_date", "password_hash").
+		Values(user.ID, user.Email, user.Role, user.RegistrationDate, "").
+		Suffix("ON CONFLICT (id) DO NOTHING")
+	if _, err := q.RunWith(conn(ctx, r.db)).ExecContext(ctx); err != nil {
+		if isUniqueViolation(err) {
+			return usecases.ErrEmailTaken
+		}
+		logSQLError(ctx, "PGUserRepository.EnsureUser", q, err)
+		return err
+	}
+	return nil
+}
+
+// GetByID возвращает пользователя по id, nil — если его нет
+func (r *PGUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	q := r.qb.Select("id", "email", "role", "registration_date").
+		From("users").
+		Where(squirrel.Eq{"id": id})
+	row := q.RunWith(conn(ctx, r.db)).QueryRowContext(ctx)
+	var user entities.User
+	if err := row.Scan(&user.ID, &user.Email, &user.Role, &user.RegistrationDate); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		logSQLError(ctx, "PGUserRepository.GetByID", q, err)
+		return nil, err
+	}
+	return &user, nil
+}
+
 // GetByEmail ищет пользователя по email, возвращает User и passwordHash
 func (r *PGUserRepository) GetByEmail(ctx context.Context, email string) (*entities.User, string, error) {
 	q := r.qb.Select("id", "email", "role", "registration_date", "password_hash").
 		From("users").
 		Where(squirrel.Eq{"email": email})
-	row := q.RunWith(r.db).QueryRowContext(ctx)
+	row := q.RunWith(conn(ctx, r.db)).QueryRowContext(ctx)
 	var user entities.User
 	var hash string
 	if err := row.Scan(&user.ID, &user.Email, &user.Role, &user.RegistrationDate, &hash); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, "", nil
 		}
+		logSQLError(ctx, "PGUserRepository.GetByEmail", q, err)
 		return nil, "", err
 	}
 	return &user, hash, nil
@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
+)
+
+type txCtxKey struct{}
+
+// PGTxManager — реализация usecases.TxManager для PostgreSQL.
+// Открытая транзакция кладётся в ctx, репозитории берут её через conn
+type PGTxManager struct {
+	db *sql.DB
+}
+
+// NewPGTxManager создаёт новый PGTxManager
+func NewPGTxManager(db *sql.DB) *PGTxManager {
+	return &PGTxManager{db: db}
+}
+
+// WithinTx выполняет fn в транзакции: commit при успехе, rollback при ошибке или панике.
+// Вложенный вызов переиспользует уже открытую транзакцию
+func (m *PGTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(txCtxKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.FromContext(ctx).Error("begin tx failed", slog.Any("error", err))
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+		if err = tx.Commit(); err != nil {
+			logger.FromContext(ctx).Error("commit tx failed", slog.Any("error", err))
+		}
+	}()
+	return fn(context.WithValue(ctx, txCtxKey{}, tx))
+}
+
+// conn возвращает транзакцию из ctx, если она открыта, иначе пул соединений
+func conn(ctx context.Context, db *sql.DB) squirrel.BaseRunner {
+	if tx, ok := ctx.Value(txCtxKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
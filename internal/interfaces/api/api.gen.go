@@ -0,0 +1,1539 @@
+// Package api provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.0 DO NOT EDIT.
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for CatalogKind.
+const (
+	CatalogKindCity        CatalogKind = "city"
+	CatalogKindProductType CatalogKind = "product_type"
+)
+
+// Defines values for ErrorCode.
+const (
+	ErrorCodeConflict        ErrorCode = "conflict"
+	ErrorCodeForbidden       ErrorCode = "forbidden"
+	ErrorCodeInternalError   ErrorCode = "internal_error"
+	ErrorCodeNoOpenReception ErrorCode = "no_open_reception"
+	ErrorCodeNotFound        ErrorCode = "not_found"
+	ErrorCodeUnauthorized    ErrorCode = "unauthorized"
+	ErrorCodeValidationError ErrorCode = "validation_error"
+)
+
+// Defines values for ReceptionStatus.
+const (
+	ReceptionStatusCancelled  ReceptionStatus = "cancelled"
+	ReceptionStatusClose      ReceptionStatus = "close"
+	ReceptionStatusInProgress ReceptionStatus = "in_progress"
+	ReceptionStatusPaused     ReceptionStatus = "paused"
+)
+
+// Defines values for ReceptionTransitionFrom.
+const (
+	ReceptionTransitionFromCancelled  ReceptionTransitionFrom = "cancelled"
+	ReceptionTransitionFromClose      ReceptionTransitionFrom = "close"
+	ReceptionTransitionFromInProgress ReceptionTransitionFrom = "in_progress"
+	ReceptionTransitionFromPaused     ReceptionTransitionFrom = "paused"
+)
+
+// Defines values for ReceptionTransitionTo.
+const (
+	ReceptionTransitionToCancelled  ReceptionTransitionTo = "cancelled"
+	ReceptionTransitionToClose      ReceptionTransitionTo = "close"
+	ReceptionTransitionToInProgress ReceptionTransitionTo = "in_progress"
+	ReceptionTransitionToPaused     ReceptionTransitionTo = "paused"
+)
+
+// Defines values for UserRole.
+const (
+	UserRoleClient    UserRole = "client"
+	UserRoleModerator UserRole = "moderator"
+	UserRolePvzStaff  UserRole = "pvz_staff"
+)
+
+// Defines values for PostDummyLoginJSONBodyRole.
+const (
+	PostDummyLoginJSONBodyRoleClient    PostDummyLoginJSONBodyRole = "client"
+	PostDummyLoginJSONBodyRoleModerator PostDummyLoginJSONBodyRole = "moderator"
+	PostDummyLoginJSONBodyRolePvzStaff  PostDummyLoginJSONBodyRole = "pvz_staff"
+)
+
+// Defines values for GetPvzPvzIdReceptionsParamsStatus.
+const (
+	GetPvzPvzIdReceptionsParamsStatusCancelled  GetPvzPvzIdReceptionsParamsStatus = "cancelled"
+	GetPvzPvzIdReceptionsParamsStatusClose      GetPvzPvzIdReceptionsParamsStatus = "close"
+	GetPvzPvzIdReceptionsParamsStatusInProgress GetPvzPvzIdReceptionsParamsStatus = "in_progress"
+	GetPvzPvzIdReceptionsParamsStatusPaused     GetPvzPvzIdReceptionsParamsStatus = "paused"
+)
+
+// Defines values for PostRegisterJSONBodyRole.
+const (
+	PostRegisterJSONBodyRoleClient    PostRegisterJSONBodyRole = "client"
+	PostRegisterJSONBodyRoleModerator PostRegisterJSONBodyRole = "moderator"
+	PostRegisterJSONBodyRolePvzStaff  PostRegisterJSONBodyRole = "pvz_staff"
+)
+
+// CatalogEntry defines model for CatalogEntry.
+type CatalogEntry struct {
+	// Active Отключённый элемент нельзя выбрать для новых ПВЗ и товаров, созданные не меняются
+	Active bool   `json:"active"`
+	Id     int64  `json:"id"`
+	Name   string `json:"name"`
+}
+
+// CatalogKind defines model for CatalogKind.
+type CatalogKind string
+
+// Error defines model for Error.
+type Error struct {
+	// Code Машиночитаемый код ошибки (стабилен, в отличие от message)
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+// ErrorCode Машиночитаемый код ошибки (стабилен, в отличие от message)
+type ErrorCode string
+
+// GeoPoint Координаты в градусах (WGS 84). Без координат ПВЗ не участвует в поиске ближайших
+type GeoPoint struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// NearestPVZ defines model for NearestPVZ.
+type NearestPVZ struct {
+	// Distance Расстояние до ПВЗ в метрах
+	Distance float64 `json:"distance"`
+	Pvz      PVZ     `json:"pvz"`
+}
+
+// PVZ defines model for PVZ.
+type PVZ struct {
+	Address *string `json:"address,omitempty"`
+
+	// ArchivedAt Когда ПВЗ выведен из работы; нет поля — ПВЗ работает
+	ArchivedAt *time.Time `json:"archivedAt,omitempty"`
+
+	// City Название активного города из справочника (GET /catalogs/city)
+	City string              `json:"city"`
+	Id   *openapi_types.UUID `json:"id,omitempty"`
+
+	// Location Координаты в градусах (WGS 84). Без координат ПВЗ не участвует в поиске ближайших
+	Location *GeoPoint `json:"location,omitempty"`
+
+	// Name Название для клиентов и курьеров
+	Name             *string    `json:"name,omitempty"`
+	RegistrationDate *time.Time `json:"registrationDate,omitempty"`
+	WorkingHours     *string    `json:"workingHours,omitempty"`
+}
+
+// PVZWithReceptions defines model for PVZWithReceptions.
+type PVZWithReceptions struct {
+	Pvz        PVZ                     `json:"pvz"`
+	Receptions []ReceptionWithProducts `json:"receptions"`
+}
+
+// Product defines model for Product.
+type Product struct {
+	// Barcode Штрихкод посылки, не передаётся для товаров без штрихкода
+	Barcode  *string             `json:"barcode,omitempty"`
+	DateTime *time.Time          `json:"dateTime,omitempty"`
+	Id       *openapi_types.UUID `json:"id,omitempty"`
+
+	// Position Порядковый номер товара в приёмке, последний удаляется первым
+	Position    *int               `json:"position,omitempty"`
+	ReceptionId openapi_types.UUID `json:"receptionId"`
+
+	// Type Название типа товара из справочника (GET /catalogs/product_type)
+	Type string `json:"type"`
+}
+
+// ProductBatchItemResult Результат по одному товару пакета — сохранённый товар или причина отказа
+type ProductBatchItemResult struct {
+	Error *string `json:"error,omitempty"`
+
+	// Index Номер товара в запросе, с нуля
+	Index   int      `json:"index"`
+	Product *Product `json:"product,omitempty"`
+}
+
+// ProductBatchResult defines model for ProductBatchResult.
+type ProductBatchResult struct {
+	Accepted int `json:"accepted"`
+	Rejected int `json:"rejected"`
+
+	// Results Результаты в порядке товаров запроса
+	Results []ProductBatchItemResult `json:"results"`
+}
+
+// ProductLocation Где находится посылка — товар, его приёмка и ПВЗ
+type ProductLocation struct {
+	Product   Product   `json:"product"`
+	Pvz       PVZ       `json:"pvz"`
+	Reception Reception `json:"reception"`
+}
+
+// ProductRemoval Запись журнала удалений — удалённый товар и кто его удалил
+type ProductRemoval struct {
+	Product   Product            `json:"product"`
+	RemovedAt time.Time          `json:"removedAt"`
+	RemovedBy openapi_types.UUID `json:"removedBy"`
+}
+
+// Reception defines model for Reception.
+type Reception struct {
+	// ClosedAt Когда приёмку закрыли, нет у открытой приёмки
+	ClosedAt *time.Time          `json:"closedAt,omitempty"`
+	DateTime time.Time           `json:"dateTime"`
+	Id       *openapi_types.UUID `json:"id,omitempty"`
+	PvzId    openapi_types.UUID  `json:"pvzId"`
+
+	// Status in_progress — идёт приёмка; paused — сканирование остановлено, ПВЗ занят; close — закрыта; cancelled — отменена, товары аннулированы
+	Status ReceptionStatus `json:"status"`
+}
+
+// ReceptionStatus in_progress — идёт приёмка; paused — сканирование остановлено, ПВЗ занят; close — закрыта; cancelled — отменена, товары аннулированы
+type ReceptionStatus string
+
+// ReceptionTransition Запись истории статусов приёмки
+type ReceptionTransition struct {
+	ChangedAt time.Time               `json:"changedAt"`
+	ChangedBy openapi_types.UUID      `json:"changedBy"`
+	From      ReceptionTransitionFrom `json:"from"`
+
+	// Reason Причина, обязательна при переоткрытии
+	Reason *string               `json:"reason,omitempty"`
+	To     ReceptionTransitionTo `json:"to"`
+}
+
+// ReceptionTransitionFrom defines model for ReceptionTransition.From.
+type ReceptionTransitionFrom string
+
+// ReceptionTransitionTo defines model for ReceptionTransition.To.
+type ReceptionTransitionTo string
+
+// ReceptionWithProducts defines model for ReceptionWithProducts.
+type ReceptionWithProducts struct {
+	Products  []Product `json:"products"`
+	Reception Reception `json:"reception"`
+}
+
+// StaffAssignment Назначение сотрудника на ПВЗ
+type StaffAssignment struct {
+	AssignedAt time.Time `json:"assignedAt"`
+
+	// AssignedBy Модератор, назначивший сотрудника
+	AssignedBy openapi_types.UUID `json:"assignedBy"`
+	PvzId      openapi_types.UUID `json:"pvzId"`
+	UserId     openapi_types.UUID `json:"userId"`
+}
+
+// Token defines model for Token.
+type Token = string
+
+// TokenResponse defines model for TokenResponse.
+type TokenResponse struct {
+	// ExpiresIn Время жизни access-токена (token) в секундах
+	ExpiresIn int `json:"expiresIn"`
+
+	// RefreshToken Одноразовый refresh-токен для POST /token/refresh
+	RefreshToken string `json:"refreshToken"`
+	Token        Token  `json:"token"`
+
+	// UserId Id пользователя, которому выдан токен (sub access-токена); нужен, например, для назначения сотрудника на ПВЗ
+	UserId openapi_types.UUID `json:"userId"`
+}
+
+// User defines model for User.
+type User struct {
+	Email openapi_types.Email `json:"email"`
+	Id    *openapi_types.UUID `json:"id,omitempty"`
+	Role  UserRole            `json:"role"`
+}
+
+// UserRole defines model for User.Role.
+type UserRole string
+
+// PostCatalogsKindJSONBody defines parameters for PostCatalogsKind.
+type PostCatalogsKindJSONBody struct {
+	Name string `json:"name"`
+}
+
+// PatchCatalogsKindIdJSONBody defines parameters for PatchCatalogsKindId.
+type PatchCatalogsKindIdJSONBody struct {
+	Active *bool   `json:"active,omitempty"`
+	Name   *string `json:"name,omitempty"`
+}
+
+// PostDummyLoginJSONBody defines parameters for PostDummyLogin.
+type PostDummyLoginJSONBody struct {
+	Role PostDummyLoginJSONBodyRole `json:"role"`
+}
+
+// PostDummyLoginJSONBodyRole defines parameters for PostDummyLogin.
+type PostDummyLoginJSONBodyRole string
+
+// PostLoginJSONBody defines parameters for PostLogin.
+type PostLoginJSONBody struct {
+	Email    openapi_types.Email `json:"email"`
+	Password string              `json:"password"`
+}
+
+// GetProductsParams defines parameters for GetProducts.
+type GetProductsParams struct {
+	Barcode string `form:"barcode" json:"barcode"`
+}
+
+// PostProductsJSONBody defines parameters for PostProducts.
+type PostProductsJSONBody struct {
+	// Barcode Штрихкод посылки, печатные символы ASCII; повторное сканирование в ту же приёмку — 409
+	Barcode *string            `json:"barcode,omitempty"`
+	PvzId   openapi_types.UUID `json:"pvzId"`
+
+	// Type Название активного типа товара из справочника (GET /catalogs/product_type)
+	Type string `json:"type"`
+}
+
+// PostProductsBatchJSONBody defines parameters for PostProductsBatch.
+type PostProductsBatchJSONBody struct {
+	Products []struct {
+		Barcode *string `json:"barcode,omitempty"`
+
+		// Type Название активного типа товара из справочника (GET /catalogs/product_type)
+		Type string `json:"type"`
+	} `json:"products"`
+	PvzId openapi_types.UUID `json:"pvzId"`
+}
+
+// GetPvzParams defines parameters for GetPvz.
+type GetPvzParams struct {
+	// StartDate Начальная дата диапазона
+	StartDate *time.Time `form:"startDate,omitempty" json:"startDate,omitempty"`
+
+	// EndDate Конечная дата диапазона
+	EndDate *time.Time `form:"endDate,omitempty" json:"endDate,omitempty"`
+
+	// Page Номер страницы
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// Limit Количество элементов на странице
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// IncludeArchived Показывать архивные ПВЗ
+	IncludeArchived *bool `form:"includeArchived,omitempty" json:"includeArchived,omitempty"`
+}
+
+// GetPvzNearestParams defines parameters for GetPvzNearest.
+type GetPvzNearestParams struct {
+	Lat float64 `form:"lat" json:"lat"`
+	Lon float64 `form:"lon" json:"lon"`
+
+	// Radius Радиус поиска в метрах
+	Radius *float64 `form:"radius,omitempty" json:"radius,omitempty"`
+
+	// Limit Максимальное количество ПВЗ
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// PatchPvzPvzIdJSONBody defines parameters for PatchPvzPvzId.
+type PatchPvzPvzIdJSONBody struct {
+	Address *string `json:"address,omitempty"`
+
+	// City Название активного города из справочника
+	City *string `json:"city,omitempty"`
+
+	// Location Координаты в градусах (WGS 84). Без координат ПВЗ не участвует в поиске ближайших
+	Location     *GeoPoint `json:"location,omitempty"`
+	Name         *string   `json:"name,omitempty"`
+	WorkingHours *string   `json:"workingHours,omitempty"`
+}
+
+// GetPvzPvzIdReceptionsParams defines parameters for GetPvzPvzIdReceptions.
+type GetPvzPvzIdReceptionsParams struct {
+	Status *GetPvzPvzIdReceptionsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// StartDate Начальная дата диапазона
+	StartDate *time.Time `form:"startDate,omitempty" json:"startDate,omitempty"`
+
+	// EndDate Конечная дата диапазона
+	EndDate *time.Time `form:"endDate,omitempty" json:"endDate,omitempty"`
+
+	// Page Номер страницы
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// Limit Количество элементов на странице
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetPvzPvzIdReceptionsParamsStatus defines parameters for GetPvzPvzIdReceptions.
+type GetPvzPvzIdReceptionsParamsStatus string
+
+// PostReceptionsJSONBody defines parameters for PostReceptions.
+type PostReceptionsJSONBody struct {
+	PvzId openapi_types.UUID `json:"pvzId"`
+}
+
+// PostReceptionsReceptionIdCancelJSONBody defines parameters for PostReceptionsReceptionIdCancel.
+type PostReceptionsReceptionIdCancelJSONBody struct {
+	Reason string `json:"reason"`
+}
+
+// PostReceptionsReceptionIdReopenJSONBody defines parameters for PostReceptionsReceptionIdReopen.
+type PostReceptionsReceptionIdReopenJSONBody struct {
+	Reason string `json:"reason"`
+}
+
+// PostRegisterJSONBody defines parameters for PostRegister.
+type PostRegisterJSONBody struct {
+	Email    openapi_types.Email      `json:"email"`
+	Password string                   `json:"password"`
+	Role     PostRegisterJSONBodyRole `json:"role"`
+}
+
+// PostRegisterJSONBodyRole defines parameters for PostRegister.
+type PostRegisterJSONBodyRole string
+
+// PostTokenRefreshJSONBody defines parameters for PostTokenRefresh.
+type PostTokenRefreshJSONBody struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+// PostCatalogsKindJSONRequestBody defines body for PostCatalogsKind for application/json ContentType.
+type PostCatalogsKindJSONRequestBody PostCatalogsKindJSONBody
+
+// PatchCatalogsKindIdJSONRequestBody defines body for PatchCatalogsKindId for application/json ContentType.
+type PatchCatalogsKindIdJSONRequestBody PatchCatalogsKindIdJSONBody
+
+// PostDummyLoginJSONRequestBody defines body for PostDummyLogin for application/json ContentType.
+type PostDummyLoginJSONRequestBody PostDummyLoginJSONBody
+
+// PostLoginJSONRequestBody defines body for PostLogin for application/json ContentType.
+type PostLoginJSONRequestBody PostLoginJSONBody
+
+// PostProductsJSONRequestBody defines body for PostProducts for application/json ContentType.
+type PostProductsJSONRequestBody PostProductsJSONBody
+
+// PostProductsBatchJSONRequestBody defines body for PostProductsBatch for application/json ContentType.
+type PostProductsBatchJSONRequestBody PostProductsBatchJSONBody
+
+// PostPvzJSONRequestBody defines body for PostPvz for application/json ContentType.
+type PostPvzJSONRequestBody = PVZ
+
+// PatchPvzPvzIdJSONRequestBody defines body for PatchPvzPvzId for application/json ContentType.
+type PatchPvzPvzIdJSONRequestBody PatchPvzPvzIdJSONBody
+
+// PostReceptionsJSONRequestBody defines body for PostReceptions for application/json ContentType.
+type PostReceptionsJSONRequestBody PostReceptionsJSONBody
+
+// PostReceptionsReceptionIdCancelJSONRequestBody defines body for PostReceptionsReceptionIdCancel for application/json ContentType.
+type PostReceptionsReceptionIdCancelJSONRequestBody PostReceptionsReceptionIdCancelJSONBody
+
+// PostReceptionsReceptionIdReopenJSONRequestBody defines body for PostReceptionsReceptionIdReopen for application/json ContentType.
+type PostReceptionsReceptionIdReopenJSONRequestBody PostReceptionsReceptionIdReopenJSONBody
+
+// PostRegisterJSONRequestBody defines body for PostRegister for application/json ContentType.
+type PostRegisterJSONRequestBody PostRegisterJSONBody
+
+// PostTokenRefreshJSONRequestBody defines body for PostTokenRefresh for application/json ContentType.
+type PostTokenRefreshJSONRequestBody PostTokenRefreshJSONBody
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Справочник городов или типов товаров (модератор видит и отключённые элементы)
+	// (GET /catalogs/{kind})
+	GetCatalogsKind(c *gin.Context, kind CatalogKind)
+	// Добавление элемента справочника (только для модераторов)
+	// (POST /catalogs/{kind})
+	PostCatalogsKind(c *gin.Context, kind CatalogKind)
+	// Переименование, отключение или включение элемента справочника (только для модераторов)
+	// (PATCH /catalogs/{kind}/{id})
+	PatchCatalogsKindId(c *gin.Context, kind CatalogKind, id int64)
+	// Получение тестового токена
+	// (POST /dummyLogin)
+	PostDummyLogin(c *gin.Context)
+	// Авторизация пользователя
+	// (POST /login)
+	PostLogin(c *gin.Context)
+	// Выход — завершение сессии текущего токена
+	// (POST /logout)
+	PostLogout(c *gin.Context)
+	// Поиск посылки по штрихкоду — в каких ПВЗ и приёмках она принималась, новые первыми
+	// (GET /products)
+	GetProducts(c *gin.Context, params GetProductsParams)
+	// Добавление товара в текущую приемку (только для сотрудников, назначенных на ПВЗ)
+	// (POST /products)
+	PostProducts(c *gin.Context)
+	// Пакетное добавление товаров в текущую приемку (только для сотрудников, назначенных на ПВЗ)
+	// (POST /products/batch)
+	PostProductsBatch(c *gin.Context)
+	// Получение списка ПВЗ с фильтрацией по дате приемки и пагинацией
+	// (GET /pvz)
+	GetPvz(c *gin.Context, params GetPvzParams)
+	// Создание ПВЗ (только для модераторов)
+	// (POST /pvz)
+	PostPvz(c *gin.Context)
+	// Ближайшие к точке работающие ПВЗ, по возрастанию расстояния (любая роль)
+	// (GET /pvz/nearest)
+	GetPvzNearest(c *gin.Context, params GetPvzNearestParams)
+	// Получение ПВЗ, в том числе архивного
+	// (GET /pvz/{pvzId})
+	GetPvzPvzId(c *gin.Context, pvzId openapi_types.UUID)
+	// Исправление данных ПВЗ (только для модераторов)
+	// (PATCH /pvz/{pvzId})
+	PatchPvzPvzId(c *gin.Context, pvzId openapi_types.UUID)
+	// Вывод ПВЗ из работы (только для модераторов). Архивный ПВЗ не принимает приёмки и скрыт из GET /pvz
+	// (POST /pvz/{pvzId}/archive)
+	PostPvzPvzIdArchive(c *gin.Context, pvzId openapi_types.UUID)
+	// Закрытие последней открытой приемки товаров в рамках ПВЗ (только для сотрудников, назначенных на ПВЗ)
+	// (POST /pvz/{pvzId}/close_last_reception)
+	PostPvzPvzIdCloseLastReception(c *gin.Context, pvzId openapi_types.UUID)
+	// Удаление последнего добавленного товара из текущей приемки (LIFO, только для сотрудников, назначенных на ПВЗ)
+	// (POST /pvz/{pvzId}/delete_last_product)
+	PostPvzPvzIdDeleteLastProduct(c *gin.Context, pvzId openapi_types.UUID)
+	// История приёмок ПВЗ, новые первыми, с фильтрами по статусу и дате и пагинацией
+	// (GET /pvz/{pvzId}/receptions)
+	GetPvzPvzIdReceptions(c *gin.Context, pvzId openapi_types.UUID, params GetPvzPvzIdReceptionsParams)
+	// Сотрудники, назначенные на ПВЗ, в порядке назначения (только для модераторов)
+	// (GET /pvz/{pvzId}/staff)
+	GetPvzPvzIdStaff(c *gin.Context, pvzId openapi_types.UUID)
+	// Снятие сотрудника с ПВЗ (только для модераторов)
+	// (DELETE /pvz/{pvzId}/staff/{userId})
+	DeletePvzPvzIdStaffUserId(c *gin.Context, pvzId openapi_types.UUID, userId openapi_types.UUID)
+	// Назначение сотрудника на ПВЗ (только для модераторов). Приёмки и товары ПВЗ доступны только назначенным сотрудникам
+	// (PUT /pvz/{pvzId}/staff/{userId})
+	PutPvzPvzIdStaffUserId(c *gin.Context, pvzId openapi_types.UUID, userId openapi_types.UUID)
+	// Возврат ПВЗ в работу (только для модераторов)
+	// (POST /pvz/{pvzId}/unarchive)
+	PostPvzPvzIdUnarchive(c *gin.Context, pvzId openapi_types.UUID)
+	// Создание новой приемки товаров (только для сотрудников, назначенных на ПВЗ)
+	// (POST /receptions)
+	PostReceptions(c *gin.Context)
+	// Приёмка вместе с товарами в порядке добавления
+	// (GET /receptions/{receptionId})
+	GetReceptionsReceptionId(c *gin.Context, receptionId openapi_types.UUID)
+	// Отмена открытой или приостановленной приёмки (только для сотрудников, назначенных на ПВЗ)
+	// (POST /receptions/{receptionId}/cancel)
+	PostReceptionsReceptionIdCancel(c *gin.Context, receptionId openapi_types.UUID)
+	// Пауза приёмки — сканирование останавливается, ПВЗ остаётся занят (только для сотрудников, назначенных на ПВЗ)
+	// (POST /receptions/{receptionId}/pause)
+	PostReceptionsReceptionIdPause(c *gin.Context, receptionId openapi_types.UUID)
+	// Удаление конкретного товара из незакрытой приёмки (только для сотрудников, назначенных на ПВЗ)
+	// (DELETE /receptions/{receptionId}/products/{productId})
+	DeleteReceptionsReceptionIdProductsProductId(c *gin.Context, receptionId openapi_types.UUID, productId openapi_types.UUID)
+	// Переоткрытие закрытой приёмки (только для модераторов)
+	// (POST /receptions/{receptionId}/reopen)
+	PostReceptionsReceptionIdReopen(c *gin.Context, receptionId openapi_types.UUID)
+	// Продолжение приёмки после паузы (только для сотрудников, назначенных на ПВЗ)
+	// (POST /receptions/{receptionId}/resume)
+	PostReceptionsReceptionIdResume(c *gin.Context, receptionId openapi_types.UUID)
+	// История статусов приёмки — закрытия и переоткрытия по порядку
+	// (GET /receptions/{receptionId}/transitions)
+	GetReceptionsReceptionIdTransitions(c *gin.Context, receptionId openapi_types.UUID)
+	// Регистрация пользователя
+	// (POST /register)
+	PostRegister(c *gin.Context)
+	// Обмен refresh-токена на новую пару токенов
+	// (POST /token/refresh)
+	PostTokenRefresh(c *gin.Context)
+	// Завершение всех сессий пользователя (только для модераторов)
+	// (POST /users/{userId}/revoke_sessions)
+	PostUsersUserIdRevokeSessions(c *gin.Context, userId openapi_types.UUID)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandler       func(*gin.Context, error, int)
+}
+
+type MiddlewareFunc func(c *gin.Context)
+
+// GetCatalogsKind operation middleware
+func (siw *ServerInterfaceWrapper) GetCatalogsKind(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "kind" -------------
+	var kind CatalogKind
+
+	err = runtime.BindStyledParameterWithOptions("simple", "kind", c.Param("kind"), &kind, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter kind: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetCatalogsKind(c, kind)
+}
+
+// PostCatalogsKind operation middleware
+func (siw *ServerInterfaceWrapper) PostCatalogsKind(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "kind" -------------
+	var kind CatalogKind
+
+	err = runtime.BindStyledParameterWithOptions("simple", "kind", c.Param("kind"), &kind, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter kind: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostCatalogsKind(c, kind)
+}
+
+// PatchCatalogsKindId operation middleware
+func (siw *ServerInterfaceWrapper) PatchCatalogsKindId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "kind" -------------
+	var kind CatalogKind
+
+	err = runtime.BindStyledParameterWithOptions("simple", "kind", c.Param("kind"), &kind, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter kind: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PatchCatalogsKindId(c, kind, id)
+}
+
+// PostDummyLogin operation middleware
+func (siw *ServerInterfaceWrapper) PostDummyLogin(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostDummyLogin(c)
+}
+
+// PostLogin operation middleware
+func (siw *ServerInterfaceWrapper) PostLogin(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostLogin(c)
+}
+
+// PostLogout operation middleware
+func (siw *ServerInterfaceWrapper) PostLogout(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostLogout(c)
+}
+
+// GetProducts operation middleware
+func (siw *ServerInterfaceWrapper) GetProducts(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProductsParams
+
+	// ------------- Required query parameter "barcode" -------------
+
+	if paramValue := c.Query("barcode"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument barcode is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "barcode", c.Request.URL.Query(), &params.Barcode)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter barcode: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetProducts(c, params)
+}
+
+// PostProducts operation middleware
+func (siw *ServerInterfaceWrapper) PostProducts(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostProducts(c)
+}
+
+// PostProductsBatch operation middleware
+func (siw *ServerInterfaceWrapper) PostProductsBatch(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostProductsBatch(c)
+}
+
+// GetPvz operation middleware
+func (siw *ServerInterfaceWrapper) GetPvz(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPvzParams
+
+	// ------------- Optional query parameter "startDate" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "startDate", c.Request.URL.Query(), &params.StartDate)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter startDate: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "endDate" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "endDate", c.Request.URL.Query(), &params.EndDate)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter endDate: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", c.Request.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "includeArchived" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "includeArchived", c.Request.URL.Query(), &params.IncludeArchived)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter includeArchived: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetPvz(c, params)
+}
+
+// PostPvz operation middleware
+func (siw *ServerInterfaceWrapper) PostPvz(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostPvz(c)
+}
+
+// GetPvzNearest operation middleware
+func (siw *ServerInterfaceWrapper) GetPvzNearest(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPvzNearestParams
+
+	// ------------- Required query parameter "lat" -------------
+
+	if paramValue := c.Query("lat"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument lat is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "lat", c.Request.URL.Query(), &params.Lat)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter lat: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "lon" -------------
+
+	if paramValue := c.Query("lon"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument lon is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "lon", c.Request.URL.Query(), &params.Lon)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter lon: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "radius" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "radius", c.Request.URL.Query(), &params.Radius)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter radius: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetPvzNearest(c, params)
+}
+
+// GetPvzPvzId operation middleware
+func (siw *ServerInterfaceWrapper) GetPvzPvzId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "pvzId" -------------
+	var pvzId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Param("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pvzId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetPvzPvzId(c, pvzId)
+}
+
+// PatchPvzPvzId operation middleware
+func (siw *ServerInterfaceWrapper) PatchPvzPvzId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "pvzId" -------------
+	var pvzId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Param("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pvzId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PatchPvzPvzId(c, pvzId)
+}
+
+// PostPvzPvzIdArchive operation middleware
+func (siw *ServerInterfaceWrapper) PostPvzPvzIdArchive(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "pvzId" -------------
+	var pvzId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Param("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pvzId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostPvzPvzIdArchive(c, pvzId)
+}
+
+// PostPvzPvzIdCloseLastReception operation middleware
+func (siw *ServerInterfaceWrapper) PostPvzPvzIdCloseLastReception(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "pvzId" -------------
+	var pvzId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Param("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pvzId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostPvzPvzIdCloseLastReception(c, pvzId)
+}
+
+// PostPvzPvzIdDeleteLastProduct operation middleware
+func (siw *ServerInterfaceWrapper) PostPvzPvzIdDeleteLastProduct(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "pvzId" -------------
+	var pvzId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Param("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pvzId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostPvzPvzIdDeleteLastProduct(c, pvzId)
+}
+
+// GetPvzPvzIdReceptions operation middleware
+func (siw *ServerInterfaceWrapper) GetPvzPvzIdReceptions(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "pvzId" -------------
+	var pvzId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Param("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pvzId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPvzPvzIdReceptionsParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", c.Request.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "startDate" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "startDate", c.Request.URL.Query(), &params.StartDate)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter startDate: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "endDate" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "endDate", c.Request.URL.Query(), &params.EndDate)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter endDate: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", c.Request.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetPvzPvzIdReceptions(c, pvzId, params)
+}
+
+// GetPvzPvzIdStaff operation middleware
+func (siw *ServerInterfaceWrapper) GetPvzPvzIdStaff(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "pvzId" -------------
+	var pvzId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Param("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pvzId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetPvzPvzIdStaff(c, pvzId)
+}
+
+// DeletePvzPvzIdStaffUserId operation middleware
+func (siw *ServerInterfaceWrapper) DeletePvzPvzIdStaffUserId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "pvzId" -------------
+	var pvzId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Param("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pvzId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "userId" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", c.Param("userId"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter userId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeletePvzPvzIdStaffUserId(c, pvzId, userId)
+}
+
+// PutPvzPvzIdStaffUserId operation middleware
+func (siw *ServerInterfaceWrapper) PutPvzPvzIdStaffUserId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "pvzId" -------------
+	var pvzId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Param("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pvzId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "userId" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", c.Param("userId"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter userId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutPvzPvzIdStaffUserId(c, pvzId, userId)
+}
+
+// PostPvzPvzIdUnarchive operation middleware
+func (siw *ServerInterfaceWrapper) PostPvzPvzIdUnarchive(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "pvzId" -------------
+	var pvzId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Param("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pvzId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostPvzPvzIdUnarchive(c, pvzId)
+}
+
+// PostReceptions operation middleware
+func (siw *ServerInterfaceWrapper) PostReceptions(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostReceptions(c)
+}
+
+// GetReceptionsReceptionId operation middleware
+func (siw *ServerInterfaceWrapper) GetReceptionsReceptionId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "receptionId" -------------
+	var receptionId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "receptionId", c.Param("receptionId"), &receptionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter receptionId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetReceptionsReceptionId(c, receptionId)
+}
+
+// PostReceptionsReceptionIdCancel operation middleware
+func (siw *ServerInterfaceWrapper) PostReceptionsReceptionIdCancel(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "receptionId" -------------
+	var receptionId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "receptionId", c.Param("receptionId"), &receptionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter receptionId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostReceptionsReceptionIdCancel(c, receptionId)
+}
+
+// PostReceptionsReceptionIdPause operation middleware
+func (siw *ServerInterfaceWrapper) PostReceptionsReceptionIdPause(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "receptionId" -------------
+	var receptionId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "receptionId", c.Param("receptionId"), &receptionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter receptionId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostReceptionsReceptionIdPause(c, receptionId)
+}
+
+// DeleteReceptionsReceptionIdProductsProductId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteReceptionsReceptionIdProductsProductId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "receptionId" -------------
+	var receptionId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "receptionId", c.Param("receptionId"), &receptionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter receptionId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "productId" -------------
+	var productId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", c.Param("productId"), &productId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter productId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteReceptionsReceptionIdProductsProductId(c, receptionId, productId)
+}
+
+// PostReceptionsReceptionIdReopen operation middleware
+func (siw *ServerInterfaceWrapper) PostReceptionsReceptionIdReopen(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "receptionId" -------------
+	var receptionId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "receptionId", c.Param("receptionId"), &receptionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter receptionId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostReceptionsReceptionIdReopen(c, receptionId)
+}
+
+// PostReceptionsReceptionIdResume operation middleware
+func (siw *ServerInterfaceWrapper) PostReceptionsReceptionIdResume(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "receptionId" -------------
+	var receptionId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "receptionId", c.Param("receptionId"), &receptionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter receptionId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostReceptionsReceptionIdResume(c, receptionId)
+}
+
+// GetReceptionsReceptionIdTransitions operation middleware
+func (siw *ServerInterfaceWrapper) GetReceptionsReceptionIdTransitions(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "receptionId" -------------
+	var receptionId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "receptionId", c.Param("receptionId"), &receptionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter receptionId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetReceptionsReceptionIdTransitions(c, receptionId)
+}
+
+// PostRegister operation middleware
+func (siw *ServerInterfaceWrapper) PostRegister(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostRegister(c)
+}
+
+// PostTokenRefresh operation middleware
+func (siw *ServerInterfaceWrapper) PostTokenRefresh(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostTokenRefresh(c)
+}
+
+// PostUsersUserIdRevokeSessions operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersUserIdRevokeSessions(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", c.Param("userId"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter userId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostUsersUserIdRevokeSessions(c, userId)
+}
+
+// GinServerOptions provides options for the Gin server.
+type GinServerOptions struct {
+	BaseURL      string
+	Middlewares  []MiddlewareFunc
+	ErrorHandler func(*gin.Context, error, int)
+}
+
+// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
+func RegisterHandlers(router gin.IRouter, si ServerInterface) {
+	RegisterHandlersWithOptions(router, si, GinServerOptions{})
+}
+
+// RegisterHandlersWithOptions creates http.Handler with additional options
+func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options GinServerOptions) {
+	errorHandler := options.ErrorHandler
+	if errorHandler == nil {
+		errorHandler = func(c *gin.Context, err error, statusCode int) {
+			c.JSON(statusCode, gin.H{"msg": err.Error()})
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandler:       errorHandler,
+	}
+
+	router.GET(options.BaseURL+"/catalogs/:kind", wrapper.GetCatalogsKind)
+	router.POST(options.BaseURL+"/catalogs/:kind", wrapper.PostCatalogsKind)
+	router.PATCH(options.BaseURL+"/catalogs/:kind/:id", wrapper.PatchCatalogsKindId)
+	router.POST(options.BaseURL+"/dummyLogin", wrapper.PostDummyLogin)
+	router.POST(options.BaseURL+"/login", wrapper.PostLogin)
+	router.POST(options.BaseURL+"/logout", wrapper.PostLogout)
+	router.GET(options.BaseURL+"/products", wrapper.GetProducts)
+	router.POST(options.BaseURL+"/products", wrapper.PostProducts)
+	router.POST(options.BaseURL+"/products/batch", wrapper.PostProductsBatch)
+	router.GET(options.BaseURL+"/pvz", wrapper.GetPvz)
+	router.POST(options.BaseURL+"/pvz", wrapper.PostPvz)
+	router.GET(options.BaseURL+"/pvz/nearest", wrapper.GetPvzNearest)
+	router.GET(options.BaseURL+"/pvz/:pvzId", wrapper.GetPvzPvzId)
+	router.PATCH(options.BaseURL+"/pvz/:pvzId", wrapper.PatchPvzPvzId)
+	router.POST(options.BaseURL+"/pvz/:pvzId/archive", wrapper.PostPvzPvzIdArchive)
+	router.POST(options.BaseURL+"/pvz/:pvzId/close_last_reception", wrapper.PostPvzPvzIdCloseLastReception)
+	router.POST(options.BaseURL+"/pvz/:pvzId/delete_last_product", wrapper.PostPvzPvzIdDeleteLastProduct)
+	router.GET(options.BaseURL+"/pvz/:pvzId/receptions", wrapper.GetPvzPvzIdReceptions)
+	router.GET(options.BaseURL+"/pvz/:pvzId/staff", wrapper.GetPvzPvzIdStaff)
+	router.DELETE(options.BaseURL+"/pvz/:pvzId/staff/:userId", wrapper.DeletePvzPvzIdStaffUserId)
+	router.PUT(options.BaseURL+"/pvz/:pvzId/staff/:userId", wrapper.PutPvzPvzIdStaffUserId)
+	router.POST(options.BaseURL+"/pvz/:pvzId/unarchive", wrapper.PostPvzPvzIdUnarchive)
+	router.POST(options.BaseURL+"/receptions", wrapper.PostReceptions)
+	router.GET(options.BaseURL+"/receptions/:receptionId", wrapper.GetReceptionsReceptionId)
+	router.POST(options.BaseURL+"/receptions/:receptionId/cancel", wrapper.PostReceptionsReceptionIdCancel)
+	router.POST(options.BaseURL+"/receptions/:receptionId/pause", wrapper.PostReceptionsReceptionIdPause)
+	router.DELETE(options.BaseURL+"/receptions/:receptionId/products/:productId", wrapper.DeleteReceptionsReceptionIdProductsProductId)
+	router.POST(options.BaseURL+"/receptions/:receptionId/reopen", wrapper.PostReceptionsReceptionIdReopen)
+	router.POST(options.BaseURL+"/receptions/:receptionId/resume", wrapper.PostReceptionsReceptionIdResume)
+	router.GET(options.BaseURL+"/receptions/:receptionId/transitions", wrapper.GetReceptionsReceptionIdTransitions)
+	router.POST(options.BaseURL+"/register", wrapper.PostRegister)
+	router.POST(options.BaseURL+"/token/refresh", wrapper.PostTokenRefresh)
+	router.POST(options.BaseURL+"/users/:userId/revoke_sessions", wrapper.PostUsersUserIdRevokeSessions)
+}
@@ -0,0 +1,5 @@
+// Package api содержит DTO и типизированный интерфейс gin-сервера,
+// сгенерированные по swagger.yaml (oapi-codegen). Руками не редактировать — см. make api-gen
+package api
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen@v2.5.0 -config oapi-codegen.yaml ../../../swagger.yaml
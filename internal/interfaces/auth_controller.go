@@ -2,6 +2,8 @@ package interfaces
 
 import (
 	"context"
+
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/api"
 )
 
 // AuthController — интерфейс контроллера авторизации (см. .puml)
@@ -9,7 +11,11 @@ type AuthController interface {
 	// DummyLogin выдаёт токен по роли (без пароля)
 	DummyLogin(ctx context.Context, role string) (string, error)
 	// Register регистрирует пользователя
-	Register(ctx context.Context, req RegisterRequest) (UserDTO, error)
+	Register(ctx context.Context, req api.PostRegisterJSONRequestBody) (api.User, error)
 	// Login логинит пользователя по email+пароль
-	Login(ctx context.Context, req LoginRequest) (string, error)
+	Login(ctx context.Context, req api.PostLoginJSONRequestBody) (string, error)
+	// Refresh обменивает refresh-токен на новую пару токенов
+	Refresh(ctx context.Context, req api.PostTokenRefreshJSONRequestBody) (api.TokenResponse, error)
+	// Logout завершает сессию текущего токена
+	Logout(ctx context.Context) error
 }
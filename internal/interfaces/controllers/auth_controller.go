@@ -4,7 +4,10 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/api"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
 )
 
@@ -12,66 +15,98 @@ type AuthController struct {
 	DummyLoginUC usecases.DummyLoginUseCaseIface
 	RegisterUC   usecases.RegisterUseCaseIface
 	LoginUC      usecases.LoginUseCaseIface
+	RefreshUC    usecases.RefreshTokenUseCaseIface
+	LogoutUC     usecases.LogoutUseCaseIface
+	RevokeUC     usecases.RevokeUserSessionsUseCaseIface
 }
 
-func NewAuthController(dummy usecases.DummyLoginUseCaseIface, reg usecases.RegisterUseCaseIface, login usecases.LoginUseCaseIface) *AuthController {
+func NewAuthController(dummy usecases.DummyLoginUseCaseIface, reg usecases.RegisterUseCaseIface, login usecases.LoginUseCaseIface, refresh usecases.RefreshTokenUseCaseIface, logout usecases.LogoutUseCaseIface, revoke usecases.RevokeUserSessionsUseCaseIface) *AuthController {
 	return &AuthController{
 		DummyLoginUC: dummy,
 		RegisterUC:   reg,
 		LoginUC:      login,
+		RefreshUC:    refresh,
+		LogoutUC:     logout,
+		RevokeUC:     revoke,
 	}
 }
 
 // POST /dummyLogin {"role": "moderator"}
 func (c *AuthController) DummyLogin(ctx *gin.Context) {
-	var req struct {
-		Role entities.UserRole `json:"role"`
-	}
+	var req api.PostDummyLoginJSONRequestBody
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": "bad request"})
+		abortWithError(ctx, errBadRequest)
 		return
 	}
-	token, err := c.DummyLoginUC.Execute(ctx.Request.Context(), req.Role)
+	token, err := c.DummyLoginUC.Execute(ctx.Request.Context(), entities.UserRole(req.Role))
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"message": err.Error()})
+		abortWithError(ctx, err)
 		return
 	}
-	ctx.JSON(http.StatusOK, gin.H{"token": token})
+	ctx.JSON(http.StatusOK, interfaces.ToTokenResponseDTO(token))
 }
 
 // POST /register {"email":..., "password":..., "role":...}
 func (c *AuthController) Register(ctx *gin.Context) {
-	var req struct {
-		Email    string            `json:"email"`
-		Password string            `json:"password"`
-		Role     entities.UserRole `json:"role"`
-	}
+	var req api.PostRegisterJSONRequestBody
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": "bad request"})
+		abortWithError(ctx, errBadRequest)
 		return
 	}
-	user, err := c.RegisterUC.Execute(ctx.Request.Context(), req.Email, req.Password, req.Role)
+	user, err := c.RegisterUC.Execute(ctx.Request.Context(), string(req.Email), req.Password, entities.UserRole(req.Role))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		abortWithError(ctx, err)
 		return
 	}
-	ctx.JSON(http.StatusCreated, user)
+	ctx.JSON(http.StatusCreated, interfaces.ToUserDTO(user))
 }
 
 // POST /login {"email":..., "password":...}
 func (c *AuthController) Login(ctx *gin.Context) {
-	var req struct {
-		Email    string `json:"email"`
-		Password string `json:"password"`
+	var req api.PostLoginJSONRequestBody
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		abortWithError(ctx, errBadRequest)
+		return
 	}
+	token, err := c.LoginUC.Execute(ctx.Request.Context(), string(req.Email), req.Password)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, interfaces.ToTokenResponseDTO(token))
+}
+
+// POST /token/refresh {"refreshToken": "..."}
+func (c *AuthController) Refresh(ctx *gin.Context) {
+	var req api.PostTokenRefreshJSONRequestBody
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": "bad request"})
+		abortWithError(ctx, errBadRequest)
 		return
 	}
-	token, err := c.LoginUC.Execute(ctx.Request.Context(), req.Email, req.Password)
+	token, err := c.RefreshUC.Execute(ctx.Request.Context(), req.RefreshToken)
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"message": err.Error()})
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, interfaces.ToTokenResponseDTO(token))
+}
+
+// POST /logout — завершает сессию, к которой относится токен запроса
+func (c *AuthController) Logout(ctx *gin.Context) {
+	sessionID := ctx.MustGet("session_id").(uuid.UUID)
+	if err := c.LogoutUC.Execute(ctx.Request.Context(), sessionID); err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// POST /users/:userId/revoke_sessions — завершает все сессии пользователя (только для модераторов)
+func (c *AuthController) RevokeUserSessions(ctx *gin.Context, userID uuid.UUID) {
+	user := ctx.MustGet("user").(entities.User)
+	if err := c.RevokeUC.Execute(ctx.Request.Context(), user, userID); err != nil {
+		abortWithError(ctx, err)
 		return
 	}
-	ctx.JSON(http.StatusOK, gin.H{"token": token})
+	ctx.Status(http.StatusNoContent)
 }
@@ -0,0 +1,65 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/api"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+)
+
+type CatalogController struct {
+	ListUC   usecases.ListCatalogUseCaseIface
+	AddUC    usecases.AddCatalogEntryUseCaseIface
+	UpdateUC usecases.UpdateCatalogEntryUseCaseIface
+}
+
+func NewCatalogController(list usecases.ListCatalogUseCaseIface, add usecases.AddCatalogEntryUseCaseIface, update usecases.UpdateCatalogEntryUseCaseIface) *CatalogController {
+	return &CatalogController{ListUC: list, AddUC: add, UpdateUC: update}
+}
+
+// GET /catalogs/:kind
+func (c *CatalogController) List(ctx *gin.Context, kind api.CatalogKind) {
+	user := ctx.MustGet("user").(entities.User)
+	entries, err := c.ListUC.Execute(ctx.Request.Context(), user, entities.CatalogKind(kind))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, interfaces.ToCatalogEntryDTOs(entries))
+}
+
+// POST /catalogs/:kind {"name": "Новосибирск"}
+func (c *CatalogController) Add(ctx *gin.Context, kind api.CatalogKind) {
+	user := ctx.MustGet("user").(entities.User)
+	var req api.PostCatalogsKindJSONRequestBody
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		abortWithError(ctx, errBadRequest)
+		return
+	}
+	entry, err := c.AddUC.Execute(ctx.Request.Context(), user, entities.CatalogKind(kind), req.Name)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, interfaces.ToCatalogEntryDTO(entry))
+}
+
+// PATCH /catalogs/:kind/:id {"name": "...", "active": false}
+func (c *CatalogController) Update(ctx *gin.Context, kind api.CatalogKind, id int64) {
+	user := ctx.MustGet("user").(entities.User)
+	var req api.PatchCatalogsKindIdJSONRequestBody
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		abortWithError(ctx, errBadRequest)
+		return
+	}
+	patch := usecases.CatalogEntryPatch{Name: req.Name, Active: req.Active}
+	entry, err := c.UpdateUC.Execute(ctx.Request.Context(), user, entities.CatalogKind(kind), id, patch)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, interfaces.ToCatalogEntryDTO(entry))
+}
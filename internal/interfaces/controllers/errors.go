@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/api"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+)
+
+// Ошибки транспортного уровня (разбор запроса, авторизация)
+var (
+	errBadRequest    = usecases.NewError(usecases.ErrValidation, "bad request")
+	errUnauthorized  = usecases.NewError(usecases.ErrUnauthorized, "unauthorized")
+	errMissingToken  = usecases.NewError(usecases.ErrUnauthorized, "missing or invalid Authorization header")
+	errBadPagination = usecases.NewError(usecases.ErrValidation, "bad pagination params")
+)
+
+// errorMapping — категория ошибки usecase -> HTTP-статус и стабильный code
+var errorMapping = []struct {
+	kind   error
+	status int
+	code   api.ErrorCode
+}{
+	{usecases.ErrValidation, http.StatusBadRequest, api.ErrorCodeValidationError},
+	{usecases.ErrUnauthorized, http.StatusUnauthorized, api.ErrorCodeUnauthorized},
+	{usecases.ErrForbidden, http.StatusForbidden, api.ErrorCodeForbidden},
+	{usecases.ErrNotFound, http.StatusNotFound, api.ErrorCodeNotFound},
+	{usecases.ErrConflict, http.StatusConflict, api.ErrorCodeConflict},
+	{usecases.ErrNoOpenReception, http.StatusBadRequest, api.ErrorCodeNoOpenReception},
+}
+
+// MapError преобразует ошибку в HTTP-статус и тело ответа.
+// Неизвестные ошибки (БД и пр.) — 500 без деталей, чтобы не светить внутренности
+func MapError(err error) (int, api.Error) {
+	for _, m := range errorMapping {
+		if errors.Is(err, m.kind) {
+			return m.status, api.Error{Code: m.code, Message: err.Error()}
+		}
+	}
+	return http.StatusInternalServerError, api.Error{Code: api.ErrorCodeInternalError, Message: "internal error"}
+}
+
+// ErrorHandlerMiddleware — единый обработчик ошибок HTTP API.
+// Контроллеры кладут ошибку через ctx.Error и выходят, ответ формирует middleware
+func ErrorHandlerMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Next()
+		if len(ctx.Errors) == 0 || ctx.Writer.Written() {
+			return
+		}
+		err := ctx.Errors.Last().Err
+		status, body := MapError(err)
+		if status >= http.StatusInternalServerError {
+			logger.FromContext(ctx.Request.Context()).Error("request failed", slog.Any("error", err))
+		}
+		ctx.AbortWithStatusJSON(status, body)
+	}
+}
+
+// abortWithError передаёт ошибку в ErrorHandlerMiddleware и прерывает цепочку
+func abortWithError(ctx *gin.Context, err error) {
+	_ = ctx.Error(err)
+	ctx.Abort()
+}
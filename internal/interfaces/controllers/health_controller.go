@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+)
+
+// HealthController — пробы для оркестратора. Вне swagger.yaml: это не часть бизнес-API
+type HealthController struct {
+	ReadinessUC usecases.CheckReadinessUseCaseIface
+}
+
+func NewHealthController(readiness usecases.CheckReadinessUseCaseIface) *HealthController {
+	return &HealthController{ReadinessUC: readiness}
+}
+
+// RegisterHealthRoutes регистрирует /health/live, /health/ready и /ping (старый алиас live)
+func RegisterHealthRoutes(router gin.IRouter, c *HealthController) {
+	router.GET("/health/live", c.Live)
+	router.GET("/health/ready", c.Ready)
+	router.GET("/ping", func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, gin.H{"message": "pong"})
+	})
+}
+
+// GET /health/live — процесс жив и обслуживает HTTP, зависимости не проверяются
+func (c *HealthController) Live(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// GET /health/ready — 200, если БД доступна и схема применена; 503 иначе и во время остановки.
+// Тексты ошибок не отдаются (там адреса и имена), они в логе usecase
+func (c *HealthController) Ready(ctx *gin.Context) {
+	res := c.ReadinessUC.Execute(ctx.Request.Context())
+	checks := gin.H{"database": "ok"}
+	if res.DatabaseErr != nil {
+		checks["database"] = "unavailable"
+	}
+	switch {
+	case res.MigrationErr != nil:
+		checks["migrations"] = "unknown"
+	case res.Migrations != nil:
+		checks["migrations"] = res.Migrations
+	}
+
+	status, code := "ok", http.StatusOK
+	switch {
+	case res.ShuttingDown:
+		status, code = "shutting_down", http.StatusServiceUnavailable
+	case !res.Ready:
+		status, code = "unavailable", http.StatusServiceUnavailable
+	}
+	ctx.JSON(code, gin.H{"status": status, "checks": checks})
+}
@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/configs"
+)
+
+// JWK — открытый ключ в формате RFC 7517: RSA (n, e) или Ed25519 (crv, x)
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKSController отдаёт открытые ключи подписи, чтобы другие сервисы проверяли наши токены
+// без секрета. Вне swagger.yaml: стандартный путь, формат задаёт RFC 7517
+type JWKSController struct {
+	keys []JWK
+}
+
+// NewJWKSController собирает набор ключей один раз: ключи читаются только при старте.
+// HS256-ключи не публикуются
+func NewJWKSController(keys []configs.JWTKey) *JWKSController {
+	jwks := make([]JWK, 0, len(keys))
+	for _, key := range keys {
+		if jwk, ok := toJWK(key); ok {
+			jwks = append(jwks, jwk)
+		}
+	}
+	return &JWKSController{keys: jwks}
+}
+
+// RegisterJWKSRoutes регистрирует /.well-known/jwks.json
+func RegisterJWKSRoutes(router gin.IRouter, c *JWKSController) {
+	router.GET("/.well-known/jwks.json", c.JWKS)
+}
+
+// GET /.well-known/jwks.json — открытые ключи RS256/EdDSA, включая выводимые из ротации
+func (c *JWKSController) JWKS(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, gin.H{"keys": c.keys})
+}
+
+func toJWK(key configs.JWTKey) (JWK, bool) {
+	b64 := base64.RawURLEncoding.EncodeToString
+	switch pub := key.Verify.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA", Kid: key.KID, Alg: key.Alg, Use: "sig",
+			N: b64(pub.N.Bytes()),
+			E: b64(big.NewInt(int64(pub.E)).Bytes()),
+		}, true
+	case ed25519.PublicKey:
+		return JWK{Kty: "OKP", Kid: key.KID, Alg: key.Alg, Use: "sig", Crv: "Ed25519", X: b64(pub)}, true
+	}
+	return JWK{}, false
+}
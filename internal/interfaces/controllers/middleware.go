@@ -1,39 +1,156 @@
 package controllers
 
 import (
-	"net/http"
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/configs"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
 )
 
-func JWTAuthMiddleware(secret string) gin.HandlerFunc {
+// ErrInvalidToken возвращается, если токен не прошёл проверку
+var ErrInvalidToken = errors.New("invalid token")
+
+// ErrInvalidClaims возвращается, если в токене нет нужных claims
+var ErrInvalidClaims = errors.New("invalid claims")
+
+// ErrUnknownKey возвращается, если kid токена не найден среди ключей подписи
+var ErrUnknownKey = errors.New("unknown signing key")
+
+// SessionChecker проверяет, что сессия access-токена не отозвана (реализация — PGSessionRepository)
+type SessionChecker interface {
+	IsSessionActive(ctx context.Context, sessionID uuid.UUID) (bool, error)
+}
+
+// TokenVerifier проверяет access-токены: ключ по kid и его алгоритм, подпись, срок, iss и aud,
+// затем — что сессия из claim sid не отозвана. Используется и HTTP-middleware, и gRPC-интерсепторами
+type TokenVerifier struct {
+	keys     map[string]configs.JWTKey
+	parser   *jwt.Parser
+	sessions SessionChecker
+}
+
+// NewTokenVerifier создаёт TokenVerifier с ключами, iss и aud из конфига и хранилищем сессий.
+// Принимаются токены, подписанные любым из ключей: при ротации старый ключ остаётся в JWT_KEYS
+// или JWT_PUBLIC_KEY_FILES, пока не истекут выданные им токены
+func NewTokenVerifier(cfg *configs.Config, sessions SessionChecker) *TokenVerifier {
+	keys, _ := cfg.SigningKeys()
+	algs := map[string]bool{}
+	for _, key := range keys {
+		algs[key.Alg] = true
+	}
+	methods := make([]string, 0, len(algs))
+	for alg := range algs {
+		methods = append(methods, alg)
+	}
+	sort.Strings(methods)
+	return &TokenVerifier{
+		keys: keys,
+		parser: jwt.NewParser(
+			jwt.WithValidMethods(methods),
+			jwt.WithIssuer(cfg.JWTIssuer),
+			jwt.WithAudience(cfg.JWTAudience),
+			jwt.WithExpirationRequired(),
+			jwt.WithIssuedAt(),
+		),
+		sessions: sessions,
+	}
+}
+
+// Verify возвращает пользователя и id сессии из токена. Непринятый токен и отозванная сессия —
+// ошибки категории usecases.ErrUnauthorized, остальные ошибки — сбой хранилища сессий
+func (v *TokenVerifier) Verify(ctx context.Context, tokenStr string) (entities.User, uuid.UUID, error) {
+	user, sessionID, err := v.parse(tokenStr)
+	if err != nil {
+		return entities.User{}, uuid.Nil, usecases.NewError(usecases.ErrUnauthorized, err.Error())
+	}
+	active, err := v.sessions.IsSessionActive(ctx, sessionID)
+	if err != nil {
+		return entities.User{}, uuid.Nil, err
+	}
+	if !active {
+		return entities.User{}, uuid.Nil, usecases.ErrSessionRevoked
+	}
+	return user, sessionID, nil
+}
+
+// key выбирает ключ проверки по заголовку kid. Алгоритм токена должен совпадать с алгоритмом ключа:
+// иначе токен HS256 можно было бы подписать открытым RSA-ключом как секретом
+func (v *TokenVerifier) key(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := v.keys[kid]
+	if !ok || token.Method.Alg() != key.Alg {
+		return nil, ErrUnknownKey
+	}
+	return key.Verify, nil
+}
+
+// parse проверяет JWT и собирает из claims пользователя и id сессии.
+// sub, sid и role обязательны: без них токен не принимается
+func (v *TokenVerifier) parse(tokenStr string) (entities.User, uuid.UUID, error) {
+	var claims usecases.AccessClaims
+	if _, err := v.parser.ParseWithClaims(tokenStr, &claims, v.key); err != nil {
+		return entities.User{}, uuid.Nil, ErrInvalidToken
+	}
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return entities.User{}, uuid.Nil, ErrInvalidClaims
+	}
+	sessionID, err := uuid.Parse(claims.SessionID)
+	if err != nil {
+		return entities.User{}, uuid.Nil, ErrInvalidClaims
+	}
+	if !entities.ValidateUserRole(claims.Role) {
+		return entities.User{}, uuid.Nil, ErrInvalidClaims
+	}
+	return entities.User{
+		ID:    userID,
+		Email: claims.Email,
+		Role:  claims.Role,
+	}, sessionID, nil
+}
+
+// SecuredRoutesAuthMiddleware проверяет JWT и право на операцию (см. routePermissions) для всех операций,
+// кроме открытых (publicRoutes). Подключается к группе gin, поэтому срабатывает раньше разбора параметров
+// сгенерированной обёрткой: запрос без токена получает 401, а не 400 за неверный параметр
+func SecuredRoutesAuthMiddleware(verifier *TokenVerifier, authz *usecases.Authorizer) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		h := ctx.GetHeader("Authorization")
-		if h == "" || !strings.HasPrefix(h, "Bearer ") {
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "missing or invalid Authorization header"})
+		if publicRoutes[ctx.Request.Method+" "+ctx.FullPath()] {
+			ctx.Next()
 			return
 		}
-		tokenStr := strings.TrimPrefix(h, "Bearer ")
-		token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-			return []byte(secret), nil
-		})
-		if err != nil || !token.Valid {
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "invalid token"})
+		if !authenticate(ctx, verifier) || !authorizeRoute(ctx, authz) {
 			return
 		}
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "invalid claims"})
-			return
-		}
-		user := entities.User{
-			Email: claims["email"].(string),
-			Role:  entities.UserRole(claims["role"].(string)),
-		}
-		ctx.Set("user", user)
 		ctx.Next()
 	}
 }
+
+// authenticate кладёт пользователя и id сессии из токена в контекст или прерывает запрос с 401
+func authenticate(ctx *gin.Context, verifier *TokenVerifier) bool {
+	h := ctx.GetHeader("Authorization")
+	if h == "" || !strings.HasPrefix(h, "Bearer ") {
+		abortWithError(ctx, errMissingToken)
+		return false
+	}
+	tokenStr := strings.TrimPrefix(h, "Bearer ")
+	user, sessionID, err := verifier.Verify(ctx.Request.Context(), tokenStr)
+	if err != nil {
+		if errors.Is(err, usecases.ErrUnauthorized) {
+			logger.FromContext(ctx.Request.Context()).Warn("token rejected", slog.Any("error", err))
+		}
+		abortWithError(ctx, err)
+		return false
+	}
+	ctx.Set("user", user)
+	ctx.Set("session_id", sessionID)
+	return true
+}
@@ -4,39 +4,73 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/api"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
 )
 
 type ProductController struct {
-	AddUC *usecases.AddProductUseCase
+	AddUC   usecases.AddProductUseCaseIface
+	FindUC  usecases.FindProductsByBarcodeUseCaseIface
+	BatchUC usecases.AddProductsBatchUseCaseIface
 }
 
-func NewProductController(add *usecases.AddProductUseCase) *ProductController {
-	return &ProductController{AddUC: add}
+func NewProductController(add usecases.AddProductUseCaseIface, find usecases.FindProductsByBarcodeUseCaseIface, batch usecases.AddProductsBatchUseCaseIface) *ProductController {
+	return &ProductController{AddUC: add, FindUC: find, BatchUC: batch}
 }
 
-// POST /products {"pvzId": "...", "type": "электроника"}
+// POST /products {"pvzId": "...", "type": "электроника", "barcode": "4601234567893"}
 func (c *ProductController) Add(ctx *gin.Context) {
 	user := ctx.MustGet("user").(entities.User)
-	var req struct {
-		PVZID string               `json:"pvzId"`
-		Type  entities.ProductType `json:"type"`
+	var req api.PostProductsJSONRequestBody
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		abortWithError(ctx, errBadRequest)
+		return
 	}
+	var barcode string
+	if req.Barcode != nil {
+		barcode = *req.Barcode
+	}
+	product, err := c.AddUC.Execute(ctx.Request.Context(), user, req.PvzId, entities.ProductType(req.Type), barcode)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, interfaces.ToProductDTO(product))
+}
+
+// POST /products/batch {"pvzId": "...", "products": [{"type": "обувь", "barcode": "4601234567893"}, ...]}
+func (c *ProductController) AddBatch(ctx *gin.Context) {
+	user := ctx.MustGet("user").(entities.User)
+	var req api.PostProductsBatchJSONRequestBody
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": "bad request"})
+		abortWithError(ctx, errBadRequest)
 		return
 	}
-	pvzID, err := uuid.Parse(req.PVZID)
+	items := make([]usecases.ProductBatchItem, 0, len(req.Products))
+	for _, p := range req.Products {
+		item := usecases.ProductBatchItem{Type: entities.ProductType(p.Type)}
+		if p.Barcode != nil {
+			item.Barcode = *p.Barcode
+		}
+		items = append(items, item)
+	}
+	results, err := c.BatchUC.Execute(ctx.Request.Context(), user, req.PvzId, items)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": "bad pvzId"})
+		abortWithError(ctx, err)
 		return
 	}
-	product, err := c.AddUC.Execute(ctx, user, pvzID, req.Type)
+	ctx.JSON(http.StatusOK, interfaces.ToProductBatchResultDTO(results))
+}
+
+// GET /products?barcode=4601234567893
+func (c *ProductController) FindByBarcode(ctx *gin.Context, params api.GetProductsParams) {
+	user := ctx.MustGet("user").(entities.User)
+	locations, err := c.FindUC.Execute(ctx.Request.Context(), user, params.Barcode)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		abortWithError(ctx, err)
 		return
 	}
-	ctx.JSON(http.StatusCreated, product)
+	ctx.JSON(http.StatusOK, interfaces.ToProductLocationDTOs(locations))
 }
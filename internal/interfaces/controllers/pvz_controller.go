@@ -1,171 +1,233 @@
 package controllers
 
 import (
-	"fmt"
 	"net/http"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/api"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
 )
 
+// Ограничения пагинации из swagger.yaml (GET /pvz)
+const (
+	defaultPage  = 1
+	defaultLimit = 10
+	maxLimit     = 30
+)
+
+// pagination подставляет значения по умолчанию; ok=false, если page или limit вне диапазона
+func pagination(pageParam, limitParam *int) (page, limit int, ok bool) {
+	page, limit = defaultPage, defaultLimit
+	if pageParam != nil {
+		page = *pageParam
+	}
+	if limitParam != nil {
+		limit = *limitParam
+	}
+	return page, limit, page >= 1 && limit >= 1 && limit <= maxLimit
+}
+
+// Радиус поиска по умолчанию для GET /pvz/nearest, в метрах
+const defaultNearestRadius = 5000
+
 type PVZController struct {
 	CreateUC     usecases.CreatePVZUseCaseIface
 	ListUC       usecases.ListPVZsUseCaseIface
 	CloseUC      usecases.CloseReceptionUseCaseIface
 	DeleteLastUC usecases.DeleteLastProductUseCaseIface
+	GetUC        usecases.GetPVZUseCaseIface
+	UpdateUC     usecases.UpdatePVZUseCaseIface
+	ArchiveUC    usecases.ArchivePVZUseCaseIface
+	NearestUC    usecases.FindNearestPVZsUseCaseIface
+	AssignUC     usecases.AssignStaffUseCaseIface
+	ListStaffUC  usecases.ListPVZStaffUseCaseIface
 }
 
-func NewPVZController(create usecases.CreatePVZUseCaseIface, list usecases.ListPVZsUseCaseIface, closeUC usecases.CloseReceptionUseCaseIface, delUC usecases.DeleteLastProductUseCaseIface) *PVZController {
+func NewPVZController(create usecases.CreatePVZUseCaseIface, list usecases.ListPVZsUseCaseIface, closeUC usecases.CloseReceptionUseCaseIface, delUC usecases.DeleteLastProductUseCaseIface, get usecases.GetPVZUseCaseIface, update usecases.UpdatePVZUseCaseIface, archive usecases.ArchivePVZUseCaseIface, nearest usecases.FindNearestPVZsUseCaseIface, assign usecases.AssignStaffUseCaseIface, listStaff usecases.ListPVZStaffUseCaseIface) *PVZController {
 	return &PVZController{
 		CreateUC:     create,
 		ListUC:       list,
 		CloseUC:      closeUC,
 		DeleteLastUC: delUC,
+		GetUC:        get,
+		UpdateUC:     update,
+		ArchiveUC:    archive,
+		NearestUC:    nearest,
+		AssignUC:     assign,
+		ListStaffUC:  listStaff,
 	}
 }
 
-// POST /pvz {"city": "Москва"}
+// POST /pvz {"city": "Москва", "name": "...", "address": "...", "workingHours": "...", "location": {"lat": 55.75, "lon": 37.61}}
 func (c *PVZController) Create(ctx *gin.Context) {
 	userVal, ok := ctx.Get("user")
 	if !ok {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+		abortWithError(ctx, errUnauthorized)
 		return
 	}
 	user := userVal.(entities.User)
-	var req struct {
-		City entities.City `json:"city"`
-	}
+	var req api.PostPvzJSONRequestBody
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": "bad request"})
+		abortWithError(ctx, errBadRequest)
 		return
 	}
-	pvz, err := c.CreateUC.Execute(ctx.Request.Context(), user, req.City)
+	pvz, err := c.CreateUC.Execute(ctx.Request.Context(), user, entities.City(req.City), interfaces.PVZDetailsFromDTO(req))
 	if err != nil {
-		ctx.JSON(http.StatusForbidden, gin.H{"message": err.Error()})
+		abortWithError(ctx, err)
 		return
 	}
-	ctx.JSON(http.StatusCreated, pvz)
+	ctx.JSON(http.StatusCreated, interfaces.ToPVZDTO(pvz))
 }
 
-// GET /pvz?start=...&end=...&page=1&limit=10
-func (c *PVZController) List(ctx *gin.Context) {
+// GET /pvz?startDate=...&endDate=...&page=1&limit=10&includeArchived=false
+func (c *PVZController) List(ctx *gin.Context, params api.GetPvzParams) {
 	userVal, ok := ctx.Get("user")
 	if !ok {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+		abortWithError(ctx, errUnauthorized)
 		return
 	}
 	user := userVal.(entities.User)
-	var start, end *time.Time
-	if s := ctx.Query("start"); s != "" {
-		t, err := time.Parse(time.RFC3339, s)
-		if err == nil {
-			start = &t
-		}
-	}
-	if e := ctx.Query("end"); e != "" {
-		t, err := time.Parse(time.RFC3339, e)
-		if err == nil {
-			end = &t
-		}
-	}
-	page := 1
-	limit := 10
-	if p := ctx.Query("page"); p != "" {
-		fmt.Sscanf(p, "%d", &page)
-	}
-	if l := ctx.Query("limit"); l != "" {
-		fmt.Sscanf(l, "%d", &limit)
+	page, limit, ok := pagination(params.Page, params.Limit)
+	if !ok {
+		abortWithError(ctx, errBadPagination)
+		return
 	}
 
 	// --- агрегирующий usecase ---
-	pvzs, err := c.ListUC.Execute(ctx.Request.Context(), user, start, end, page, limit)
+	includeArchived := params.IncludeArchived != nil && *params.IncludeArchived
+	pvzs, err := c.ListUC.Execute(ctx.Request.Context(), user, params.StartDate, params.EndDate, page, limit, includeArchived)
 	if err != nil {
-		ctx.JSON(http.StatusForbidden, gin.H{"message": err.Error()})
+		abortWithError(ctx, err)
 		return
 	}
 
-	// Используем новый DTO для правильного форматирования ответа
-	var result []interfaces.PVZListResponseItem
-
-	for _, pvz := range pvzs {
-		// Создаем DTO для текущего ПВЗ
-		pvzDTO := interfaces.ToPVZListItemDTO(pvz)
-
-		// Для каждого PVZ — получить все приёмки и продукты
-		receptions, _ := c.ListUC.GetReceptionsByPVZ(ctx.Request.Context(), pvz.ID) // предполагается, что usecase расширен
-		var recs []struct {
-			Reception interfaces.ReceptionListItemDTO `json:"reception"`
-			Products  []interfaces.ProductDTO         `json:"products"`
-		}
+	ctx.JSON(http.StatusOK, interfaces.ToPVZWithReceptionsDTOs(pvzs))
+}
 
-		for _, rec := range receptions {
-			// Преобразуем Reception в ReceptionListItemDTO, исключая поле Products
-			recDTO := interfaces.ToReceptionListItemDTO(rec)
+// GET /pvz/:pvzId
+func (c *PVZController) Get(ctx *gin.Context, pvzID uuid.UUID) {
+	user := ctx.MustGet("user").(entities.User)
+	pvz, err := c.GetUC.Execute(ctx.Request.Context(), user, pvzID)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, interfaces.ToPVZDTO(pvz))
+}
 
-			products, _ := c.ListUC.GetProductsByReception(ctx.Request.Context(), rec.ID)
+// PATCH /pvz/:pvzId {"city": "Казань", "address": "..."} — только переданные поля
+func (c *PVZController) Update(ctx *gin.Context, pvzID uuid.UUID) {
+	user := ctx.MustGet("user").(entities.User)
+	var req api.PatchPvzPvzIdJSONRequestBody
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		abortWithError(ctx, errBadRequest)
+		return
+	}
+	patch := usecases.PVZPatch{
+		Name:         req.Name,
+		Address:      req.Address,
+		WorkingHours: req.WorkingHours,
+		Location:     interfaces.GeoPointFromDTO(req.Location),
+	}
+	if req.City != nil {
+		city := entities.City(*req.City)
+		patch.City = &city
+	}
+	pvz, err := c.UpdateUC.Execute(ctx.Request.Context(), user, pvzID, patch)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, interfaces.ToPVZDTO(pvz))
+}
 
-			// Конвертируем доменные модели продуктов в DTO
-			var productDTOs []interfaces.ProductDTO
-			for _, product := range products {
-				productDTOs = append(productDTOs, interfaces.ToProductDTO(product))
-			}
+// POST /pvz/:pvzId/archive и /pvz/:pvzId/unarchive
+func (c *PVZController) SetArchived(ctx *gin.Context, pvzID uuid.UUID, archived bool) {
+	user := ctx.MustGet("user").(entities.User)
+	pvz, err := c.ArchiveUC.Execute(ctx.Request.Context(), user, pvzID, archived)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, interfaces.ToPVZDTO(pvz))
+}
 
-			recs = append(recs, struct {
-				Reception interfaces.ReceptionListItemDTO `json:"reception"`
-				Products  []interfaces.ProductDTO         `json:"products"`
-			}{Reception: recDTO, Products: productDTOs})
-		}
+// GET /pvz/:pvzId/staff
+func (c *PVZController) ListStaff(ctx *gin.Context, pvzID uuid.UUID) {
+	user := ctx.MustGet("user").(entities.User)
+	staff, err := c.ListStaffUC.Execute(ctx.Request.Context(), user, pvzID)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, interfaces.ToStaffAssignmentDTOs(staff))
+}
 
-		result = append(result, interfaces.PVZListResponseItem{
-			PVZ:        pvzDTO,
-			Receptions: recs,
-		})
+// PUT и DELETE /pvz/:pvzId/staff/:userId
+func (c *PVZController) AssignStaff(ctx *gin.Context, pvzID, userID uuid.UUID, assigned bool) {
+	user := ctx.MustGet("user").(entities.User)
+	if err := c.AssignUC.Execute(ctx.Request.Context(), user, pvzID, userID, assigned); err != nil {
+		abortWithError(ctx, err)
+		return
 	}
-	ctx.JSON(http.StatusOK, result)
+	ctx.Status(http.StatusNoContent)
 }
 
 // POST /pvz/:pvzId/close_last_reception
-func (c *PVZController) CloseLastReception(ctx *gin.Context) {
+func (c *PVZController) CloseLastReception(ctx *gin.Context, pvzID uuid.UUID) {
 	userVal, ok := ctx.Get("user")
 	if !ok {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+		abortWithError(ctx, errUnauthorized)
 		return
 	}
 	user := userVal.(entities.User)
-	pvzID, err := uuid.Parse(ctx.Param("pvzId"))
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": "bad pvzId"})
-		return
-	}
 	rec, err := c.CloseUC.Execute(ctx.Request.Context(), user, pvzID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		abortWithError(ctx, err)
 		return
 	}
-	ctx.JSON(http.StatusOK, rec)
+	ctx.JSON(http.StatusOK, interfaces.ToReceptionDTO(rec))
 }
 
 // POST /pvz/:pvzId/delete_last_product
-func (c *PVZController) DeleteLastProduct(ctx *gin.Context) {
+func (c *PVZController) DeleteLastProduct(ctx *gin.Context, pvzID uuid.UUID) {
 	userVal, ok := ctx.Get("user")
 	if !ok {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+		abortWithError(ctx, errUnauthorized)
 		return
 	}
 	user := userVal.(entities.User)
-	pvzID, err := uuid.Parse(ctx.Param("pvzId"))
+	err := c.DeleteLastUC.Execute(ctx.Request.Context(), user, pvzID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": "bad pvzId"})
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"ok": true})
+}
+
+// GET /pvz/nearest?lat=55.75&lon=37.61&radius=5000&limit=10
+func (c *PVZController) Nearest(ctx *gin.Context, params api.GetPvzNearestParams) {
+	user := ctx.MustGet("user").(entities.User)
+	radius := float64(defaultNearestRadius)
+	if params.Radius != nil {
+		radius = *params.Radius
+	}
+	limit := defaultLimit
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if limit < 1 || limit > maxLimit {
+		abortWithError(ctx, errBadPagination)
 		return
 	}
-	err = c.DeleteLastUC.Execute(ctx.Request.Context(), user, pvzID)
+	point := entities.GeoPoint{Lat: params.Lat, Lon: params.Lon}
+	nearest, err := c.NearestUC.Execute(ctx.Request.Context(), user, point, radius, limit)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		abortWithError(ctx, err)
 		return
 	}
-	ctx.JSON(http.StatusOK, gin.H{"ok": true})
+	ctx.JSON(http.StatusOK, interfaces.ToNearestPVZDTOs(nearest))
 }
@@ -6,36 +6,137 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/api"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
 )
 
 type ReceptionController struct {
-	CreateUC *usecases.CreateReceptionUseCase
+	CreateUC  usecases.CreateReceptionUseCaseIface
+	GetUC     usecases.GetReceptionUseCaseIface
+	ListUC    usecases.ListReceptionsUseCaseIface
+	DeleteUC  usecases.DeleteProductUseCaseIface
+	ReopenUC  usecases.ReopenReceptionUseCaseIface
+	HistoryUC usecases.ListReceptionTransitionsUseCaseIface
+	StatusUC  usecases.ChangeReceptionStatusUseCaseIface
 }
 
-func NewReceptionController(create *usecases.CreateReceptionUseCase) *ReceptionController {
-	return &ReceptionController{CreateUC: create}
+func NewReceptionController(create usecases.CreateReceptionUseCaseIface, get usecases.GetReceptionUseCaseIface, list usecases.ListReceptionsUseCaseIface, deleteProduct usecases.DeleteProductUseCaseIface, reopen usecases.ReopenReceptionUseCaseIface, history usecases.ListReceptionTransitionsUseCaseIface, status usecases.ChangeReceptionStatusUseCaseIface) *ReceptionController {
+	return &ReceptionController{CreateUC: create, GetUC: get, ListUC: list, DeleteUC: deleteProduct, ReopenUC: reopen, HistoryUC: history, StatusUC: status}
 }
 
 // POST /receptions {"pvzId": "..."}
 func (c *ReceptionController) Create(ctx *gin.Context) {
 	user := ctx.MustGet("user").(entities.User)
-	var req struct {
-		PVZID string `json:"pvzId"`
+	var req api.PostReceptionsJSONRequestBody
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		abortWithError(ctx, errBadRequest)
+		return
+	}
+	rec, err := c.CreateUC.Execute(ctx.Request.Context(), user, req.PvzId)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, interfaces.ToReceptionDTO(rec))
+}
+
+// GET /receptions/:receptionId
+func (c *ReceptionController) Get(ctx *gin.Context, receptionID uuid.UUID) {
+	user := ctx.MustGet("user").(entities.User)
+	rec, err := c.GetUC.Execute(ctx.Request.Context(), user, receptionID)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, interfaces.ToReceptionWithProductsDTO(rec))
+}
+
+// GET /pvz/:pvzId/receptions?status=close&startDate=...&endDate=...&page=1&limit=10
+func (c *ReceptionController) ListByPVZ(ctx *gin.Context, pvzID uuid.UUID, params api.GetPvzPvzIdReceptionsParams) {
+	user := ctx.MustGet("user").(entities.User)
+	page, limit, ok := pagination(params.Page, params.Limit)
+	if !ok {
+		abortWithError(ctx, errBadPagination)
+		return
+	}
+	filter := usecases.ReceptionFilter{StartDate: params.StartDate, EndDate: params.EndDate, Page: page, Limit: limit}
+	if params.Status != nil {
+		status := entities.ReceptionStatus(*params.Status)
+		filter.Status = &status
+	}
+	recs, err := c.ListUC.Execute(ctx.Request.Context(), user, pvzID, filter)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
 	}
+	ctx.JSON(http.StatusOK, interfaces.ToReceptionDTOs(recs))
+}
+
+// DELETE /receptions/:receptionId/products/:productId
+func (c *ReceptionController) DeleteProduct(ctx *gin.Context, receptionID, productID uuid.UUID) {
+	user := ctx.MustGet("user").(entities.User)
+	removal, err := c.DeleteUC.Execute(ctx.Request.Context(), user, receptionID, productID)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, interfaces.ToProductRemovalDTO(removal))
+}
+
+// POST /receptions/:receptionId/reopen {"reason": "..."}
+func (c *ReceptionController) Reopen(ctx *gin.Context, receptionID uuid.UUID) {
+	user := ctx.MustGet("user").(entities.User)
+	var req api.PostReceptionsReceptionIdReopenJSONRequestBody
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "bad request"})
+		abortWithError(ctx, errBadRequest)
+		return
+	}
+	rec, err := c.ReopenUC.Execute(ctx.Request.Context(), user, receptionID, req.Reason)
+	if err != nil {
+		abortWithError(ctx, err)
 		return
 	}
-	pvzID, err := uuid.Parse(req.PVZID)
+	ctx.JSON(http.StatusOK, interfaces.ToReceptionDTO(rec))
+}
+
+// GET /receptions/:receptionId/transitions
+func (c *ReceptionController) Transitions(ctx *gin.Context, receptionID uuid.UUID) {
+	user := ctx.MustGet("user").(entities.User)
+	transitions, err := c.HistoryUC.Execute(ctx.Request.Context(), user, receptionID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "bad pvzId"})
+		abortWithError(ctx, err)
 		return
 	}
-	rec, err := c.CreateUC.Execute(ctx, user, pvzID)
+	ctx.JSON(http.StatusOK, interfaces.ToReceptionTransitionDTOs(transitions))
+}
+
+// POST /receptions/:receptionId/pause
+func (c *ReceptionController) Pause(ctx *gin.Context, receptionID uuid.UUID) {
+	c.changeStatus(ctx, receptionID, entities.ReceptionPaused, "")
+}
+
+// POST /receptions/:receptionId/resume
+func (c *ReceptionController) Resume(ctx *gin.Context, receptionID uuid.UUID) {
+	c.changeStatus(ctx, receptionID, entities.ReceptionInProgress, "")
+}
+
+// POST /receptions/:receptionId/cancel {"reason": "..."}
+func (c *ReceptionController) Cancel(ctx *gin.Context, receptionID uuid.UUID) {
+	var req api.PostReceptionsReceptionIdCancelJSONRequestBody
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		abortWithError(ctx, errBadRequest)
+		return
+	}
+	c.changeStatus(ctx, receptionID, entities.ReceptionCancelled, req.Reason)
+}
+
+func (c *ReceptionController) changeStatus(ctx *gin.Context, receptionID uuid.UUID, to entities.ReceptionStatus, reason string) {
+	user := ctx.MustGet("user").(entities.User)
+	rec, err := c.StatusUC.Execute(ctx.Request.Context(), user, receptionID, to, reason)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		abortWithError(ctx, err)
 		return
 	}
-	ctx.JSON(http.StatusOK, rec)
+	ctx.JSON(http.StatusOK, interfaces.ToReceptionDTO(rec))
 }
@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
+)
+
+// RequestIDHeader — заголовок с идентификатором запроса
+const RequestIDHeader = "X-Request-ID"
+
+// RequestLoggerMiddleware присваивает запросу request_id (берёт из X-Request-ID или генерирует),
+// кладёт логгер с этим id в context.Context запроса и логирует завершение запроса
+func RequestLoggerMiddleware(base *slog.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		requestID := ctx.GetHeader(RequestIDHeader)
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+		ctx.Header(RequestIDHeader, requestID)
+
+		l := base.With(
+			slog.String("request_id", requestID),
+			slog.String("method", ctx.Request.Method),
+			slog.String("path", ctx.Request.URL.Path),
+		)
+		ctx.Request = ctx.Request.WithContext(logger.WithContext(ctx.Request.Context(), l))
+
+		ctx.Next()
+
+		attrs := []any{
+			slog.Int("status", ctx.Writer.Status()),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("client_ip", ctx.ClientIP()),
+		}
+		if userVal, ok := ctx.Get("user"); ok {
+			if user, ok := userVal.(entities.User); ok {
+				attrs = append(attrs, slog.String("user_role", string(user.Role)))
+			}
+		}
+		switch {
+		case ctx.Writer.Status() >= 500:
+			l.Error("request completed", attrs...)
+		case ctx.Writer.Status() >= 400:
+			l.Warn("request completed", attrs...)
+		default:
+			l.Info("request completed", attrs...)
+		}
+	}
+}
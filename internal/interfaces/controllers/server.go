@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/api"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+)
+
+// Server собирает контроллеры в api.ServerInterface, сгенерированный по swagger.yaml
+type Server struct {
+	Auth      *AuthController
+	PVZ       *PVZController
+	Product   *ProductController
+	Reception *ReceptionController
+	Catalog   *CatalogController
+}
+
+var _ api.ServerInterface = (*Server)(nil)
+
+func NewServer(auth *AuthController, pvz *PVZController, product *ProductController, reception *ReceptionController, catalog *CatalogController) *Server {
+	return &Server{
+		Auth:      auth,
+		PVZ:       pvz,
+		Product:   product,
+		Reception: reception,
+		Catalog:   catalog,
+	}
+}
+
+// RegisterRoutes регистрирует все маршруты из swagger.yaml с проверкой JWT и прав для защищённых операций
+// и единым обработчиком ошибок
+func RegisterRoutes(router gin.IRouter, srv *Server, verifier *TokenVerifier, authz *usecases.Authorizer) {
+	router.Use(ErrorHandlerMiddleware())
+	api.RegisterHandlersWithOptions(router.Group("", SecuredRoutesAuthMiddleware(verifier, authz)), srv, api.GinServerOptions{
+		ErrorHandler: ErrorHandler,
+	})
+}
+
+// ErrorHandler передаёт ошибки разбора параметров в ErrorHandlerMiddleware как ошибки валидации
+func ErrorHandler(ctx *gin.Context, err error, _ int) {
+	abortWithError(ctx, usecases.NewError(usecases.ErrValidation, err.Error()))
+}
+
+func (s *Server) PostDummyLogin(ctx *gin.Context) { s.Auth.DummyLogin(ctx) }
+
+func (s *Server) PostRegister(ctx *gin.Context) { s.Auth.Register(ctx) }
+
+func (s *Server) PostLogin(ctx *gin.Context) { s.Auth.Login(ctx) }
+
+func (s *Server) PostTokenRefresh(ctx *gin.Context) { s.Auth.Refresh(ctx) }
+
+func (s *Server) PostLogout(ctx *gin.Context) { s.Auth.Logout(ctx) }
+
+func (s *Server) PostUsersUserIdRevokeSessions(ctx *gin.Context, userID uuid.UUID) {
+	s.Auth.RevokeUserSessions(ctx, userID)
+}
+
+func (s *Server) PostPvz(ctx *gin.Context) { s.PVZ.Create(ctx) }
+
+func (s *Server) GetPvz(ctx *gin.Context, params api.GetPvzParams) { s.PVZ.List(ctx, params) }
+
+func (s *Server) GetPvzNearest(ctx *gin.Context, params api.GetPvzNearestParams) {
+	s.PVZ.Nearest(ctx, params)
+}
+
+func (s *Server) GetPvzPvzId(ctx *gin.Context, pvzID uuid.UUID) { s.PVZ.Get(ctx, pvzID) }
+
+func (s *Server) PatchPvzPvzId(ctx *gin.Context, pvzID uuid.UUID) { s.PVZ.Update(ctx, pvzID) }
+
+func (s *Server) PostPvzPvzIdArchive(ctx *gin.Context, pvzID uuid.UUID) {
+	s.PVZ.SetArchived(ctx, pvzID, true)
+}
+
+func (s *Server) PostPvzPvzIdUnarchive(ctx *gin.Context, pvzID uuid.UUID) {
+	s.PVZ.SetArchived(ctx, pvzID, false)
+}
+
+func (s *Server) GetPvzPvzIdStaff(ctx *gin.Context, pvzID uuid.UUID) { s.PVZ.ListStaff(ctx, pvzID) }
+
+func (s *Server) PutPvzPvzIdStaffUserId(ctx *gin.Context, pvzID, userID uuid.UUID) {
+	s.PVZ.AssignStaff(ctx, pvzID, userID, true)
+}
+
+func (s *Server) DeletePvzPvzIdStaffUserId(ctx *gin.Context, pvzID, userID uuid.UUID) {
+	s.PVZ.AssignStaff(ctx, pvzID, userID, false)
+}
+
+func (s *Server) PostPvzPvzIdCloseLastReception(ctx *gin.Context, pvzID uuid.UUID) {
+	s.PVZ.CloseLastReception(ctx, pvzID)
+}
+
+func (s *Server) PostPvzPvzIdDeleteLastProduct(ctx *gin.Context, pvzID uuid.UUID) {
+	s.PVZ.DeleteLastProduct(ctx, pvzID)
+}
+
+func (s *Server) PostProducts(ctx *gin.Context) { s.Product.Add(ctx) }
+
+func (s *Server) PostProductsBatch(ctx *gin.Context) { s.Product.AddBatch(ctx) }
+
+func (s *Server) GetProducts(ctx *gin.Context, params api.GetProductsParams) {
+	s.Product.FindByBarcode(ctx, params)
+}
+
+func (s *Server) PostReceptions(ctx *gin.Context) { s.Reception.Create(ctx) }
+
+func (s *Server) GetReceptionsReceptionId(ctx *gin.Context, receptionID uuid.UUID) {
+	s.Reception.Get(ctx, receptionID)
+}
+
+func (s *Server) DeleteReceptionsReceptionIdProductsProductId(ctx *gin.Context, receptionID, productID uuid.UUID) {
+	s.Reception.DeleteProduct(ctx, receptionID, productID)
+}
+
+func (s *Server) PostReceptionsReceptionIdPause(ctx *gin.Context, receptionID uuid.UUID) {
+	s.Reception.Pause(ctx, receptionID)
+}
+
+func (s *Server) PostReceptionsReceptionIdResume(ctx *gin.Context, receptionID uuid.UUID) {
+	s.Reception.Resume(ctx, receptionID)
+}
+
+func (s *Server) PostReceptionsReceptionIdCancel(ctx *gin.Context, receptionID uuid.UUID) {
+	s.Reception.Cancel(ctx, receptionID)
+}
+
+func (s *Server) PostReceptionsReceptionIdReopen(ctx *gin.Context, receptionID uuid.UUID) {
+	s.Reception.Reopen(ctx, receptionID)
+}
+
+func (s *Server) GetReceptionsReceptionIdTransitions(ctx *gin.Context, receptionID uuid.UUID) {
+	s.Reception.Transitions(ctx, receptionID)
+}
+
+func (s *Server) GetPvzPvzIdReceptions(ctx *gin.Context, pvzID uuid.UUID, params api.GetPvzPvzIdReceptionsParams) {
+	s.Reception.ListByPVZ(ctx, pvzID, params)
+}
+
+func (s *Server) GetCatalogsKind(ctx *gin.Context, kind api.CatalogKind) { s.Catalog.List(ctx, kind) }
+
+func (s *Server) PostCatalogsKind(ctx *gin.Context, kind api.CatalogKind) { s.Catalog.Add(ctx, kind) }
+
+func (s *Server) PatchCatalogsKindId(ctx *gin.Context, kind api.CatalogKind, id int64) {
+	s.Catalog.Update(ctx, kind, id)
+}
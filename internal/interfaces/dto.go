@@ -1,136 +1,254 @@
 package interfaces
 
 import (
-	"time"
-
-	"github.com/google/uuid"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/api"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
-// UserDTO — DTO пользователя для API
-// id, email, role
-// swagger:model UserDTO
-// example: {"id":"uuid","email":"user@avito.ru","role":"client"}
-type UserDTO struct {
-	ID    uuid.UUID         `json:"id"`
-	Email string            `json:"email"`
-	Role  entities.UserRole `json:"role"`
-}
-
-// PVZDTO — DTO ПВЗ для API
-// id, registrationDate, city
-type PVZDTO struct {
-	ID               uuid.UUID     `json:"id"`
-	RegistrationDate time.Time     `json:"registrationDate"`
-	City             entities.City `json:"city"`
-}
-
-// FullPVZDTO — ПВЗ + все приёмки с товарами
-type FullPVZDTO struct {
-	PVZ        PVZDTO                     `json:"pvz"`
-	Receptions []ReceptionWithProductsDTO `json:"receptions"`
-}
-
-// ReceptionDTO — DTO приёмки
-type ReceptionDTO struct {
-	ID       uuid.UUID                `json:"id"`
-	DateTime time.Time                `json:"dateTime"`
-	Status   entities.ReceptionStatus `json:"status"`
-	PVZID    uuid.UUID                `json:"pvzId"`
-}
-
-// ReceptionListItemDTO - структура для возврата Reception в API ответе без поля products
-type ReceptionListItemDTO struct {
-	ID       uuid.UUID                `json:"id"`
-	PVZID    uuid.UUID                `json:"pvzId"`
-	Status   entities.ReceptionStatus `json:"status"`
-	DateTime time.Time                `json:"dateTime"`
-	// Поле Products здесь специально отсутствует
-}
-
-// ToReceptionListItemDTO конвертирует Reception в DTO для API ответа
-func ToReceptionListItemDTO(reception entities.Reception) ReceptionListItemDTO {
-	return ReceptionListItemDTO{
-		ID:       reception.ID,
-		PVZID:    reception.PVZID,
-		Status:   reception.Status,
+// DTO для HTTP API генерируются по swagger.yaml (пакет api, см. make api-gen).
+// Здесь — только преобразование доменных моделей в сгенерированные DTO
+
+// ToUserDTO преобразует доменную модель User в DTO для API
+func ToUserDTO(user entities.User) api.User {
+	id := user.ID
+	return api.User{
+		Id:    &id,
+		Email: openapi_types.Email(user.Email),
+		Role:  api.UserRole(user.Role),
+	}
+}
+
+// ToTokenResponseDTO преобразует пару токенов в DTO для API (expiresIn — в секундах)
+func ToTokenResponseDTO(pair usecases.TokenPair) api.TokenResponse {
+	return api.TokenResponse{
+		Token:        pair.AccessToken,
+		RefreshToken: pair.RefreshToken,
+		ExpiresIn:    int(pair.ExpiresIn.Seconds()),
+		UserId:       pair.UserID,
+	}
+}
+
+// ToPVZDTO преобразует доменную модель PVZ в DTO для API (без списка приёмок)
+func ToPVZDTO(pvz entities.PVZ) api.PVZ {
+	id := pvz.ID
+	regDate := pvz.RegistrationDate
+	return api.PVZ{
+		Id:               &id,
+		RegistrationDate: &regDate,
+		City:             string(pvz.City),
+		ArchivedAt:       pvz.ArchivedAt,
+		Name:             optionalString(pvz.Name),
+		Address:          optionalString(pvz.Address),
+		WorkingHours:     optionalString(pvz.WorkingHours),
+		Location:         toGeoPointDTO(pvz.Location),
+	}
+}
+
+// PVZDetailsFromDTO достаёт описание ПВЗ из тела POST /pvz
+func PVZDetailsFromDTO(dto api.PVZ) entities.PVZDetails {
+	details := entities.PVZDetails{Location: GeoPointFromDTO(dto.Location)}
+	if dto.Name != nil {
+		details.Name = *dto.Name
+	}
+	if dto.Address != nil {
+		details.Address = *dto.Address
+	}
+	if dto.WorkingHours != nil {
+		details.WorkingHours = *dto.WorkingHours
+	}
+	return details
+}
+
+// GeoPointFromDTO преобразует координаты из DTO, nil — координаты не переданы
+func GeoPointFromDTO(dto *api.GeoPoint) *entities.GeoPoint {
+	if dto == nil {
+		return nil
+	}
+	return &entities.GeoPoint{Lat: dto.Lat, Lon: dto.Lon}
+}
+
+func toGeoPointDTO(p *entities.GeoPoint) *api.GeoPoint {
+	if p == nil {
+		return nil
+	}
+	return &api.GeoPoint{Lat: p.Lat, Lon: p.Lon}
+}
+
+// optionalString — пустая строка не выводится в ответе
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// ToNearestPVZDTOs преобразует результат поиска ближайших ПВЗ в DTO (пустой список, а не null)
+func ToNearestPVZDTOs(nearest []entities.NearestPVZ) []api.NearestPVZ {
+	res := make([]api.NearestPVZ, 0, len(nearest))
+	for _, n := range nearest {
+		res = append(res, api.NearestPVZ{Pvz: ToPVZDTO(n.PVZ), Distance: n.Distance})
+	}
+	return res
+}
+
+// ToReceptionDTO преобразует доменную модель Reception в DTO для API (без списка товаров)
+func ToReceptionDTO(reception entities.Reception) api.Reception {
+	id := reception.ID
+	return api.Reception{
+		Id:       &id,
+		PvzId:    reception.PVZID,
+		Status:   api.ReceptionStatus(reception.Status),
 		DateTime: reception.DateTime,
+		ClosedAt: reception.ClosedAt,
 	}
 }
 
-// ProductDTO — DTO товара
-type ProductDTO struct {
-	ID          uuid.UUID            `json:"id"`
-	DateTime    time.Time            `json:"dateTime"`
-	Type        entities.ProductType `json:"type"`
-	ReceptionID uuid.UUID            `json:"receptionId"`
+// ToReceptionTransitionDTOs преобразует историю статусов приёмки в DTO (пустой список, а не null)
+func ToReceptionTransitionDTOs(transitions []entities.ReceptionTransition) []api.ReceptionTransition {
+	res := make([]api.ReceptionTransition, 0, len(transitions))
+	for _, t := range transitions {
+		res = append(res, api.ReceptionTransition{
+			From:      api.ReceptionTransitionFrom(t.From),
+			To:        api.ReceptionTransitionTo(t.To),
+			ChangedBy: t.ChangedBy,
+			Reason:    optionalString(t.Reason),
+			ChangedAt: t.ChangedAt,
+		})
+	}
+	return res
 }
 
-// ToProductDTO преобразует доменную модель Product в DTO для API
-func ToProductDTO(product entities.Product) ProductDTO {
-	return ProductDTO{
-		ID:          product.ID,
-		DateTime:    product.DateTime,
-		Type:        product.Type,
-		ReceptionID: product.ReceptionID,
+// ToReceptionDTOs преобразует список приёмок в DTO (пустой список, а не null)
+func ToReceptionDTOs(receptions []entities.Reception) []api.Reception {
+	res := make([]api.Reception, 0, len(receptions))
+	for _, r := range receptions {
+		res = append(res, ToReceptionDTO(r))
 	}
+	return res
 }
 
-// ReceptionWithProductsDTO — приёмка + товары
-type ReceptionWithProductsDTO struct {
-	Reception ReceptionDTO `json:"reception"`
-	Products  []ProductDTO `json:"products"`
+// ToReceptionWithProductsDTO преобразует приёмку с товарами в DTO
+func ToReceptionWithProductsDTO(rec entities.ReceptionWithProducts) api.ReceptionWithProducts {
+	return api.ReceptionWithProducts{
+		Reception: ToReceptionDTO(rec.Reception),
+		Products:  ToProductDTOs(rec.Products),
+	}
 }
 
-// RegisterRequest — DTO для регистрации пользователя
-type RegisterRequest struct {
-	Email    string            `json:"email"`
-	Password string            `json:"password"`
-	Role     entities.UserRole `json:"role"`
+// ToProductDTO преобразует доменную модель Product в DTO для API
+func ToProductDTO(product entities.Product) api.Product {
+	id := product.ID
+	dateTime := product.DateTime
+	dto := api.Product{
+		Id:          &id,
+		DateTime:    &dateTime,
+		Type:        string(product.Type),
+		ReceptionId: product.ReceptionID,
+		Barcode:     optionalString(product.Barcode),
+	}
+	if product.Position > 0 {
+		position := product.Position
+		dto.Position = &position
+	}
+	return dto
 }
 
-// LoginRequest — DTO для логина
-type LoginRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+// ToProductRemovalDTO преобразует запись журнала удалений в DTO
+func ToProductRemovalDTO(removal entities.ProductRemoval) api.ProductRemoval {
+	return api.ProductRemoval{
+		Product:   ToProductDTO(removal.Product),
+		RemovedBy: removal.RemovedBy,
+		RemovedAt: removal.RemovedAt,
+	}
 }
 
-// ListParams — параметры фильтрации/пагинации для /pvz
-type ListParams struct {
-	StartDate *time.Time `json:"startDate,omitempty"`
-	EndDate   *time.Time `json:"endDate,omitempty"`
-	Page      int        `json:"page"`
-	Limit     int        `json:"limit"`
+// ToProductBatchResultDTO преобразует поштучные результаты пакетного приёма в DTO
+func ToProductBatchResultDTO(results []usecases.ProductBatchResult) api.ProductBatchResult {
+	dto := api.ProductBatchResult{Results: make([]api.ProductBatchItemResult, 0, len(results))}
+	for i, r := range results {
+		item := api.ProductBatchItemResult{Index: i}
+		if r.Product != nil {
+			product := ToProductDTO(*r.Product)
+			item.Product = &product
+			dto.Accepted++
+		} else {
+			msg := r.Err.Error()
+			item.Error = &msg
+			dto.Rejected++
+		}
+		dto.Results = append(dto.Results, item)
+	}
+	return dto
+}
+
+// ToProductLocationDTOs преобразует результаты поиска по штрихкоду в DTO (пустой список, а не null)
+func ToProductLocationDTOs(locations []entities.ProductLocation) []api.ProductLocation {
+	res := make([]api.ProductLocation, 0, len(locations))
+	for _, loc := range locations {
+		res = append(res, api.ProductLocation{
+			Product:   ToProductDTO(loc.Product),
+			Reception: ToReceptionDTO(loc.Reception),
+			Pvz:       ToPVZDTO(loc.PVZ),
+		})
+	}
+	return res
 }
 
-// AddProductRequest — DTO для добавления товара
-type AddProductRequest struct {
-	PVZID uuid.UUID            `json:"pvzId"`
-	Type  entities.ProductType `json:"type"`
+// ToProductDTOs преобразует список товаров в DTO (пустой список, а не null)
+func ToProductDTOs(products []entities.Product) []api.Product {
+	res := make([]api.Product, 0, len(products))
+	for _, p := range products {
+		res = append(res, ToProductDTO(p))
+	}
+	return res
+}
+
+// ToPVZWithReceptionsDTOs преобразует read model листинга ПВЗ в DTO для GET /pvz
+func ToPVZWithReceptionsDTOs(pvzs []entities.PVZWithReceptions) []api.PVZWithReceptions {
+	res := make([]api.PVZWithReceptions, 0, len(pvzs))
+	for _, pvz := range pvzs {
+		recs := make([]api.ReceptionWithProducts, 0, len(pvz.Receptions))
+		for _, rec := range pvz.Receptions {
+			recs = append(recs, ToReceptionWithProductsDTO(rec))
+		}
+		res = append(res, api.PVZWithReceptions{
+			Pvz:        ToPVZDTO(pvz.PVZ),
+			Receptions: recs,
+		})
+	}
+	return res
 }
 
-// PVZListItemDTO - структура для возврата ПВЗ в API ответе /pvz GET
-type PVZListItemDTO struct {
-	ID               uuid.UUID     `json:"id"`
-	RegistrationDate time.Time     `json:"registrationDate"`
-	City             entities.City `json:"city"`
-	// Поле Receptions здесь специально отсутствует
+// ToCatalogEntryDTOs преобразует элементы справочника в DTO (пустой список, а не null)
+func ToCatalogEntryDTOs(entries []entities.CatalogEntry) []api.CatalogEntry {
+	res := make([]api.CatalogEntry, 0, len(entries))
+	for _, e := range entries {
+		res = append(res, ToCatalogEntryDTO(e))
+	}
+	return res
 }
 
-// ToPVZListItemDTO конвертирует PVZ в DTO для API ответа
-func ToPVZListItemDTO(pvz entities.PVZ) PVZListItemDTO {
-	return PVZListItemDTO{
-		ID:               pvz.ID,
-		RegistrationDate: pvz.RegistrationDate,
-		City:             pvz.City,
+// ToCatalogEntryDTO преобразует элемент справочника в DTO для API
+func ToCatalogEntryDTO(entry entities.CatalogEntry) api.CatalogEntry {
+	return api.CatalogEntry{
+		Id:     entry.ID,
+		Name:   entry.Name,
+		Active: entry.Active,
 	}
 }
 
-// PVZListResponseItem - структура для элемента в ответе списка ПВЗ
-type PVZListResponseItem struct {
-	PVZ        PVZListItemDTO `json:"pvz"`
-	Receptions []struct {
-		Reception ReceptionListItemDTO `json:"reception"` // Используем ReceptionListItemDTO вместо entities.Reception
-		Products  []ProductDTO         `json:"products"`
-	} `json:"receptions"`
+// ToStaffAssignmentDTOs преобразует назначения сотрудников на ПВЗ в DTO для API
+func ToStaffAssignmentDTOs(staff []entities.StaffAssignment) []api.StaffAssignment {
+	res := make([]api.StaffAssignment, 0, len(staff))
+	for _, a := range staff {
+		res = append(res, api.StaffAssignment{
+			PvzId:      a.PVZID,
+			UserId:     a.UserID,
+			AssignedAt: a.AssignedAt,
+			AssignedBy: a.AssignedBy,
+		})
+	}
+	return res
 }
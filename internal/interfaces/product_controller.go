@@ -2,12 +2,14 @@ package interfaces
 
 import (
 	"context"
+
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/api"
 )
 
 // ProductController — интерфейс контроллера товаров (см. .puml)
 type ProductController interface {
 	// AddProduct добавляет товар в приёмку
-	AddProduct(ctx context.Context, req AddProductRequest) (ProductDTO, error)
+	AddProduct(ctx context.Context, req api.PostProductsJSONRequestBody) (api.Product, error)
 	// DeleteLastProduct удаляет последний товар из приёмки по PVZ
 	DeleteLastProduct(ctx context.Context, pvzID string) error
 }
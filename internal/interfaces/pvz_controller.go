@@ -2,16 +2,18 @@ package interfaces
 
 import (
 	"context"
+
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/api"
 )
 
 // PVZController — интерфейс контроллера ПВЗ (см. .puml)
 type PVZController interface {
 	// CreatePVZ создаёт новый ПВЗ
-	CreatePVZ(ctx context.Context, req PVZDTO) (PVZDTO, error)
+	CreatePVZ(ctx context.Context, req api.PostPvzJSONRequestBody) (api.PVZ, error)
 	// ListPVZ возвращает список ПВЗ с фильтрами и пагинацией
-	ListPVZ(ctx context.Context, params ListParams) ([]FullPVZDTO, error)
+	ListPVZ(ctx context.Context, params api.GetPvzParams) ([]api.PVZWithReceptions, error)
 	// CloseLastReception закрывает последнюю приёмку по PVZ
-	CloseLastReception(ctx context.Context, pvzID string) (ReceptionDTO, error)
+	CloseLastReception(ctx context.Context, pvzID string) (api.Reception, error)
 	// DeleteLastProduct удаляет последний товар из приёмки по PVZ
 	DeleteLastProduct(ctx context.Context, pvzID string) error
 }
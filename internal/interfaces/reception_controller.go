@@ -2,12 +2,14 @@ package interfaces
 
 import (
 	"context"
+
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/api"
 )
 
 // ReceptionController — интерфейс контроллера приёмок (см. .puml)
 type ReceptionController interface {
 	// CreateReception создаёт новую приёмку
-	CreateReception(ctx context.Context, pvzID string) (ReceptionDTO, error)
+	CreateReception(ctx context.Context, pvzID string) (api.Reception, error)
 	// CloseLastReception закрывает последнюю приёмку по PVZ
-	CloseLastReception(ctx context.Context, pvzID string) (ReceptionDTO, error)
+	CloseLastReception(ctx context.Context, pvzID string) (api.Reception, error)
 }
@@ -0,0 +1,49 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
+)
+
+// AddCatalogEntryUseCaseIface — интерфейс для моков и контроллеров
+type AddCatalogEntryUseCaseIface interface {
+	Execute(ctx context.Context, user entities.User, kind entities.CatalogKind, name string) (entities.CatalogEntry, error)
+}
+
+// AddCatalogEntryUseCase — интерактор для добавления города или типа товара
+// Нужно право catalog:manage, название уникально в пределах справочника
+
+type AddCatalogEntryUseCase struct {
+	repo  CatalogRepository
+	cache CatalogInvalidator
+	authz *Authorizer
+}
+
+func NewAddCatalogEntryUseCase(repo CatalogRepository, cache CatalogInvalidator, authz *Authorizer) *AddCatalogEntryUseCase {
+	return &AddCatalogEntryUseCase{repo: repo, cache: cache, authz: authz}
+}
+
+// Execute добавляет активный элемент в справочник kind и сбрасывает кэш справочника
+func (uc *AddCatalogEntryUseCase) Execute(ctx context.Context, user entities.User, kind entities.CatalogKind, name string) (entities.CatalogEntry, error) {
+	log := logger.FromContext(ctx).With(slog.String("kind", string(kind)))
+	if err := uc.authz.Authorize(ctx, user, entities.PermCatalogManage); err != nil {
+		return entities.CatalogEntry{}, err
+	}
+	if !entities.ValidateCatalogKind(kind) {
+		return entities.CatalogEntry{}, ErrUnknownCatalog
+	}
+	name, ok := entities.NormalizeCatalogName(name)
+	if !ok {
+		return entities.CatalogEntry{}, ErrInvalidCatalogName
+	}
+	entry, err := uc.repo.Add(ctx, kind, name)
+	if err != nil {
+		return entities.CatalogEntry{}, err
+	}
+	uc.cache.Invalidate(kind)
+	log.Info("catalog entry added", slog.Int64("id", entry.ID), slog.String("name", entry.Name))
+	return entry, nil
+}
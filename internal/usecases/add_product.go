@@ -3,55 +3,93 @@ package usecases
 import (
 	"context"
 	"errors"
+	"log/slog"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
 )
 
 // ProductRepository — интерфейс для работы с товарами (см. CA_c4_class.puml)
 type ProductRepository interface {
+	// Save возвращает ErrDuplicateBarcode, если штрихкод уже есть в этой приёмке
 	Save(ctx context.Context, product entities.Product) (entities.Product, error)
 }
 
-// ReceptionRepositoryForAdd — интерфейс для получения незакрытой приёмки
-// (можно использовать тот же, что и для CreateReception, но для явности)
+// ReceptionRepositoryForAdd — интерфейс для получения незакрытой приёмки с блокировкой строки
+// (параллельное закрытие приёмки ждёт, пока товар не будет сохранён)
 type ReceptionRepositoryForAdd interface {
-	GetActive(ctx context.Context, pvzID uuid.UUID) (*entities.Reception, error)
+	GetActiveForUpdate(ctx context.Context, pvzID uuid.UUID) (*entities.Reception, error)
 }
 
 // AddProductUseCase — интерактор для добавления товара в приёмку
-// Только pvz_staff, только в незакрытую приёмку, тип товара валиден
+// Нужно право product:add и назначение на ПВЗ, только в незакрытую приёмку, тип товара активен в справочнике product_type,
+// один штрихкод — не больше одного раза в приёмке
 
 type AddProductUseCase struct {
 	productRepo   ProductRepository
 	receptionRepo ReceptionRepositoryForAdd
+	catalog       CatalogChecker
+	tx            TxManager
+	metrics       BusinessMetrics
+	authz         *Authorizer
 }
 
-func NewAddProductUseCase(productRepo ProductRepository, receptionRepo ReceptionRepositoryForAdd) *AddProductUseCase {
-	return &AddProductUseCase{productRepo: productRepo, receptionRepo: receptionRepo}
+func NewAddProductUseCase(productRepo ProductRepository, receptionRepo ReceptionRepositoryForAdd, catalog CatalogChecker, tx TxManager, metrics BusinessMetrics, authz *Authorizer) *AddProductUseCase {
+	return &AddProductUseCase{productRepo: productRepo, receptionRepo: receptionRepo, catalog: catalog, tx: tx, metrics: metrics, authz: authz}
 }
 
-// Execute добавляет товар в незакрытую приёмку, если роль pvz_staff и тип валиден
-func (uc *AddProductUseCase) Execute(ctx context.Context, user entities.User, pvzID uuid.UUID, productType entities.ProductType) (entities.Product, error) {
-	if user.Role != entities.UserRolePVZStaff {
-		return entities.Product{}, errors.New("только сотрудник ПВЗ может добавлять товары")
+// Execute добавляет товар в незакрытую приёмку, если есть право product:add и тип есть в справочнике.
+// Пустой barcode — товар без штрихкода. Проверка приёмки и вставка товара — в одной транзакции под блокировкой приёмки
+func (uc *AddProductUseCase) Execute(ctx context.Context, user entities.User, pvzID uuid.UUID, productType entities.ProductType, barcode string) (entities.Product, error) {
+	log := logger.FromContext(ctx).With(slog.String("pvz_id", pvzID.String()))
+	if err := uc.authz.AuthorizePVZ(ctx, user, entities.PermProductAdd, pvzID); err != nil {
+		return entities.Product{}, err
 	}
-	if !entities.ValidateProductType(productType) {
-		return entities.Product{}, errors.New("некорректный тип товара")
+	barcode, ok := entities.NormalizeBarcode(barcode)
+	if !ok {
+		return entities.Product{}, ErrInvalidBarcode
 	}
-	rec, err := uc.receptionRepo.GetActive(ctx, pvzID)
+	allowed, err := uc.catalog.IsAllowed(ctx, entities.CatalogProductType, string(productType))
 	if err != nil {
 		return entities.Product{}, err
 	}
-	if rec == nil || !rec.IsOpen() {
-		return entities.Product{}, errors.New("нет открытой приёмки для добавления товара")
+	if !allowed {
+		return entities.Product{}, ErrInvalidProductType
 	}
-	product := entities.Product{
-		ID:          entities.GenerateUUID(),
-		ReceptionID: rec.ID,
-		Type:        productType,
-		DateTime:    time.Now().UTC(),
+	var saved entities.Product
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		rec, err := uc.receptionRepo.GetActiveForUpdate(ctx, pvzID)
+		if err != nil {
+			return err
+		}
+		if err := requireOpen(rec, ErrNoReceptionForProduct); err != nil {
+			log.Warn("no open reception", slog.String("op", "AddProduct"), slog.String("error", err.Error()))
+			return err
+		}
+		product := entities.Product{
+			ID:          entities.GenerateUUID(),
+			ReceptionID: rec.ID,
+			Type:        productType,
+			DateTime:    time.Now().UTC(),
+			Barcode:     barcode,
+		}
+		saved, err = uc.productRepo.Save(ctx, product)
+		if errors.Is(err, ErrDuplicateBarcode) {
+			log.Warn("duplicate barcode", slog.String("op", "AddProduct"), slog.String("reception_id", rec.ID.String()), slog.String("barcode", barcode))
+		}
+		return err
+	})
+	if err != nil {
+		return entities.Product{}, err
 	}
-	return uc.productRepo.Save(ctx, product)
+	uc.metrics.IncProductAdded()
+	log.Info("product added", slog.String("reception_id", saved.ReceptionID.String()), slog.String("product_id", saved.ID.String()), slog.String("type", string(saved.Type)))
+	return saved, nil
+}
+
+// AddProductUseCaseIface — интерфейс для моков и контроллеров
+type AddProductUseCaseIface interface {
+	Execute(ctx context.Context, user entities.User, pvzID uuid.UUID, productType entities.ProductType, barcode string) (entities.Product, error)
 }
@@ -0,0 +1,161 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
+)
+
+// ProductBatchMaxSize — максимум товаров в одном пакете
+const ProductBatchMaxSize = 1000
+
+// ProductBatchItem — товар из пакета: тип и необязательный штрихкод
+type ProductBatchItem struct {
+	Type    entities.ProductType
+	Barcode string
+}
+
+// ProductBatchResult — результат по одному товару пакета: сохранённый товар или причина отказа
+type ProductBatchResult struct {
+	Product *entities.Product
+	Err     error
+}
+
+// ProductRepositoryForBatch — интерфейс для пакетной вставки товаров в приёмку
+type ProductRepositoryForBatch interface {
+	ExistingBarcodes(ctx context.Context, receptionID uuid.UUID, barcodes []string) ([]string, error)
+	SaveBatch(ctx context.Context, receptionID uuid.UUID, products []entities.Product) ([]entities.Product, error)
+}
+
+// AddProductsBatchUseCaseIface — интерфейс для моков и контроллеров
+type AddProductsBatchUseCaseIface interface {
+	Execute(ctx context.Context, user entities.User, pvzID uuid.UUID, items []ProductBatchItem) ([]ProductBatchResult, error)
+}
+
+// AddProductsBatchUseCase — интерактор для приёма пакета товаров в открытую приёмку.
+// Правила те же, что у AddProductUseCase; товары, не прошедшие проверку, отклоняются поштучно,
+// остальные вставляются одной транзакцией
+type AddProductsBatchUseCase struct {
+	productRepo   ProductRepositoryForBatch
+	receptionRepo ReceptionRepositoryForAdd
+	catalog       CatalogChecker
+	tx            TxManager
+	metrics       BusinessMetrics
+	authz         *Authorizer
+}
+
+func NewAddProductsBatchUseCase(productRepo ProductRepositoryForBatch, receptionRepo ReceptionRepositoryForAdd, catalog CatalogChecker, tx TxManager, metrics BusinessMetrics, authz *Authorizer) *AddProductsBatchUseCase {
+	return &AddProductsBatchUseCase{productRepo: productRepo, receptionRepo: receptionRepo, catalog: catalog, tx: tx, metrics: metrics, authz: authz}
+}
+
+// Execute возвращает результаты в порядке items. Ошибка всего запроса — только если пакет пуст или слишком велик,
+// нет права product:add, пользователь не назначен на ПВЗ или у ПВЗ нет открытой приёмки
+func (uc *AddProductsBatchUseCase) Execute(ctx context.Context, user entities.User, pvzID uuid.UUID, items []ProductBatchItem) ([]ProductBatchResult, error) {
+	log := logger.FromContext(ctx).With(slog.String("pvz_id", pvzID.String()))
+	if err := uc.authz.AuthorizePVZ(ctx, user, entities.PermProductAdd, pvzID); err != nil {
+		return nil, err
+	}
+	if len(items) == 0 || len(items) > ProductBatchMaxSize {
+		return nil, ErrInvalidBatchSize
+	}
+	results := make([]ProductBatchResult, len(items))
+	seen := make(map[string]bool, len(items))
+	now := time.Now().UTC()
+	var products []entities.Product
+	var indexes []int // indexes[i] — позиция products[i] в items
+	for i, item := range items {
+		allowed, err := uc.catalog.IsAllowed(ctx, entities.CatalogProductType, string(item.Type))
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			results[i].Err = ErrInvalidProductType
+			continue
+		}
+		barcode, ok := entities.NormalizeBarcode(item.Barcode)
+		if !ok {
+			results[i].Err = ErrInvalidBarcode
+			continue
+		}
+		if barcode != "" {
+			if seen[barcode] {
+				results[i].Err = ErrDuplicateBarcode
+				continue
+			}
+			seen[barcode] = true
+		}
+		products = append(products, entities.Product{ID: entities.GenerateUUID(), Type: item.Type, DateTime: now, Barcode: barcode})
+		indexes = append(indexes, i)
+	}
+
+	var receptionID uuid.UUID
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		rec, err := uc.receptionRepo.GetActiveForUpdate(ctx, pvzID)
+		if err != nil {
+			return err
+		}
+		if err := requireOpen(rec, ErrNoReceptionForProduct); err != nil {
+			log.Warn("no open reception", slog.String("op", "AddProductsBatch"), slog.String("error", err.Error()))
+			return err
+		}
+		receptionID = rec.ID
+		toSave, saveIndexes, err := uc.dropScanned(ctx, rec.ID, products, indexes, results)
+		if err != nil || len(toSave) == 0 {
+			return err
+		}
+		saved, err := uc.productRepo.SaveBatch(ctx, rec.ID, toSave)
+		if err != nil {
+			return err
+		}
+		for i := range saved {
+			results[saveIndexes[i]].Product = &saved[i]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	accepted := 0
+	for _, r := range results {
+		if r.Product != nil {
+			uc.metrics.IncProductAdded()
+			accepted++
+		}
+	}
+	log.Info("product batch added", slog.String("reception_id", receptionID.String()), slog.Int("accepted", accepted), slog.Int("rejected", len(items)-accepted))
+	return results, nil
+}
+
+// dropScanned отклоняет товары со штрихкодами, уже отсканированными в приёмку, и возвращает остальные с их индексами в items
+func (uc *AddProductsBatchUseCase) dropScanned(ctx context.Context, receptionID uuid.UUID, products []entities.Product, indexes []int, results []ProductBatchResult) ([]entities.Product, []int, error) {
+	var barcodes []string
+	for _, p := range products {
+		if p.Barcode != "" {
+			barcodes = append(barcodes, p.Barcode)
+		}
+	}
+	existing, err := uc.productRepo.ExistingBarcodes(ctx, receptionID, barcodes)
+	if err != nil || len(existing) == 0 {
+		return products, indexes, err
+	}
+	scanned := make(map[string]bool, len(existing))
+	for _, b := range existing {
+		scanned[b] = true
+	}
+	var keptProducts []entities.Product
+	var keptIndexes []int
+	for i, p := range products {
+		if scanned[p.Barcode] {
+			results[indexes[i]].Err = ErrDuplicateBarcode
+			continue
+		}
+		keptProducts = append(keptProducts, p)
+		keptIndexes = append(keptIndexes, indexes[i])
+	}
+	return keptProducts, keptIndexes, nil
+}
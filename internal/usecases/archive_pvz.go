@@ -0,0 +1,77 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
+)
+
+// ReceptionRepositoryForArchive — интерфейс для проверки открытой приёмки перед архивацией
+type ReceptionRepositoryForArchive interface {
+	GetActive(ctx context.Context, pvzID uuid.UUID) (*entities.Reception, error)
+}
+
+// ArchivePVZUseCaseIface — интерфейс для моков и контроллеров
+type ArchivePVZUseCaseIface interface {
+	Execute(ctx context.Context, user entities.User, pvzID uuid.UUID, archived bool) (entities.PVZ, error)
+}
+
+// ArchivePVZUseCase — интерактор для вывода ПВЗ из работы и возврата в работу
+// Нужно право pvz:archive. ПВЗ с открытой приёмкой архивировать нельзя: сначала её нужно закрыть
+
+type ArchivePVZUseCase struct {
+	pvzRepo       PVZRepositoryForUpdate
+	receptionRepo ReceptionRepositoryForArchive
+	tx            TxManager
+	authz         *Authorizer
+}
+
+func NewArchivePVZUseCase(pvzRepo PVZRepositoryForUpdate, receptionRepo ReceptionRepositoryForArchive, tx TxManager, authz *Authorizer) *ArchivePVZUseCase {
+	return &ArchivePVZUseCase{pvzRepo: pvzRepo, receptionRepo: receptionRepo, tx: tx, authz: authz}
+}
+
+// Execute архивирует (archived=true) или возвращает в работу ПВЗ. Повторный вызов ничего не меняет.
+// ПВЗ блокируется до коммита: приёмка не может открыться между проверкой и архивацией
+func (uc *ArchivePVZUseCase) Execute(ctx context.Context, user entities.User, pvzID uuid.UUID, archived bool) (entities.PVZ, error) {
+	log := logger.FromContext(ctx).With(slog.String("pvz_id", pvzID.String()))
+	if err := uc.authz.Authorize(ctx, user, entities.PermPVZArchive); err != nil {
+		return entities.PVZ{}, err
+	}
+	var res entities.PVZ
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		pvz, err := uc.pvzRepo.GetByIDForUpdate(ctx, pvzID)
+		if err != nil {
+			return err
+		}
+		if pvz == nil {
+			return ErrPVZNotFound
+		}
+		if pvz.IsArchived() == archived {
+			res = *pvz
+			return nil
+		}
+		if archived {
+			active, err := uc.receptionRepo.GetActive(ctx, pvzID)
+			if err != nil {
+				return err
+			}
+			if active != nil && active.IsActive() {
+				log.Warn("archive rejected: reception open", slog.String("reception_id", active.ID.String()))
+				return ErrPVZHasOpenReception
+			}
+			pvz.Archive(entities.NowUTC())
+		} else {
+			pvz.Unarchive()
+		}
+		res, err = uc.pvzRepo.Update(ctx, *pvz)
+		return err
+	})
+	if err != nil {
+		return entities.PVZ{}, err
+	}
+	log.Info("pvz archive state changed", slog.Bool("archived", res.IsArchived()))
+	return res, nil
+}
@@ -0,0 +1,71 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
+)
+
+// StaffAssignmentChecker — интерфейс для проверки назначения сотрудника на ПВЗ
+type StaffAssignmentChecker interface {
+	IsAssigned(ctx context.Context, userID, pvzID uuid.UUID) (bool, error)
+}
+
+// Authorizer проверяет права роли по политике роль → права и назначение на ПВЗ. Один экземпляр на процесс:
+// его используют и usecase'ы, и HTTP-middleware, поэтому отказ везде одинаковый — ErrForbidden
+type Authorizer struct {
+	grants map[entities.UserRole]map[entities.Permission]bool
+	staff  StaffAssignmentChecker
+}
+
+// NewAuthorizer создаёт Authorizer с политикой (см. entities.DefaultRolePermissions) и хранилищем назначений.
+// Роли, которых нет в политике, не имеют прав
+func NewAuthorizer(policy map[entities.UserRole][]entities.Permission, staff StaffAssignmentChecker) *Authorizer {
+	grants := make(map[entities.UserRole]map[entities.Permission]bool, len(policy))
+	for role, perms := range policy {
+		grants[role] = make(map[entities.Permission]bool, len(perms))
+		for _, p := range perms {
+			grants[role][p] = true
+		}
+	}
+	return &Authorizer{grants: grants, staff: staff}
+}
+
+// Can сообщает, есть ли у роли право
+func (a *Authorizer) Can(role entities.UserRole, perm entities.Permission) bool {
+	return a.grants[role][perm]
+}
+
+// Authorize возвращает ошибку категории ErrForbidden, если у роли пользователя нет права
+func (a *Authorizer) Authorize(ctx context.Context, user entities.User, perm entities.Permission) error {
+	if a.Can(user.Role, perm) {
+		return nil
+	}
+	logger.FromContext(ctx).Warn("permission denied", slog.String("user_role", string(user.Role)), slog.String("permission", string(perm)))
+	return permissionDenied(perm)
+}
+
+// AuthorizePVZ — Authorize и проверка, что пользователь назначен на ПВЗ pvzID.
+// Нужна операциям с приёмками и товарами конкретного ПВЗ
+func (a *Authorizer) AuthorizePVZ(ctx context.Context, user entities.User, perm entities.Permission, pvzID uuid.UUID) error {
+	if err := a.Authorize(ctx, user, perm); err != nil {
+		return err
+	}
+	assigned, err := a.staff.IsAssigned(ctx, user.ID, pvzID)
+	if err != nil {
+		return err
+	}
+	if !assigned {
+		logger.FromContext(ctx).Warn("pvz access denied", slog.String("user_id", user.ID.String()), slog.String("pvz_id", pvzID.String()), slog.String("permission", string(perm)))
+		return ErrNotAssignedToPVZ
+	}
+	return nil
+}
+
+// permissionDenied — отказ с названием недостающего права
+func permissionDenied(perm entities.Permission) *Error {
+	return NewError(ErrForbidden, "недостаточно прав: нужно "+string(perm))
+}
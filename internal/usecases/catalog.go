@@ -0,0 +1,130 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
+)
+
+// CatalogRepository — хранилище справочников (города, типы товаров)
+type CatalogRepository interface {
+	List(ctx context.Context, kind entities.CatalogKind) ([]entities.CatalogEntry, error)
+	// Add возвращает ErrCatalogEntryExists, если название занято
+	Add(ctx context.Context, kind entities.CatalogKind, name string) (entities.CatalogEntry, error)
+	// Update меняет только переданные поля; nil — элемента нет, ErrCatalogEntryExists — название занято
+	Update(ctx context.Context, kind entities.CatalogKind, id int64, patch CatalogEntryPatch) (*entities.CatalogEntry, error)
+}
+
+// CatalogEntryPatch — изменяемые поля элемента справочника, nil — не менять
+type CatalogEntryPatch struct {
+	Name   *string
+	Active *bool
+}
+
+// CatalogChecker — проверка значения по справочнику (реализации — CatalogCache, StaticCatalog)
+type CatalogChecker interface {
+	IsAllowed(ctx context.Context, kind entities.CatalogKind, name string) (bool, error)
+}
+
+// CatalogInvalidator — сброс закэшированного справочника после изменения
+type CatalogInvalidator interface {
+	Invalidate(kind entities.CatalogKind)
+}
+
+// CatalogLister — чтение справочника, нужное кэшу
+type CatalogLister interface {
+	List(ctx context.Context, kind entities.CatalogKind) ([]entities.CatalogEntry, error)
+}
+
+// CatalogCache — активные элементы справочников в памяти. Изменения через usecase этого процесса
+// сбрасывают кэш сразу, изменения с других реплик видны не позже чем через ttl (0 — без ttl)
+type CatalogCache struct {
+	repo CatalogLister
+	ttl  time.Duration
+
+	mu      sync.RWMutex
+	entries map[entities.CatalogKind]catalogSnapshot
+	// gen растёт при каждом Invalidate: загрузка, начатая до сброса, не перезапишет кэш устаревшими данными
+	gen map[entities.CatalogKind]uint64
+}
+
+type catalogSnapshot struct {
+	active   map[string]bool
+	loadedAt time.Time
+}
+
+func NewCatalogCache(repo CatalogLister, ttl time.Duration) *CatalogCache {
+	return &CatalogCache{
+		repo:    repo,
+		ttl:     ttl,
+		entries: map[entities.CatalogKind]catalogSnapshot{},
+		gen:     map[entities.CatalogKind]uint64{},
+	}
+}
+
+// IsAllowed сообщает, есть ли name среди активных элементов справочника kind
+func (c *CatalogCache) IsAllowed(ctx context.Context, kind entities.CatalogKind, name string) (bool, error) {
+	c.mu.RLock()
+	snap, ok := c.entries[kind]
+	gen := c.gen[kind]
+	c.mu.RUnlock()
+	if !ok || (c.ttl > 0 && time.Since(snap.loadedAt) >= c.ttl) {
+		var err error
+		if snap, err = c.load(ctx, kind, gen); err != nil {
+			return false, err
+		}
+	}
+	return snap.active[name], nil
+}
+
+// Invalidate сбрасывает справочник kind, следующая проверка перечитает его из репозитория
+func (c *CatalogCache) Invalidate(kind entities.CatalogKind) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, kind)
+	c.gen[kind]++
+}
+
+func (c *CatalogCache) load(ctx context.Context, kind entities.CatalogKind, gen uint64) (catalogSnapshot, error) {
+	list, err := c.repo.List(ctx, kind)
+	if err != nil {
+		return catalogSnapshot{}, err
+	}
+	snap := catalogSnapshot{active: make(map[string]bool, len(list)), loadedAt: time.Now()}
+	for _, e := range list {
+		if e.Active {
+			snap.active[e.Name] = true
+		}
+	}
+	c.mu.Lock()
+	if c.gen[kind] == gen {
+		c.entries[kind] = snap
+	}
+	c.mu.Unlock()
+	logger.FromContext(ctx).Debug("catalog loaded", slog.String("kind", string(kind)), slog.Int("active", len(snap.active)))
+	return snap, nil
+}
+
+// StaticCatalog — неизменяемый справочник в памяти (для тестов с моками репозиториев)
+type StaticCatalog map[entities.CatalogKind][]string
+
+// DefaultCatalog — начальное наполнение справочников, как в миграции 4_catalogs
+func DefaultCatalog() StaticCatalog {
+	return StaticCatalog{
+		entities.CatalogCity: {
+			string(entities.CityMoscow), string(entities.CitySPB), string(entities.CityKazan),
+		},
+		entities.CatalogProductType: {
+			string(entities.ProductElectronics), string(entities.ProductClothes), string(entities.ProductShoes),
+		},
+	}
+}
+
+func (s StaticCatalog) IsAllowed(_ context.Context, kind entities.CatalogKind, name string) (bool, error) {
+	return slices.Contains(s[kind], name), nil
+}
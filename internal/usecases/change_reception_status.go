@@ -0,0 +1,123 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
+)
+
+// ReceptionRepositoryForStatus — интерфейс для смены статуса приёмки с записью в историю
+type ReceptionRepositoryForStatus interface {
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.Reception, error)
+	Save(ctx context.Context, reception entities.Reception) (entities.Reception, error)
+	SaveTransition(ctx context.Context, transition entities.ReceptionTransition) error
+}
+
+// ProductRepositoryForVoid — интерфейс для аннулирования товаров отменённой приёмки
+type ProductRepositoryForVoid interface {
+	VoidByReception(ctx context.Context, receptionID, removedBy uuid.UUID, removedByEmail string, removedAt time.Time) (int, error)
+}
+
+// ChangeReceptionStatusUseCase — интерактор для паузы, продолжения и отмены приёмки сотрудником, назначенным на её ПВЗ.
+// Закрытие и переоткрытие — отдельные сценарии (CloseReceptionUseCase, ReopenReceptionUseCase)
+type ChangeReceptionStatusUseCase struct {
+	repo        ReceptionRepositoryForStatus
+	productRepo ProductRepositoryForVoid
+	tx          TxManager
+	authz       *Authorizer
+}
+
+func NewChangeReceptionStatusUseCase(repo ReceptionRepositoryForStatus, productRepo ProductRepositoryForVoid, tx TxManager, authz *Authorizer) *ChangeReceptionStatusUseCase {
+	return &ChangeReceptionStatusUseCase{repo: repo, productRepo: productRepo, tx: tx, authz: authz}
+}
+
+// Execute переводит приёмку в статус to: paused — пауза, in_progress — продолжение после паузы,
+// cancelled — отмена с обязательной причиной, товары приёмки аннулируются (уходят в журнал удалений).
+// Проверка перехода, смена статуса и запись в историю — в одной транзакции под блокировкой приёмки
+func (uc *ChangeReceptionStatusUseCase) Execute(ctx context.Context, user entities.User, receptionID uuid.UUID, to entities.ReceptionStatus, reason string) (entities.Reception, error) {
+	log := logger.FromContext(ctx).With(slog.String("reception_id", receptionID.String()), slog.String("to_status", string(to)))
+	if err := uc.authz.Authorize(ctx, user, entities.PermReceptionChangeStatus); err != nil {
+		return entities.Reception{}, err
+	}
+	var change func(rec *entities.Reception) error
+	switch to {
+	case entities.ReceptionPaused:
+		change = (*entities.Reception).Pause
+	case entities.ReceptionInProgress:
+		change = (*entities.Reception).Resume
+	case entities.ReceptionCancelled:
+		change = (*entities.Reception).Cancel
+		var ok bool
+		if reason, ok = entities.NormalizeReceptionReason(reason); !ok {
+			return entities.Reception{}, ErrInvalidReason
+		}
+	default:
+		return entities.Reception{}, ErrInvalidReceptionStatus
+	}
+
+	var changed entities.Reception
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		rec, err := uc.repo.GetByIDForUpdate(ctx, receptionID)
+		if err != nil {
+			return err
+		}
+		if rec == nil {
+			return ErrReceptionNotFound
+		}
+		if err := uc.authz.AuthorizePVZ(ctx, user, entities.PermReceptionChangeStatus, rec.PVZID); err != nil {
+			return err
+		}
+		from := rec.Status
+		if err := change(rec); err != nil {
+			log.Warn("transition rejected", slog.String("op", "ChangeReceptionStatus"), slog.String("from_status", string(from)))
+			return ErrTransitionNotAllowed
+		}
+		now := time.Now().UTC()
+		if to == entities.ReceptionCancelled {
+			voided, err := uc.productRepo.VoidByReception(ctx, rec.ID, user.ID, user.Email, now)
+			if err != nil {
+				return err
+			}
+			log.Info("reception products voided", slog.Int("count", voided))
+		}
+		changed, err = uc.repo.Save(ctx, *rec)
+		if err != nil {
+			return err
+		}
+		return uc.repo.SaveTransition(ctx, entities.ReceptionTransition{
+			ID:          entities.GenerateUUID(),
+			ReceptionID: rec.ID,
+			From:        from,
+			To:          to,
+			ChangedBy:   user.ID,
+			Reason:      reason,
+			ChangedAt:   now,
+		})
+	})
+	if err != nil {
+		return entities.Reception{}, err
+	}
+	log.Info("reception status changed", slog.String("pvz_id", changed.PVZID.String()))
+	return changed, nil
+}
+
+// ChangeReceptionStatusUseCaseIface — интерфейс для моков и контроллеров
+type ChangeReceptionStatusUseCaseIface interface {
+	Execute(ctx context.Context, user entities.User, receptionID uuid.UUID, to entities.ReceptionStatus, reason string) (entities.Reception, error)
+}
+
+// requireOpen проверяет, что в приёмке можно сканировать и удалять товары:
+// nil, закрытая или отменённая приёмка — noOpen, приёмка на паузе — ErrReceptionPaused
+func requireOpen(rec *entities.Reception, noOpen error) error {
+	switch {
+	case rec != nil && rec.Status == entities.ReceptionPaused:
+		return ErrReceptionPaused
+	case rec == nil || !rec.IsOpen():
+		return noOpen
+	}
+	return nil
+}
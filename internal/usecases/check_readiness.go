@@ -0,0 +1,75 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
+)
+
+// HealthRepository — проверки хранилища для readiness-пробы
+type HealthRepository interface {
+	Ping(ctx context.Context) error
+	MigrationState(ctx context.Context) (MigrationState, error)
+}
+
+// MigrationState — применённая версия схемы БД и последняя, известная бинарнику
+type MigrationState struct {
+	Version int64 `json:"version"`
+	Latest  int64 `json:"latest"`
+}
+
+// Readiness — результат проверки готовности принимать трафик
+type Readiness struct {
+	Ready        bool
+	ShuttingDown bool
+	DatabaseErr  error
+	Migrations   *MigrationState
+	MigrationErr error
+}
+
+// CheckReadinessUseCase — интерактор readiness-пробы: БД доступна, схема применена,
+// сервис не в процессе остановки
+type CheckReadinessUseCase struct {
+	repo         HealthRepository
+	shuttingDown atomic.Bool
+}
+
+func NewCheckReadinessUseCase(repo HealthRepository) *CheckReadinessUseCase {
+	return &CheckReadinessUseCase{repo: repo}
+}
+
+// BeginShutdown переводит пробу в «не готов», чтобы балансировщик перестал слать трафик,
+// пока сервер дорабатывает текущие запросы
+func (uc *CheckReadinessUseCase) BeginShutdown() {
+	uc.shuttingDown.Store(true)
+}
+
+// Execute проверяет готовность сервиса
+func (uc *CheckReadinessUseCase) Execute(ctx context.Context) Readiness {
+	res := Readiness{ShuttingDown: uc.shuttingDown.Load()}
+	if res.DatabaseErr = uc.repo.Ping(ctx); res.DatabaseErr == nil {
+		state, err := uc.repo.MigrationState(ctx)
+		if err != nil {
+			res.MigrationErr = err
+		} else {
+			res.Migrations = &state
+		}
+	}
+	res.Ready = !res.ShuttingDown && res.DatabaseErr == nil && res.MigrationErr == nil &&
+		res.Migrations != nil && res.Migrations.Version > 0 && res.Migrations.Version >= res.Migrations.Latest
+	if !res.Ready && !res.ShuttingDown {
+		logger.FromContext(ctx).Warn("readiness check failed",
+			slog.Any("db_error", res.DatabaseErr),
+			slog.Any("migration_error", res.MigrationErr),
+			slog.Any("migrations", res.Migrations),
+		)
+	}
+	return res
+}
+
+// CheckReadinessUseCaseIface — интерфейс для моков и контроллеров
+type CheckReadinessUseCaseIface interface {
+	Execute(ctx context.Context) Readiness
+}
@@ -2,43 +2,70 @@ package usecases
 
 import (
 	"context"
-	"errors"
+	"log/slog"
 
 	"github.com/google/uuid"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
 )
 
 // ReceptionRepositoryForClose — интерфейс для работы с приёмками (закрытие)
 type ReceptionRepositoryForClose interface {
-	GetActive(ctx context.Context, pvzID uuid.UUID) (*entities.Reception, error)
+	GetActiveForUpdate(ctx context.Context, pvzID uuid.UUID) (*entities.Reception, error)
 	Save(ctx context.Context, reception entities.Reception) (entities.Reception, error)
+	SaveTransition(ctx context.Context, transition entities.ReceptionTransition) error
 }
 
 // CloseReceptionUseCase — интерактор для закрытия приёмки
 type CloseReceptionUseCase struct {
-	repo ReceptionRepositoryForClose
+	repo  ReceptionRepositoryForClose
+	tx    TxManager
+	authz *Authorizer
 }
 
-func NewCloseReceptionUseCase(repo ReceptionRepositoryForClose) *CloseReceptionUseCase {
-	return &CloseReceptionUseCase{repo: repo}
+func NewCloseReceptionUseCase(repo ReceptionRepositoryForClose, tx TxManager, authz *Authorizer) *CloseReceptionUseCase {
+	return &CloseReceptionUseCase{repo: repo, tx: tx, authz: authz}
 }
 
-// Execute закрывает приёмку, если роль pvz_staff и приёмка открыта
+// Execute закрывает приёмку, если есть право reception:close, пользователь назначен на ПВЗ и приёмка открыта или на паузе, и пишет закрытие в историю статусов.
+// Приёмка блокируется до коммита: закрытие не пересечётся с добавлением или удалением товара
 func (uc *CloseReceptionUseCase) Execute(ctx context.Context, user entities.User, pvzID uuid.UUID) (entities.Reception, error) {
-	if user.Role != entities.UserRolePVZStaff {
-		return entities.Reception{}, errors.New("только сотрудник ПВЗ может закрывать приёмку")
-	}
-	rec, err := uc.repo.GetActive(ctx, pvzID)
-	if err != nil {
+	log := logger.FromContext(ctx).With(slog.String("pvz_id", pvzID.String()))
+	if err := uc.authz.AuthorizePVZ(ctx, user, entities.PermReceptionClose, pvzID); err != nil {
 		return entities.Reception{}, err
 	}
-	if rec == nil || !rec.IsOpen() {
-		return entities.Reception{}, errors.New("нет открытой приёмки для закрытия")
-	}
-	if err := rec.Close(); err != nil {
+	var closed entities.Reception
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		rec, err := uc.repo.GetActiveForUpdate(ctx, pvzID)
+		if err != nil {
+			return err
+		}
+		if rec == nil || !rec.IsActive() {
+			log.Warn("no open reception", slog.String("op", "CloseReception"))
+			return ErrNoReceptionToClose
+		}
+		from := rec.Status
+		if err := rec.Close(); err != nil {
+			return NewError(ErrNoOpenReception, err.Error())
+		}
+		closed, err = uc.repo.Save(ctx, *rec)
+		if err != nil {
+			return err
+		}
+		return uc.repo.SaveTransition(ctx, entities.ReceptionTransition{
+			ID:          entities.GenerateUUID(),
+			ReceptionID: rec.ID,
+			From:        from,
+			To:          entities.ReceptionClosed,
+			ChangedBy:   user.ID,
+			ChangedAt:   *rec.ClosedAt,
+		})
+	})
+	if err != nil {
 		return entities.Reception{}, err
 	}
-	return uc.repo.Save(ctx, *rec)
+	log.Info("reception closed", slog.String("reception_id", closed.ID.String()))
+	return closed, nil
 }
 
 // CloseReceptionUseCaseIface — интерфейс для моков и контроллеров
@@ -2,10 +2,11 @@ package usecases
 
 import (
 	"context"
-	"errors"
+	"log/slog"
 
 	"github.com/google/uuid"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
 )
 
 // PVZRepository — интерфейс для работы с ПВЗ (см. CA_c4_class.puml)
@@ -15,33 +16,66 @@ type PVZRepository interface {
 
 // CreatePVZUseCaseIface — интерфейс для моков и контроллеров
 type CreatePVZUseCaseIface interface {
-	Execute(ctx context.Context, user entities.User, city entities.City) (entities.PVZ, error)
+	Execute(ctx context.Context, user entities.User, city entities.City, details entities.PVZDetails) (entities.PVZ, error)
 }
 
 // CreatePVZUseCase — интерактор для создания ПВЗ
-// Только модератор может создать ПВЗ, город должен быть разрешён
+// Нужно право pvz:create, город должен быть активным в справочнике city
 
 type CreatePVZUseCase struct {
 	pvzRepo PVZRepository
+	catalog CatalogChecker
+	metrics BusinessMetrics
+	authz   *Authorizer
 }
 
-func NewCreatePVZUseCase(pvzRepo PVZRepository) *CreatePVZUseCase {
-	return &CreatePVZUseCase{pvzRepo: pvzRepo}
+func NewCreatePVZUseCase(pvzRepo PVZRepository, catalog CatalogChecker, metrics BusinessMetrics, authz *Authorizer) *CreatePVZUseCase {
+	return &CreatePVZUseCase{pvzRepo: pvzRepo, catalog: catalog, metrics: metrics, authz: authz}
 }
 
-// Execute создаёт новый ПВЗ, если город разрешён и роль — модератор
-func (uc *CreatePVZUseCase) Execute(ctx context.Context, user entities.User, city entities.City) (entities.PVZ, error) {
-	if !entities.ValidateUserRole(user.Role) || user.Role != entities.UserRoleModerator {
-		return entities.PVZ{}, errors.New("только модератор может создавать ПВЗ")
+// Execute создаёт новый ПВЗ, если город есть в справочнике, описание корректно и есть право pvz:create
+func (uc *CreatePVZUseCase) Execute(ctx context.Context, user entities.User, city entities.City, details entities.PVZDetails) (entities.PVZ, error) {
+	log := logger.FromContext(ctx)
+	if err := uc.authz.Authorize(ctx, user, entities.PermPVZCreate); err != nil {
+		return entities.PVZ{}, err
 	}
-	if !entities.ValidateCity(city) {
-		return entities.PVZ{}, errors.New("ПВЗ можно создать только в Москве, Санкт-Петербурге или Казани")
+	allowed, err := uc.catalog.IsAllowed(ctx, entities.CatalogCity, string(city))
+	if err != nil {
+		return entities.PVZ{}, err
+	}
+	if !allowed {
+		log.Warn("invalid city", slog.String("op", "CreatePVZ"), slog.String("city", string(city)))
+		return entities.PVZ{}, ErrInvalidCity
+	}
+	details, err = normalizePVZDetails(details)
+	if err != nil {
+		log.Warn("invalid pvz details", slog.String("op", "CreatePVZ"), slog.String("error", err.Error()))
+		return entities.PVZ{}, err
 	}
 	pvz := entities.PVZ{
 		ID:               entities.GenerateUUID(),
 		RegistrationDate: entities.NowUTC(),
 		City:             city,
 		Receptions:       []uuid.UUID{},
+		PVZDetails:       details,
+	}
+	saved, err := uc.pvzRepo.Save(ctx, pvz)
+	if err != nil {
+		return entities.PVZ{}, err
+	}
+	uc.metrics.IncPVZCreated()
+	log.Info("pvz created", slog.String("pvz_id", saved.ID.String()), slog.String("city", string(saved.City)))
+	return saved, nil
+}
+
+// normalizePVZDetails проверяет описание ПВЗ перед сохранением
+func normalizePVZDetails(details entities.PVZDetails) (entities.PVZDetails, error) {
+	if details.Location != nil && !details.Location.Valid() {
+		return entities.PVZDetails{}, ErrInvalidLocation
+	}
+	details, ok := details.Normalize()
+	if !ok {
+		return entities.PVZDetails{}, ErrInvalidPVZDetails
 	}
-	return uc.pvzRepo.Save(ctx, pvz)
+	return details, nil
 }
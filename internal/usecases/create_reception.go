@@ -2,11 +2,12 @@ package usecases
 
 import (
 	"context"
-	"errors"
+	"log/slog"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
 )
 
 // ReceptionRepository — интерфейс для работы с приёмками (см. CA_c4_class.puml)
@@ -15,35 +16,74 @@ type ReceptionRepository interface {
 	Save(ctx context.Context, reception entities.Reception) (entities.Reception, error)
 }
 
+// PVZRepositoryForReception — интерфейс для проверки ПВЗ перед созданием приёмки.
+// Разделяемая блокировка не даёт архивировать ПВЗ, пока приёмка создаётся
+type PVZRepositoryForReception interface {
+	GetByIDForShare(ctx context.Context, id uuid.UUID) (*entities.PVZ, error)
+}
+
 // CreateReceptionUseCase — интерактор для создания приёмки
-// Только pvz_staff может создать приёмку, на PVZ может быть только одна открытая приёмка
+// Нужно право reception:create и назначение на ПВЗ, ПВЗ существует и не в архиве,
+// на PVZ может быть только одна открытая приёмка
 
 type CreateReceptionUseCase struct {
-	repo ReceptionRepository
+	repo    ReceptionRepository
+	pvzRepo PVZRepositoryForReception
+	tx      TxManager
+	metrics BusinessMetrics
+	authz   *Authorizer
 }
 
-func NewCreateReceptionUseCase(repo ReceptionRepository) *CreateReceptionUseCase {
-	return &CreateReceptionUseCase{repo: repo}
+func NewCreateReceptionUseCase(repo ReceptionRepository, pvzRepo PVZRepositoryForReception, tx TxManager, metrics BusinessMetrics, authz *Authorizer) *CreateReceptionUseCase {
+	return &CreateReceptionUseCase{repo: repo, pvzRepo: pvzRepo, tx: tx, metrics: metrics, authz: authz}
 }
 
-// Execute создаёт новую приёмку, если нет открытой приёмки на PVZ и роль — pvz_staff
+// Execute создаёт новую приёмку, если ПВЗ работает, нет открытой приёмки на PVZ, есть право reception:create и пользователь назначен на ПВЗ
 func (uc *CreateReceptionUseCase) Execute(ctx context.Context, user entities.User, pvzID uuid.UUID) (entities.Reception, error) {
-	if user.Role != "pvz_staff" {
-		return entities.Reception{}, errors.New("только сотрудник ПВЗ может создавать приёмку")
+	log := logger.FromContext(ctx).With(slog.String("pvz_id", pvzID.String()))
+	if err := uc.authz.AuthorizePVZ(ctx, user, entities.PermReceptionCreate, pvzID); err != nil {
+		return entities.Reception{}, err
 	}
-	active, err := uc.repo.GetActive(ctx, pvzID)
+	var saved entities.Reception
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		pvz, err := uc.pvzRepo.GetByIDForShare(ctx, pvzID)
+		if err != nil {
+			return err
+		}
+		if pvz == nil {
+			return ErrPVZNotFound
+		}
+		if pvz.IsArchived() {
+			log.Warn("pvz archived", slog.String("op", "CreateReception"))
+			return ErrPVZArchived
+		}
+		active, err := uc.repo.GetActive(ctx, pvzID)
+		if err != nil {
+			return err
+		}
+		if active != nil && active.IsActive() {
+			log.Warn("reception already open", slog.String("reception_id", active.ID.String()))
+			return ErrReceptionAlreadyOpen
+		}
+		rec := entities.Reception{
+			ID:       uuid.New(),
+			PVZID:    pvzID,
+			Products: []uuid.UUID{},
+			Status:   entities.ReceptionInProgress,
+			DateTime: time.Now().UTC(),
+		}
+		saved, err = uc.repo.Save(ctx, rec)
+		return err
+	})
 	if err != nil {
 		return entities.Reception{}, err
 	}
-	if active != nil && active.IsOpen() {
-		return entities.Reception{}, errors.New("у ПВЗ уже есть открытая приёмка")
-	}
-	rec := entities.Reception{
-		ID:       uuid.New(),
-		PVZID:    pvzID,
-		Products: []uuid.UUID{},
-		Status:   entities.ReceptionInProgress,
-		DateTime: time.Now().UTC(),
-	}
-	return uc.repo.Save(ctx, rec)
+	uc.metrics.IncReceptionCreated()
+	log.Info("reception created", slog.String("reception_id", saved.ID.String()))
+	return saved, nil
+}
+
+// CreateReceptionUseCaseIface — интерфейс для моков и контроллеров
+type CreateReceptionUseCaseIface interface {
+	Execute(ctx context.Context, user entities.User, pvzID uuid.UUID) (entities.Reception, error)
 }
@@ -2,10 +2,11 @@ package usecases
 
 import (
 	"context"
-	"errors"
+	"log/slog"
 
 	"github.com/google/uuid"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
 )
 
 // ProductRepositoryForDelete — интерфейс для удаления товара (LIFO)
@@ -13,45 +14,55 @@ type ProductRepositoryForDelete interface {
 	DeleteLast(ctx context.Context, receptionID uuid.UUID) (*entities.Product, error)
 }
 
+// ReceptionRepositoryForDelete — интерфейс для получения незакрытой приёмки с блокировкой строки
+// (параллельные удаления по одной приёмке выполняются по очереди)
 type ReceptionRepositoryForDelete interface {
-	GetActive(ctx context.Context, pvzID uuid.UUID) (*entities.Reception, error)
+	GetActiveForUpdate(ctx context.Context, pvzID uuid.UUID) (*entities.Reception, error)
 }
 
 // DeleteLastProductUseCase — интерактор для удаления последнего товара из приёмки (LIFO)
 type DeleteLastProductUseCase struct {
 	productRepo   ProductRepositoryForDelete
 	receptionRepo ReceptionRepositoryForDelete
+	tx            TxManager
+	authz         *Authorizer
 }
 
-func NewDeleteLastProductUseCase(productRepo ProductRepositoryForDelete, receptionRepo ReceptionRepositoryForDelete) *DeleteLastProductUseCase {
-	return &DeleteLastProductUseCase{productRepo: productRepo, receptionRepo: receptionRepo}
+func NewDeleteLastProductUseCase(productRepo ProductRepositoryForDelete, receptionRepo ReceptionRepositoryForDelete, tx TxManager, authz *Authorizer) *DeleteLastProductUseCase {
+	return &DeleteLastProductUseCase{productRepo: productRepo, receptionRepo: receptionRepo, tx: tx, authz: authz}
 }
 
-// Execute удаляет последний товар из незакрытой приёмки, если роль pvz_staff
+// Execute удаляет последний товар из незакрытой приёмки, если есть право product:delete и пользователь назначен на ПВЗ.
+// Проверка приёмки и удаление — в одной транзакции под блокировкой приёмки
 func (uc *DeleteLastProductUseCase) Execute(ctx context.Context, user entities.User, pvzID uuid.UUID) error {
-	if user.Role != entities.UserRolePVZStaff {
-		return errors.New("только сотрудник ПВЗ может удалять товары")
-	}
-
-	rec, err := uc.receptionRepo.GetActive(ctx, pvzID)
-	if err != nil {
+	log := logger.FromContext(ctx).With(slog.String("pvz_id", pvzID.String()))
+	if err := uc.authz.AuthorizePVZ(ctx, user, entities.PermProductDelete, pvzID); err != nil {
 		return err
 	}
-	if rec == nil || !rec.IsOpen() {
-		return errors.New("нет открытой приёмки для удаления товара")
-	}
 
-	// Удаляем последний товар через репозиторий
-	product, err := uc.productRepo.DeleteLast(ctx, rec.ID)
-	if err != nil {
-		return err
-	}
+	return uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		rec, err := uc.receptionRepo.GetActiveForUpdate(ctx, pvzID)
+		if err != nil {
+			return err
+		}
+		if err := requireOpen(rec, ErrNoReceptionForDelete); err != nil {
+			log.Warn("no open reception", slog.String("op", "DeleteLastProduct"), slog.String("error", err.Error()))
+			return err
+		}
 
-	if product == nil {
-		return errors.New("нет товаров для удаления")
-	}
+		// Удаляем последний товар через репозиторий
+		product, err := uc.productRepo.DeleteLast(ctx, rec.ID)
+		if err != nil {
+			return err
+		}
 
-	return nil
+		if product == nil {
+			log.Warn("no products to delete", slog.String("reception_id", rec.ID.String()))
+			return ErrNoProductsToDelete
+		}
+		log.Info("product deleted", slog.String("reception_id", rec.ID.String()), slog.String("product_id", product.ID.String()))
+		return nil
+	})
 }
 
 // DeleteLastProductUseCaseIface — интерфейс для моков и контроллеров
@@ -0,0 +1,92 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
+)
+
+// ProductRepositoryForDeleteByID — интерфейс для удаления конкретного товара с записью в журнал удалений
+type ProductRepositoryForDeleteByID interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Product, error)
+	Delete(ctx context.Context, productID uuid.UUID) error
+	SaveRemoval(ctx context.Context, removal entities.ProductRemoval) error
+}
+
+// ReceptionRepositoryForDeleteByID — интерфейс для получения приёмки по id с блокировкой строки
+type ReceptionRepositoryForDeleteByID interface {
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.Reception, error)
+}
+
+// DeleteProductUseCase — интерактор для удаления товара по id из незакрытой приёмки
+type DeleteProductUseCase struct {
+	productRepo   ProductRepositoryForDeleteByID
+	receptionRepo ReceptionRepositoryForDeleteByID
+	tx            TxManager
+	authz         *Authorizer
+}
+
+func NewDeleteProductUseCase(productRepo ProductRepositoryForDeleteByID, receptionRepo ReceptionRepositoryForDeleteByID, tx TxManager, authz *Authorizer) *DeleteProductUseCase {
+	return &DeleteProductUseCase{productRepo: productRepo, receptionRepo: receptionRepo, tx: tx, authz: authz}
+}
+
+// Execute удаляет товар productID из приёмки receptionID, если есть право product:delete, пользователь назначен на ПВЗ приёмки и она не закрыта.
+// Удаление и запись в журнал (кто и что удалил) — в одной транзакции под блокировкой приёмки
+func (uc *DeleteProductUseCase) Execute(ctx context.Context, user entities.User, receptionID, productID uuid.UUID) (entities.ProductRemoval, error) {
+	log := logger.FromContext(ctx).With(slog.String("reception_id", receptionID.String()), slog.String("product_id", productID.String()))
+	if err := uc.authz.Authorize(ctx, user, entities.PermProductDelete); err != nil {
+		return entities.ProductRemoval{}, err
+	}
+
+	var removal entities.ProductRemoval
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		rec, err := uc.receptionRepo.GetByIDForUpdate(ctx, receptionID)
+		if err != nil {
+			return err
+		}
+		if rec == nil {
+			return ErrReceptionNotFound
+		}
+		if err := uc.authz.AuthorizePVZ(ctx, user, entities.PermProductDelete, rec.PVZID); err != nil {
+			return err
+		}
+		if err := requireOpen(rec, ErrReceptionClosed); err != nil {
+			log.Warn("reception is not open", slog.String("op", "DeleteProduct"), slog.String("status", string(rec.Status)))
+			return err
+		}
+
+		product, err := uc.productRepo.GetByID(ctx, productID)
+		if err != nil {
+			return err
+		}
+		if product == nil || product.ReceptionID != receptionID {
+			return ErrProductNotFound
+		}
+		if err := uc.productRepo.Delete(ctx, productID); err != nil {
+			return err
+		}
+
+		removal = entities.ProductRemoval{
+			ID:             entities.GenerateUUID(),
+			Product:        *product,
+			RemovedBy:      user.ID,
+			RemovedByEmail: user.Email,
+			RemovedAt:      time.Now().UTC(),
+		}
+		return uc.productRepo.SaveRemoval(ctx, removal)
+	})
+	if err != nil {
+		return entities.ProductRemoval{}, err
+	}
+	log.Info("product removed", slog.String("removed_by", user.ID.String()), slog.String("product_type", string(removal.Product.Type)))
+	return removal, nil
+}
+
+// DeleteProductUseCaseIface — интерфейс для моков и контроллеров
+type DeleteProductUseCaseIface interface {
+	Execute(ctx context.Context, user entities.User, receptionID, productID uuid.UUID) (entities.ProductRemoval, error)
+}
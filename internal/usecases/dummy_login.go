@@ -2,50 +2,51 @@ package usecases
 
 import (
 	"context"
-	"errors"
 	"time"
 
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
-	"github.com/nikborovets/backend-trainee-assignment-spring-2025/configs"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
 )
 
-// DummyLoginUseCase выдаёт токен по роли без проверки email/пароля.
+// UserRepositoryForDummyLogin — интерфейс для сохранения тестового пользователя роли
+type UserRepositoryForDummyLogin interface {
+	EnsureUser(ctx context.Context, user entities.User) error
+}
+
+// dummyUserNamespace — пространство имён для id тестовых пользователей /dummyLogin
+var dummyUserNamespace = uuid.MustParse("5b0f6c1e-3d2a-4f7b-9c8e-1a2b3c4d5e6f")
+
+// DummyLoginUseCase выдаёт токены по роли без проверки email/пароля.
 type DummyLoginUseCase struct {
-	jwtSecret []byte
+	users  UserRepositoryForDummyLogin
+	issuer *TokenIssuer
 }
 
-// NewDummyLoginUseCase создаёт DummyLoginUseCase с секретом для JWT из конфига.
-func NewDummyLoginUseCase(cfg *configs.Config) *DummyLoginUseCase {
-	return &DummyLoginUseCase{jwtSecret: []byte(cfg.JWTSecret)}
+// NewDummyLoginUseCase создаёт DummyLoginUseCase с репозиторием пользователей и выпускающим токены.
+func NewDummyLoginUseCase(users UserRepositoryForDummyLogin, issuer *TokenIssuer) *DummyLoginUseCase {
+	return &DummyLoginUseCase{users: users, issuer: issuer}
 }
 
-// Execute выдаёт JWT-токен для заданной роли. Возвращает ошибку, если роль невалидна.
-func (uc *DummyLoginUseCase) Execute(ctx context.Context, role entities.UserRole) (string, error) {
+// Execute открывает сессию тестового пользователя роли. У каждой роли один пользователь с постоянным id,
+// он хранится в users, чтобы его можно было, например, назначить на ПВЗ. Возвращает ошибку, если роль невалидна,
+// и ErrEmailTaken, если email тестового пользователя уже зарегистрирован другим пользователем.
+func (uc *DummyLoginUseCase) Execute(ctx context.Context, role entities.UserRole) (TokenPair, error) {
 	if !entities.ValidateUserRole(role) {
-		return "", ErrInvalidRole
+		return TokenPair{}, ErrInvalidRole
 	}
-
-	claims := jwt.MapClaims{
-		"sub":   uuid.NewString(),
-		"role":  string(role),
-		"email": "dummy@avito.ru",
-		"iat":   time.Now().Unix(),
-		"exp":   time.Now().Add(24 * time.Hour).Unix(),
+	user := entities.User{
+		ID:               uuid.NewSHA1(dummyUserNamespace, []byte(role)),
+		Role:             role,
+		Email:            "dummy-" + string(role) + "@avito.ru",
+		RegistrationDate: time.Now().UTC(),
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	jwtStr, err := token.SignedString(uc.jwtSecret)
-	if err != nil {
-		return "", err
+	if err := uc.users.EnsureUser(ctx, user); err != nil {
+		return TokenPair{}, err
 	}
-	return jwtStr, nil
+	return uc.issuer.StartSession(ctx, user)
 }
 
-// ErrInvalidRole возвращается, если роль невалидна.
-var ErrInvalidRole = errors.New("invalid user role")
-
 // DummyLoginUseCaseIface — интерфейс для моков и контроллеров
 type DummyLoginUseCaseIface interface {
-	Execute(ctx context.Context, role entities.UserRole) (string, error)
+	Execute(ctx context.Context, role entities.UserRole) (TokenPair, error)
 }
@@ -0,0 +1,76 @@
+package usecases
+
+import "errors"
+
+// Категории ошибок usecase. Каждая ошибка usecase оборачивает одну из них,
+// транспорт (HTTP, gRPC) выбирает статус через errors.Is, не разбирая текст
+var (
+	ErrValidation      = errors.New("validation error")
+	ErrUnauthorized    = errors.New("unauthorized")
+	ErrForbidden       = errors.New("forbidden")
+	ErrNotFound        = errors.New("not found")
+	ErrConflict        = errors.New("conflict")
+	ErrNoOpenReception = errors.New("no open reception")
+)
+
+// Error — типизированная ошибка usecase: категория + сообщение для клиента
+type Error struct {
+	Kind    error
+	Message string
+}
+
+// NewError создаёт ошибку usecase заданной категории
+func NewError(kind error, message string) *Error {
+	return &Error{Kind: kind, Message: message}
+}
+
+func (e *Error) Error() string { return e.Message }
+
+func (e *Error) Unwrap() error { return e.Kind }
+
+// Конкретные ошибки, на которые можно проверять через errors.Is
+var (
+	ErrInvalidRole            = NewError(ErrValidation, "invalid user role")
+	ErrInvalidCity            = NewError(ErrValidation, "города нет в справочнике или он отключён")
+	ErrInvalidProductType     = NewError(ErrValidation, "типа товара нет в справочнике или он отключён")
+	ErrCredentialsRequired    = NewError(ErrValidation, "email и пароль обязательны")
+	ErrInvalidCredentials     = NewError(ErrUnauthorized, "неверный email или пароль")
+	ErrRefreshTokenRequired   = NewError(ErrValidation, "нужно передать refresh-токен")
+	ErrInvalidRefreshToken    = NewError(ErrUnauthorized, "refresh-токен недействителен или истёк")
+	ErrSessionRevoked         = NewError(ErrUnauthorized, "сессия завершена, войдите заново")
+	ErrEmailTaken             = NewError(ErrConflict, "пользователь с таким email уже существует")
+	ErrReceptionAlreadyOpen   = NewError(ErrConflict, "у ПВЗ уже есть открытая приёмка")
+	ErrNoReceptionToClose     = NewError(ErrNoOpenReception, "нет открытой приёмки для закрытия")
+	ErrNoReceptionForProduct  = NewError(ErrNoOpenReception, "нет открытой приёмки для добавления товара")
+	ErrNoReceptionForDelete   = NewError(ErrNoOpenReception, "нет открытой приёмки для удаления товара")
+	ErrNoProductsToDelete     = NewError(ErrNotFound, "нет товаров для удаления")
+	ErrProductNotFound        = NewError(ErrNotFound, "товар не найден в приёмке")
+	ErrReceptionClosed        = NewError(ErrNoOpenReception, "приёмка закрыта, товары удалять нельзя")
+	ErrReceptionNotClosed     = NewError(ErrConflict, "приёмка не закрыта")
+	ErrReceptionPaused        = NewError(ErrNoOpenReception, "приёмка на паузе, сканирование остановлено")
+	ErrTransitionNotAllowed   = NewError(ErrConflict, "недопустимая смена статуса приёмки")
+	ErrReopenWindowExpired    = NewError(ErrConflict, "время, в которое можно переоткрыть приёмку, истекло")
+	ErrInvalidReason          = NewError(ErrValidation, "нужно указать причину не длиннее 500 символов")
+	ErrDuplicateBarcode       = NewError(ErrConflict, "товар с таким штрихкодом уже отсканирован в эту приёмку")
+	ErrInvalidBarcode         = NewError(ErrValidation, "штрихкод должен быть из печатных символов ASCII и не длиннее 64 символов")
+	ErrBarcodeRequired        = NewError(ErrValidation, "нужно передать штрихкод")
+	ErrInvalidBatchSize       = NewError(ErrValidation, "в пакете должно быть от 1 до 1000 товаров")
+	ErrPVZNotFound            = NewError(ErrNotFound, "ПВЗ не найден")
+	ErrReceptionNotFound      = NewError(ErrNotFound, "приёмка не найдена")
+	ErrInvalidReceptionStatus = NewError(ErrValidation, "неизвестный статус приёмки")
+	ErrPVZArchived            = NewError(ErrConflict, "ПВЗ в архиве")
+	ErrPVZHasOpenReception    = NewError(ErrConflict, "у ПВЗ есть открытая приёмка, закройте её перед архивацией")
+	ErrEmptyPVZPatch          = NewError(ErrValidation, "нужно передать хотя бы одно поле")
+	ErrInvalidPVZDetails      = NewError(ErrValidation, "название, адрес или часы работы ПВЗ слишком длинные")
+	ErrInvalidLocation        = NewError(ErrValidation, "координаты вне допустимого диапазона")
+	ErrInvalidRadius          = NewError(ErrValidation, "радиус поиска должен быть от 1 до 50000 метров")
+	ErrUnknownCatalog         = NewError(ErrNotFound, "справочник не найден")
+	ErrCatalogEntryNotFound   = NewError(ErrNotFound, "элемент справочника не найден")
+	ErrCatalogEntryExists     = NewError(ErrConflict, "элемент справочника с таким названием уже существует")
+	ErrInvalidCatalogName     = NewError(ErrValidation, "название должно быть непустым и не длиннее 100 символов")
+	ErrEmptyCatalogPatch      = NewError(ErrValidation, "нужно передать name или active")
+	ErrNotAssignedToPVZ       = NewError(ErrForbidden, "сотрудник не назначен на этот ПВЗ")
+	ErrStaffNotAssigned       = NewError(ErrNotFound, "сотрудник не назначен на этот ПВЗ")
+	ErrUserNotFound           = NewError(ErrNotFound, "пользователь не найден")
+	ErrNotPVZStaff            = NewError(ErrValidation, "на ПВЗ можно назначить только пользователя с ролью pvz_staff")
+)
@@ -0,0 +1,47 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+)
+
+// NearestMaxRadius — максимальный радиус поиска ближайших ПВЗ, в метрах
+const NearestMaxRadius = 50000
+
+// PVZRepositoryForNearest — интерфейс для поиска ближайших ПВЗ
+type PVZRepositoryForNearest interface {
+	Nearest(ctx context.Context, point entities.GeoPoint, radius float64, limit int) ([]entities.NearestPVZ, error)
+}
+
+// FindNearestPVZsUseCaseIface — интерфейс для моков и контроллеров
+type FindNearestPVZsUseCaseIface interface {
+	Execute(ctx context.Context, user entities.User, point entities.GeoPoint, radius float64, limit int) ([]entities.NearestPVZ, error)
+}
+
+// FindNearestPVZsUseCase — интерактор для поиска ближайших к точке работающих ПВЗ
+// Нужно право pvz:nearest, по умолчанию оно у всех ролей: клиенту — найти, куда идти, курьеру и сотруднику — построить маршрут
+
+type FindNearestPVZsUseCase struct {
+	repo  PVZRepositoryForNearest
+	authz *Authorizer
+}
+
+func NewFindNearestPVZsUseCase(repo PVZRepositoryForNearest, authz *Authorizer) *FindNearestPVZsUseCase {
+	return &FindNearestPVZsUseCase{repo: repo, authz: authz}
+}
+
+// Execute возвращает до limit ПВЗ не дальше radius метров от точки, ближайшие первыми.
+// Архивные ПВЗ и ПВЗ без координат не возвращаются
+func (uc *FindNearestPVZsUseCase) Execute(ctx context.Context, user entities.User, point entities.GeoPoint, radius float64, limit int) ([]entities.NearestPVZ, error) {
+	if err := uc.authz.Authorize(ctx, user, entities.PermPVZNearest); err != nil {
+		return nil, err
+	}
+	if !point.Valid() {
+		return nil, ErrInvalidLocation
+	}
+	if !(radius >= 1 && radius <= NearestMaxRadius) {
+		return nil, ErrInvalidRadius
+	}
+	return uc.repo.Nearest(ctx, point, radius, limit)
+}
@@ -0,0 +1,42 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+)
+
+// ProductRepositoryForBarcode — интерфейс для поиска посылки по штрихкоду
+type ProductRepositoryForBarcode interface {
+	FindByBarcode(ctx context.Context, barcode string) ([]entities.ProductLocation, error)
+}
+
+// FindProductsByBarcodeUseCaseIface — интерфейс для моков и контроллеров
+type FindProductsByBarcodeUseCaseIface interface {
+	Execute(ctx context.Context, user entities.User, barcode string) ([]entities.ProductLocation, error)
+}
+
+// FindProductsByBarcodeUseCase — интерактор для поиска ПВЗ и приёмки, где находится посылка
+type FindProductsByBarcodeUseCase struct {
+	repo  ProductRepositoryForBarcode
+	authz *Authorizer
+}
+
+func NewFindProductsByBarcodeUseCase(repo ProductRepositoryForBarcode, authz *Authorizer) *FindProductsByBarcodeUseCase {
+	return &FindProductsByBarcodeUseCase{repo: repo, authz: authz}
+}
+
+// Execute возвращает все приёмы посылки, новые первыми, если есть право product:read
+func (uc *FindProductsByBarcodeUseCase) Execute(ctx context.Context, user entities.User, barcode string) ([]entities.ProductLocation, error) {
+	if err := uc.authz.Authorize(ctx, user, entities.PermProductRead); err != nil {
+		return nil, err
+	}
+	barcode, ok := entities.NormalizeBarcode(barcode)
+	if !ok {
+		return nil, ErrInvalidBarcode
+	}
+	if barcode == "" {
+		return nil, ErrBarcodeRequired
+	}
+	return uc.repo.FindByBarcode(ctx, barcode)
+}
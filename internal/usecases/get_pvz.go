@@ -0,0 +1,43 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+)
+
+// PVZRepositoryForGet — интерфейс для получения ПВЗ по id
+type PVZRepositoryForGet interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.PVZ, error)
+}
+
+// GetPVZUseCaseIface — интерфейс для моков и контроллеров
+type GetPVZUseCaseIface interface {
+	Execute(ctx context.Context, user entities.User, pvzID uuid.UUID) (entities.PVZ, error)
+}
+
+// GetPVZUseCase — интерактор для получения ПВЗ, в том числе архивного
+type GetPVZUseCase struct {
+	repo  PVZRepositoryForGet
+	authz *Authorizer
+}
+
+func NewGetPVZUseCase(repo PVZRepositoryForGet, authz *Authorizer) *GetPVZUseCase {
+	return &GetPVZUseCase{repo: repo, authz: authz}
+}
+
+// Execute возвращает ПВЗ, если есть право pvz:read
+func (uc *GetPVZUseCase) Execute(ctx context.Context, user entities.User, pvzID uuid.UUID) (entities.PVZ, error) {
+	if err := uc.authz.Authorize(ctx, user, entities.PermPVZRead); err != nil {
+		return entities.PVZ{}, err
+	}
+	pvz, err := uc.repo.GetByID(ctx, pvzID)
+	if err != nil {
+		return entities.PVZ{}, err
+	}
+	if pvz == nil {
+		return entities.PVZ{}, ErrPVZNotFound
+	}
+	return *pvz, nil
+}
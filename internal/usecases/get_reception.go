@@ -0,0 +1,53 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+)
+
+// ReceptionRepositoryForGet — интерфейс для получения приёмки по id
+type ReceptionRepositoryForGet interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Reception, error)
+}
+
+// ProductRepositoryForList — интерфейс для товаров приёмки в порядке добавления
+type ProductRepositoryForList interface {
+	ListByReception(ctx context.Context, receptionID uuid.UUID) ([]entities.Product, error)
+}
+
+// GetReceptionUseCaseIface — интерфейс для моков и контроллеров
+type GetReceptionUseCaseIface interface {
+	Execute(ctx context.Context, user entities.User, receptionID uuid.UUID) (entities.ReceptionWithProducts, error)
+}
+
+// GetReceptionUseCase — интерактор для получения одной приёмки вместе с товарами
+type GetReceptionUseCase struct {
+	repo        ReceptionRepositoryForGet
+	productRepo ProductRepositoryForList
+	authz       *Authorizer
+}
+
+func NewGetReceptionUseCase(repo ReceptionRepositoryForGet, productRepo ProductRepositoryForList, authz *Authorizer) *GetReceptionUseCase {
+	return &GetReceptionUseCase{repo: repo, productRepo: productRepo, authz: authz}
+}
+
+// Execute возвращает приёмку с товарами, если есть право reception:read
+func (uc *GetReceptionUseCase) Execute(ctx context.Context, user entities.User, receptionID uuid.UUID) (entities.ReceptionWithProducts, error) {
+	if err := uc.authz.Authorize(ctx, user, entities.PermReceptionRead); err != nil {
+		return entities.ReceptionWithProducts{}, err
+	}
+	rec, err := uc.repo.GetByID(ctx, receptionID)
+	if err != nil {
+		return entities.ReceptionWithProducts{}, err
+	}
+	if rec == nil {
+		return entities.ReceptionWithProducts{}, ErrReceptionNotFound
+	}
+	products, err := uc.productRepo.ListByReception(ctx, receptionID)
+	if err != nil {
+		return entities.ReceptionWithProducts{}, err
+	}
+	return entities.ReceptionWithProducts{Reception: *rec, Products: products}, nil
+}
@@ -0,0 +1,48 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+)
+
+// ListCatalogUseCaseIface — интерфейс для моков и контроллеров
+type ListCatalogUseCaseIface interface {
+	Execute(ctx context.Context, user entities.User, kind entities.CatalogKind) ([]entities.CatalogEntry, error)
+}
+
+// ListCatalogUseCase — интерактор для получения справочника
+// С правом catalog:manage видны и отключённые элементы, без него — только активные
+
+type ListCatalogUseCase struct {
+	repo  CatalogRepository
+	authz *Authorizer
+}
+
+func NewListCatalogUseCase(repo CatalogRepository, authz *Authorizer) *ListCatalogUseCase {
+	return &ListCatalogUseCase{repo: repo, authz: authz}
+}
+
+// Execute возвращает элементы справочника kind, отсортированные по названию
+func (uc *ListCatalogUseCase) Execute(ctx context.Context, user entities.User, kind entities.CatalogKind) ([]entities.CatalogEntry, error) {
+	if err := uc.authz.Authorize(ctx, user, entities.PermCatalogRead); err != nil {
+		return nil, err
+	}
+	if !entities.ValidateCatalogKind(kind) {
+		return nil, ErrUnknownCatalog
+	}
+	list, err := uc.repo.List(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+	if uc.authz.Can(user.Role, entities.PermCatalogManage) {
+		return list, nil
+	}
+	active := make([]entities.CatalogEntry, 0, len(list))
+	for _, e := range list {
+		if e.Active {
+			active = append(active, e)
+		}
+	}
+	return active, nil
+}
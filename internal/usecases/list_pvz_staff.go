@@ -0,0 +1,44 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+)
+
+// StaffAssignmentRepositoryForList — интерфейс для получения назначенных на ПВЗ сотрудников
+type StaffAssignmentRepositoryForList interface {
+	ListByPVZ(ctx context.Context, pvzID uuid.UUID) ([]entities.StaffAssignment, error)
+}
+
+// ListPVZStaffUseCaseIface — интерфейс для моков и контроллеров
+type ListPVZStaffUseCaseIface interface {
+	Execute(ctx context.Context, user entities.User, pvzID uuid.UUID) ([]entities.StaffAssignment, error)
+}
+
+// ListPVZStaffUseCase — интерактор для получения сотрудников, назначенных на ПВЗ
+type ListPVZStaffUseCase struct {
+	pvzRepo PVZRepositoryForGet
+	repo    StaffAssignmentRepositoryForList
+	authz   *Authorizer
+}
+
+func NewListPVZStaffUseCase(pvzRepo PVZRepositoryForGet, repo StaffAssignmentRepositoryForList, authz *Authorizer) *ListPVZStaffUseCase {
+	return &ListPVZStaffUseCase{pvzRepo: pvzRepo, repo: repo, authz: authz}
+}
+
+// Execute возвращает назначения на ПВЗ в порядке назначения, если есть право pvz:manage_staff
+func (uc *ListPVZStaffUseCase) Execute(ctx context.Context, user entities.User, pvzID uuid.UUID) ([]entities.StaffAssignment, error) {
+	if err := uc.authz.Authorize(ctx, user, entities.PermPVZManageStaff); err != nil {
+		return nil, err
+	}
+	pvz, err := uc.pvzRepo.GetByID(ctx, pvzID)
+	if err != nil {
+		return nil, err
+	}
+	if pvz == nil {
+		return nil, ErrPVZNotFound
+	}
+	return uc.repo.ListByPVZ(ctx, pvzID)
+}
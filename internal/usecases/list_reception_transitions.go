@@ -0,0 +1,44 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+)
+
+// ReceptionRepositoryForTransitions — интерфейс для истории статусов приёмки
+type ReceptionRepositoryForTransitions interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Reception, error)
+	ListTransitions(ctx context.Context, receptionID uuid.UUID) ([]entities.ReceptionTransition, error)
+}
+
+// ListReceptionTransitionsUseCaseIface — интерфейс для моков и контроллеров
+type ListReceptionTransitionsUseCaseIface interface {
+	Execute(ctx context.Context, user entities.User, receptionID uuid.UUID) ([]entities.ReceptionTransition, error)
+}
+
+// ListReceptionTransitionsUseCase — интерактор для истории закрытий и переоткрытий приёмки
+type ListReceptionTransitionsUseCase struct {
+	repo  ReceptionRepositoryForTransitions
+	authz *Authorizer
+}
+
+func NewListReceptionTransitionsUseCase(repo ReceptionRepositoryForTransitions, authz *Authorizer) *ListReceptionTransitionsUseCase {
+	return &ListReceptionTransitionsUseCase{repo: repo, authz: authz}
+}
+
+// Execute возвращает историю статусов приёмки по порядку, если есть право reception:read
+func (uc *ListReceptionTransitionsUseCase) Execute(ctx context.Context, user entities.User, receptionID uuid.UUID) ([]entities.ReceptionTransition, error) {
+	if err := uc.authz.Authorize(ctx, user, entities.PermReceptionRead); err != nil {
+		return nil, err
+	}
+	rec, err := uc.repo.GetByID(ctx, receptionID)
+	if err != nil {
+		return nil, err
+	}
+	if rec == nil {
+		return nil, ErrReceptionNotFound
+	}
+	return uc.repo.ListTransitions(ctx, receptionID)
+}
@@ -0,0 +1,57 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+)
+
+// ReceptionFilter — фильтры и пагинация истории приёмок ПВЗ; nil — без фильтра, Limit = 0 — без пагинации
+type ReceptionFilter struct {
+	Status    *entities.ReceptionStatus
+	StartDate *time.Time
+	EndDate   *time.Time
+	Page      int
+	Limit     int
+}
+
+// ReceptionRepositoryForHistory — интерфейс для истории приёмок ПВЗ
+type ReceptionRepositoryForHistory interface {
+	ListByPVZ(ctx context.Context, pvzID uuid.UUID, filter ReceptionFilter) ([]entities.Reception, error)
+}
+
+// ListReceptionsUseCaseIface — интерфейс для моков и контроллеров
+type ListReceptionsUseCaseIface interface {
+	Execute(ctx context.Context, user entities.User, pvzID uuid.UUID, filter ReceptionFilter) ([]entities.Reception, error)
+}
+
+// ListReceptionsUseCase — интерактор для постраничной истории приёмок ПВЗ, в том числе архивного
+type ListReceptionsUseCase struct {
+	pvzRepo PVZRepositoryForGet
+	repo    ReceptionRepositoryForHistory
+	authz   *Authorizer
+}
+
+func NewListReceptionsUseCase(pvzRepo PVZRepositoryForGet, repo ReceptionRepositoryForHistory, authz *Authorizer) *ListReceptionsUseCase {
+	return &ListReceptionsUseCase{pvzRepo: pvzRepo, repo: repo, authz: authz}
+}
+
+// Execute возвращает приёмки ПВЗ, новые первыми, если есть право reception:read
+func (uc *ListReceptionsUseCase) Execute(ctx context.Context, user entities.User, pvzID uuid.UUID, filter ReceptionFilter) ([]entities.Reception, error) {
+	if err := uc.authz.Authorize(ctx, user, entities.PermReceptionRead); err != nil {
+		return nil, err
+	}
+	if filter.Status != nil && !entities.ValidateReceptionStatus(*filter.Status) {
+		return nil, ErrInvalidReceptionStatus
+	}
+	pvz, err := uc.pvzRepo.GetByID(ctx, pvzID)
+	if err != nil {
+		return nil, err
+	}
+	if pvz == nil {
+		return nil, ErrPVZNotFound
+	}
+	return uc.repo.ListByPVZ(ctx, pvzID, filter)
+}
@@ -2,12 +2,8 @@ package usecases
 
 import (
 	"context"
-	"errors"
 	"strings"
-	"time"
 
-	"github.com/golang-jwt/jwt/v5"
-	"github.com/nikborovets/backend-trainee-assignment-spring-2025/configs"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -19,47 +15,35 @@ type UserRepositoryForLogin interface {
 
 // LoginUseCaseIface — интерфейс для моков и контроллеров
 type LoginUseCaseIface interface {
-	Execute(ctx context.Context, email, password string) (string, error)
+	Execute(ctx context.Context, email, password string) (TokenPair, error)
 }
 
-// LoginUseCase — интерактор для логина по email+пароль, возвращает JWT.
+// LoginUseCase — интерактор для логина по email+пароль, открывает сессию и возвращает пару токенов.
 type LoginUseCase struct {
-	repo      UserRepositoryForLogin
-	jwtSecret []byte
+	repo   UserRepositoryForLogin
+	issuer *TokenIssuer
 }
 
-// NewLoginUseCase создаёт LoginUseCase с репозиторием и секретом JWT.
-func NewLoginUseCase(repo UserRepositoryForLogin, cfg *configs.Config) *LoginUseCase {
-	return &LoginUseCase{repo: repo, jwtSecret: []byte(cfg.JWTSecret)}
+// NewLoginUseCase создаёт LoginUseCase с репозиторием пользователей и выпускающим токены.
+func NewLoginUseCase(repo UserRepositoryForLogin, issuer *TokenIssuer) *LoginUseCase {
+	return &LoginUseCase{repo: repo, issuer: issuer}
 }
 
-// Execute логинит пользователя по email+пароль, возвращает JWT-токен.
-func (uc *LoginUseCase) Execute(ctx context.Context, email, password string) (string, error) {
+// Execute логинит пользователя по email+пароль, возвращает access- и refresh-токены.
+func (uc *LoginUseCase) Execute(ctx context.Context, email, password string) (TokenPair, error) {
 	email = strings.TrimSpace(strings.ToLower(email))
 	if email == "" || password == "" {
-		return "", errors.New("email и пароль обязательны")
+		return TokenPair{}, ErrCredentialsRequired
 	}
 	user, hash, err := uc.repo.GetByEmail(ctx, email)
 	if err != nil {
-		return "", err
+		return TokenPair{}, err
 	}
 	if user == nil {
-		return "", errors.New("user not found")
+		return TokenPair{}, ErrInvalidCredentials
 	}
 	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
-		return "", errors.New("invalid password")
+		return TokenPair{}, ErrInvalidCredentials
 	}
-	claims := jwt.MapClaims{
-		"sub":   user.ID.String(),
-		"role":  string(user.Role),
-		"email": user.Email,
-		"iat":   time.Now().Unix(),
-		"exp":   time.Now().Add(24 * time.Hour).Unix(),
-	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	jwtStr, err := token.SignedString(uc.jwtSecret)
-	if err != nil {
-		return "", err
-	}
-	return jwtStr, nil
+	return uc.issuer.StartSession(ctx, *user)
 }
@@ -0,0 +1,39 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
+)
+
+// SessionRepositoryForLogout — интерфейс для отзыва сессии
+type SessionRepositoryForLogout interface {
+	RevokeSession(ctx context.Context, id uuid.UUID, revokedAt time.Time) error
+}
+
+// LogoutUseCase — интерактор для выхода: сессия отзывается, её access- и refresh-токены больше не принимаются
+type LogoutUseCase struct {
+	repo SessionRepositoryForLogout
+}
+
+// NewLogoutUseCase создаёт LogoutUseCase
+func NewLogoutUseCase(repo SessionRepositoryForLogout) *LogoutUseCase {
+	return &LogoutUseCase{repo: repo}
+}
+
+// Execute отзывает сессию, к которой относится access-токен запроса. Повторный выход не ошибка
+func (uc *LogoutUseCase) Execute(ctx context.Context, sessionID uuid.UUID) error {
+	if err := uc.repo.RevokeSession(ctx, sessionID, time.Now().UTC()); err != nil {
+		return err
+	}
+	logger.FromContext(ctx).Info("session revoked", slog.String("session_id", sessionID.String()))
+	return nil
+}
+
+// LogoutUseCaseIface — интерфейс для моков и контроллеров
+type LogoutUseCaseIface interface {
+	Execute(ctx context.Context, sessionID uuid.UUID) error
+}
@@ -0,0 +1,16 @@
+package usecases
+
+// BusinessMetrics — интерфейс для сбора бизнес-метрик (реализация — Prometheus в infrastructure)
+// Метрика увеличивается только после успешного выполнения usecase
+type BusinessMetrics interface {
+	IncPVZCreated()
+	IncReceptionCreated()
+	IncProductAdded()
+}
+
+// NopMetrics — пустая реализация BusinessMetrics (для тестов и запуска без метрик)
+type NopMetrics struct{}
+
+func (NopMetrics) IncPVZCreated()       {}
+func (NopMetrics) IncReceptionCreated() {}
+func (NopMetrics) IncProductAdded()     {}
@@ -0,0 +1,91 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
+)
+
+// SessionRepositoryForRefresh — интерфейс для обмена refresh-токена на новую пару
+type SessionRepositoryForRefresh interface {
+	SessionRepositoryForIssue
+	GetRefreshTokenForUpdate(ctx context.Context, hash string) (*entities.RefreshToken, error)
+	MarkRefreshTokenUsed(ctx context.Context, hash string, usedAt time.Time) error
+	GetSession(ctx context.Context, id uuid.UUID) (*entities.Session, error)
+	RevokeSession(ctx context.Context, id uuid.UUID, revokedAt time.Time) error
+}
+
+// RefreshTokenUseCase — интерактор для ротации токенов: refresh-токен одноразовый,
+// взамен выдаётся новая пара в той же сессии
+type RefreshTokenUseCase struct {
+	repo   SessionRepositoryForRefresh
+	issuer *TokenIssuer
+	tx     TxManager
+}
+
+// NewRefreshTokenUseCase создаёт RefreshTokenUseCase
+func NewRefreshTokenUseCase(repo SessionRepositoryForRefresh, issuer *TokenIssuer, tx TxManager) *RefreshTokenUseCase {
+	return &RefreshTokenUseCase{repo: repo, issuer: issuer, tx: tx}
+}
+
+// Execute обменивает refresh-токен на новую пару. Токен должен быть неиспользованным, не истёкшим,
+// а сессия — не отозванной. Повторное предъявление использованного токена значит, что его украли:
+// сессия отзывается целиком, и все её access-токены перестают приниматься
+func (uc *RefreshTokenUseCase) Execute(ctx context.Context, refreshToken string) (TokenPair, error) {
+	if refreshToken == "" {
+		return TokenPair{}, ErrRefreshTokenRequired
+	}
+	var (
+		pair   TokenPair
+		reused *entities.Session
+	)
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		hash := HashRefreshToken(refreshToken)
+		token, err := uc.repo.GetRefreshTokenForUpdate(ctx, hash)
+		if err != nil {
+			return err
+		}
+		if token == nil {
+			return ErrInvalidRefreshToken
+		}
+		session, err := uc.repo.GetSession(ctx, token.SessionID)
+		if err != nil {
+			return err
+		}
+		if session == nil || session.IsRevoked() {
+			return ErrSessionRevoked
+		}
+		now := time.Now().UTC()
+		if token.UsedAt != nil {
+			// отзыв сессии должен зафиксироваться, поэтому транзакция завершается без ошибки
+			reused = session
+			return uc.repo.RevokeSession(ctx, session.ID, now)
+		}
+		if token.IsExpired(now) {
+			return ErrInvalidRefreshToken
+		}
+		if err := uc.repo.MarkRefreshTokenUsed(ctx, hash, now); err != nil {
+			return err
+		}
+		pair, err = uc.issuer.issue(ctx, *session)
+		return err
+	})
+	if err != nil {
+		return TokenPair{}, err
+	}
+	if reused != nil {
+		logger.FromContext(ctx).Warn("refresh token reuse, session revoked",
+			slog.String("session_id", reused.ID.String()), slog.String("user_id", reused.UserID.String()))
+		return TokenPair{}, ErrSessionRevoked
+	}
+	return pair, nil
+}
+
+// RefreshTokenUseCaseIface — интерфейс для моков и контроллеров
+type RefreshTokenUseCaseIface interface {
+	Execute(ctx context.Context, refreshToken string) (TokenPair, error)
+}
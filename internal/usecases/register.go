@@ -2,7 +2,6 @@ package usecases
 
 import (
 	"context"
-	"errors"
 	"strings"
 	"time"
 
@@ -35,17 +34,17 @@ func NewRegisterUseCase(repo UserRepositoryForRegister) *RegisterUseCase {
 func (uc *RegisterUseCase) Execute(ctx context.Context, email, password string, role entities.UserRole) (entities.User, error) {
 	email = strings.TrimSpace(strings.ToLower(email))
 	if email == "" || password == "" {
-		return entities.User{}, errors.New("email и пароль обязательны")
+		return entities.User{}, ErrCredentialsRequired
 	}
 	if !entities.ValidateUserRole(role) {
-		return entities.User{}, errors.New("некорректная роль")
+		return entities.User{}, ErrInvalidRole
 	}
 	exists, err := uc.repo.GetByEmail(ctx, email)
 	if err != nil {
 		return entities.User{}, err
 	}
 	if exists != nil {
-		return entities.User{}, errors.New("пользователь с таким email уже существует")
+		return entities.User{}, ErrEmailTaken
 	}
 	passwordHash, err := hashPassword(password)
 	if err != nil {
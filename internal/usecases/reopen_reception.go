@@ -0,0 +1,111 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
+)
+
+// ReceptionRepositoryForReopen — интерфейс для переоткрытия приёмки и записи истории статусов
+type ReceptionRepositoryForReopen interface {
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.Reception, error)
+	GetActive(ctx context.Context, pvzID uuid.UUID) (*entities.Reception, error)
+	Save(ctx context.Context, reception entities.Reception) (entities.Reception, error)
+	SaveTransition(ctx context.Context, transition entities.ReceptionTransition) error
+}
+
+// ReopenReceptionUseCase — интерактор для переоткрытия закрытой приёмки модератором
+// (сотрудник закрыл приёмку раньше, чем закончилась разгрузка)
+type ReopenReceptionUseCase struct {
+	repo    ReceptionRepositoryForReopen
+	pvzRepo PVZRepositoryForReception
+	tx      TxManager
+	window  time.Duration
+	authz   *Authorizer
+}
+
+// NewReopenReceptionUseCase — window: сколько времени после закрытия приёмку ещё можно переоткрыть
+func NewReopenReceptionUseCase(repo ReceptionRepositoryForReopen, pvzRepo PVZRepositoryForReception, tx TxManager, window time.Duration, authz *Authorizer) *ReopenReceptionUseCase {
+	return &ReopenReceptionUseCase{repo: repo, pvzRepo: pvzRepo, tx: tx, window: window, authz: authz}
+}
+
+// Execute переоткрывает приёмку, если есть право reception:reopen, причина указана, приёмка закрыта не раньше window назад,
+// ПВЗ не в архиве и на нём нет другой открытой приёмки. Переоткрытие пишется в историю статусов вместе с причиной
+func (uc *ReopenReceptionUseCase) Execute(ctx context.Context, user entities.User, receptionID uuid.UUID, reason string) (entities.Reception, error) {
+	log := logger.FromContext(ctx).With(slog.String("reception_id", receptionID.String()))
+	if err := uc.authz.Authorize(ctx, user, entities.PermReceptionReopen); err != nil {
+		return entities.Reception{}, err
+	}
+	reason, ok := entities.NormalizeReceptionReason(reason)
+	if !ok {
+		return entities.Reception{}, ErrInvalidReason
+	}
+
+	var reopened entities.Reception
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		rec, err := uc.repo.GetByIDForUpdate(ctx, receptionID)
+		if err != nil {
+			return err
+		}
+		if rec == nil {
+			return ErrReceptionNotFound
+		}
+		if rec.Status != entities.ReceptionClosed {
+			return ErrReceptionNotClosed
+		}
+		now := time.Now().UTC()
+		if !rec.ClosedWithin(now, uc.window) {
+			log.Warn("reopen window expired", slog.String("op", "ReopenReception"), slog.Duration("window", uc.window))
+			return ErrReopenWindowExpired
+		}
+		pvz, err := uc.pvzRepo.GetByIDForShare(ctx, rec.PVZID)
+		if err != nil {
+			return err
+		}
+		if pvz == nil {
+			return ErrPVZNotFound
+		}
+		if pvz.IsArchived() {
+			return ErrPVZArchived
+		}
+		active, err := uc.repo.GetActive(ctx, rec.PVZID)
+		if err != nil {
+			return err
+		}
+		if active != nil && active.IsActive() {
+			log.Warn("reception already open", slog.String("active_reception_id", active.ID.String()))
+			return ErrReceptionAlreadyOpen
+		}
+		if err := rec.Reopen(); err != nil {
+			return ErrReceptionNotClosed
+		}
+		// Save отдаёт ErrReceptionAlreadyOpen, если параллельно на ПВЗ открыли другую приёмку
+		reopened, err = uc.repo.Save(ctx, *rec)
+		if err != nil {
+			return err
+		}
+		return uc.repo.SaveTransition(ctx, entities.ReceptionTransition{
+			ID:          entities.GenerateUUID(),
+			ReceptionID: rec.ID,
+			From:        entities.ReceptionClosed,
+			To:          entities.ReceptionInProgress,
+			ChangedBy:   user.ID,
+			Reason:      reason,
+			ChangedAt:   now,
+		})
+	})
+	if err != nil {
+		return entities.Reception{}, err
+	}
+	log.Info("reception reopened", slog.String("pvz_id", reopened.PVZID.String()), slog.String("moderator_id", user.ID.String()))
+	return reopened, nil
+}
+
+// ReopenReceptionUseCaseIface — интерфейс для моков и контроллеров
+type ReopenReceptionUseCaseIface interface {
+	Execute(ctx context.Context, user entities.User, receptionID uuid.UUID, reason string) (entities.Reception, error)
+}
@@ -0,0 +1,46 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
+)
+
+// SessionRepositoryForRevokeUser — интерфейс для отзыва всех сессий пользователя
+type SessionRepositoryForRevokeUser interface {
+	RevokeUserSessions(ctx context.Context, userID uuid.UUID, revokedAt time.Time) (int64, error)
+}
+
+// RevokeUserSessionsUseCaseIface — интерфейс для моков и контроллеров
+type RevokeUserSessionsUseCaseIface interface {
+	Execute(ctx context.Context, user entities.User, userID uuid.UUID) error
+}
+
+// RevokeUserSessionsUseCase — интерактор для принудительного выхода пользователя со всех устройств
+// (например, при утечке пароля или увольнении сотрудника)
+type RevokeUserSessionsUseCase struct {
+	repo  SessionRepositoryForRevokeUser
+	authz *Authorizer
+}
+
+func NewRevokeUserSessionsUseCase(repo SessionRepositoryForRevokeUser, authz *Authorizer) *RevokeUserSessionsUseCase {
+	return &RevokeUserSessionsUseCase{repo: repo, authz: authz}
+}
+
+// Execute отзывает все активные сессии userID, если есть право session:revoke: их access- и
+// refresh-токены больше не принимаются. Пользователь без активных сессий — не ошибка
+func (uc *RevokeUserSessionsUseCase) Execute(ctx context.Context, user entities.User, userID uuid.UUID) error {
+	if err := uc.authz.Authorize(ctx, user, entities.PermSessionRevoke); err != nil {
+		return err
+	}
+	revoked, err := uc.repo.RevokeUserSessions(ctx, userID, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	logger.FromContext(ctx).Info("user sessions revoked", slog.String("user_id", userID.String()), slog.String("moderator_id", user.ID.String()), slog.Int64("count", revoked))
+	return nil
+}
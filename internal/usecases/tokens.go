@@ -0,0 +1,132 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/configs"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+)
+
+// TokenPair — выданные клиенту токены: короткий access (JWT) и одноразовый refresh
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    time.Duration // сколько живёт access-токен
+	UserID       uuid.UUID     // владелец сессии, совпадает с sub access-токена
+}
+
+// AccessClaims — claims access-токена. sub — id пользователя, sid — id сессии
+type AccessClaims struct {
+	Role      entities.UserRole `json:"role"`
+	Email     string            `json:"email,omitempty"`
+	SessionID string            `json:"sid"`
+	jwt.RegisteredClaims
+}
+
+// SessionRepositoryForIssue — интерфейс для создания сессии и её refresh-токенов
+type SessionRepositoryForIssue interface {
+	CreateSession(ctx context.Context, session entities.Session) error
+	SaveRefreshToken(ctx context.Context, token entities.RefreshToken) error
+}
+
+// TokenIssuer выпускает пары токенов для /login, /dummyLogin и /token/refresh
+type TokenIssuer struct {
+	sessions   SessionRepositoryForIssue
+	kid        string
+	method     jwt.SigningMethod
+	key        any
+	issuer     string
+	audience   string
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+// NewTokenIssuer создаёт TokenIssuer с хранилищем сессий; ключ подписи (активный kid) и его алгоритм
+// (HS256, RS256 или EdDSA), iss, aud и время жизни токенов берутся из конфига
+func NewTokenIssuer(sessions SessionRepositoryForIssue, cfg *configs.Config) *TokenIssuer {
+	keys, kid := cfg.SigningKeys()
+	return &TokenIssuer{
+		sessions:   sessions,
+		kid:        kid,
+		method:     jwt.GetSigningMethod(keys[kid].Alg),
+		key:        keys[kid].Sign,
+		issuer:     cfg.JWTIssuer,
+		audience:   cfg.JWTAudience,
+		accessTTL:  cfg.AccessTokenTTL,
+		refreshTTL: cfg.RefreshTokenTTL,
+	}
+}
+
+// StartSession создаёт сессию пользователя и выдаёт первую пару токенов
+func (i *TokenIssuer) StartSession(ctx context.Context, user entities.User) (TokenPair, error) {
+	session := entities.Session{
+		ID:        entities.GenerateUUID(),
+		UserID:    user.ID,
+		Role:      user.Role,
+		Email:     user.Email,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := i.sessions.CreateSession(ctx, session); err != nil {
+		return TokenPair{}, err
+	}
+	return i.issue(ctx, session)
+}
+
+// issue выдаёт новую пару токенов для существующей сессии
+func (i *TokenIssuer) issue(ctx context.Context, session entities.Session) (TokenPair, error) {
+	now := time.Now()
+	claims := AccessClaims{
+		Role:      session.Role,
+		Email:     session.Email,
+		SessionID: session.ID.String(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    i.issuer,
+			Subject:   session.UserID.String(),
+			Audience:  jwt.ClaimStrings{i.audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(i.accessTTL)),
+		},
+	}
+	token := jwt.NewWithClaims(i.method, claims)
+	token.Header["kid"] = i.kid
+	access, err := token.SignedString(i.key)
+	if err != nil {
+		return TokenPair{}, err
+	}
+	refresh, err := newRefreshToken()
+	if err != nil {
+		return TokenPair{}, err
+	}
+	err = i.sessions.SaveRefreshToken(ctx, entities.RefreshToken{
+		Hash:      HashRefreshToken(refresh),
+		SessionID: session.ID,
+		IssuedAt:  now.UTC(),
+		ExpiresAt: now.Add(i.refreshTTL).UTC(),
+	})
+	if err != nil {
+		return TokenPair{}, err
+	}
+	return TokenPair{AccessToken: access, RefreshToken: refresh, ExpiresIn: i.accessTTL, UserID: session.UserID}, nil
+}
+
+// newRefreshToken — 32 случайных байта в base64url
+func newRefreshToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashRefreshToken — sha256 refresh-токена в hex, под этим ключом токен хранится в БД
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,16 @@
+package usecases
+
+import "context"
+
+// TxManager — unit of work: всё, что репозитории делают внутри fn, выполняется
+// в одной транзакции (транзакция передаётся через ctx). Ошибка fn — откат
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// NopTxManager — выполняет fn без транзакции (для тестов с моками репозиториев)
+type NopTxManager struct{}
+
+func (NopTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
@@ -0,0 +1,59 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
+)
+
+// UpdateCatalogEntryUseCaseIface — интерфейс для моков и контроллеров
+type UpdateCatalogEntryUseCaseIface interface {
+	Execute(ctx context.Context, user entities.User, kind entities.CatalogKind, id int64, patch CatalogEntryPatch) (entities.CatalogEntry, error)
+}
+
+// UpdateCatalogEntryUseCase — интерактор для переименования, отключения и включения элемента справочника
+// Нужно право catalog:manage. Переименование переносится на уже созданные ПВЗ/товары (ON UPDATE CASCADE),
+// отключение запрещает только новые
+
+type UpdateCatalogEntryUseCase struct {
+	repo  CatalogRepository
+	cache CatalogInvalidator
+	authz *Authorizer
+}
+
+func NewUpdateCatalogEntryUseCase(repo CatalogRepository, cache CatalogInvalidator, authz *Authorizer) *UpdateCatalogEntryUseCase {
+	return &UpdateCatalogEntryUseCase{repo: repo, cache: cache, authz: authz}
+}
+
+// Execute применяет patch к элементу id справочника kind и сбрасывает кэш справочника
+func (uc *UpdateCatalogEntryUseCase) Execute(ctx context.Context, user entities.User, kind entities.CatalogKind, id int64, patch CatalogEntryPatch) (entities.CatalogEntry, error) {
+	log := logger.FromContext(ctx).With(slog.String("kind", string(kind)), slog.Int64("id", id))
+	if err := uc.authz.Authorize(ctx, user, entities.PermCatalogManage); err != nil {
+		return entities.CatalogEntry{}, err
+	}
+	if !entities.ValidateCatalogKind(kind) {
+		return entities.CatalogEntry{}, ErrUnknownCatalog
+	}
+	if patch.Name == nil && patch.Active == nil {
+		return entities.CatalogEntry{}, ErrEmptyCatalogPatch
+	}
+	if patch.Name != nil {
+		name, ok := entities.NormalizeCatalogName(*patch.Name)
+		if !ok {
+			return entities.CatalogEntry{}, ErrInvalidCatalogName
+		}
+		patch.Name = &name
+	}
+	entry, err := uc.repo.Update(ctx, kind, id, patch)
+	if err != nil {
+		return entities.CatalogEntry{}, err
+	}
+	if entry == nil {
+		return entities.CatalogEntry{}, ErrCatalogEntryNotFound
+	}
+	uc.cache.Invalidate(kind)
+	log.Info("catalog entry updated", slog.String("name", entry.Name), slog.Bool("active", entry.Active))
+	return *entry, nil
+}
@@ -0,0 +1,113 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
+)
+
+// PVZRepositoryForUpdate — интерфейс для изменения ПВЗ под блокировкой строки
+type PVZRepositoryForUpdate interface {
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.PVZ, error)
+	Update(ctx context.Context, pvz entities.PVZ) (entities.PVZ, error)
+}
+
+// PVZPatch — изменяемые поля ПВЗ, nil — не менять
+type PVZPatch struct {
+	City         *entities.City
+	Name         *string
+	Address      *string
+	WorkingHours *string
+	Location     *entities.GeoPoint
+}
+
+// IsEmpty — в patch нет ни одного поля
+func (p PVZPatch) IsEmpty() bool {
+	return p.City == nil && p.Name == nil && p.Address == nil && p.WorkingHours == nil && p.Location == nil
+}
+
+// apply переносит заданные поля patch в описание ПВЗ
+func (p PVZPatch) apply(pvz *entities.PVZ) {
+	if p.City != nil {
+		pvz.City = *p.City
+	}
+	if p.Name != nil {
+		pvz.Name = *p.Name
+	}
+	if p.Address != nil {
+		pvz.Address = *p.Address
+	}
+	if p.WorkingHours != nil {
+		pvz.WorkingHours = *p.WorkingHours
+	}
+	if p.Location != nil {
+		location := *p.Location
+		pvz.Location = &location
+	}
+}
+
+// UpdatePVZUseCaseIface — интерфейс для моков и контроллеров
+type UpdatePVZUseCaseIface interface {
+	Execute(ctx context.Context, user entities.User, pvzID uuid.UUID, patch PVZPatch) (entities.PVZ, error)
+}
+
+// UpdatePVZUseCase — интерактор для исправления данных ПВЗ
+// Нужно право pvz:update, новый город должен быть активным в справочнике city,
+// описание проверяется так же, как при создании
+
+type UpdatePVZUseCase struct {
+	repo    PVZRepositoryForUpdate
+	catalog CatalogChecker
+	tx      TxManager
+	authz   *Authorizer
+}
+
+func NewUpdatePVZUseCase(repo PVZRepositoryForUpdate, catalog CatalogChecker, tx TxManager, authz *Authorizer) *UpdatePVZUseCase {
+	return &UpdatePVZUseCase{repo: repo, catalog: catalog, tx: tx, authz: authz}
+}
+
+// Execute применяет patch к ПВЗ
+func (uc *UpdatePVZUseCase) Execute(ctx context.Context, user entities.User, pvzID uuid.UUID, patch PVZPatch) (entities.PVZ, error) {
+	log := logger.FromContext(ctx).With(slog.String("pvz_id", pvzID.String()))
+	if err := uc.authz.Authorize(ctx, user, entities.PermPVZUpdate); err != nil {
+		return entities.PVZ{}, err
+	}
+	if patch.IsEmpty() {
+		return entities.PVZ{}, ErrEmptyPVZPatch
+	}
+	if patch.City != nil {
+		allowed, err := uc.catalog.IsAllowed(ctx, entities.CatalogCity, string(*patch.City))
+		if err != nil {
+			return entities.PVZ{}, err
+		}
+		if !allowed {
+			log.Warn("invalid city", slog.String("op", "UpdatePVZ"), slog.String("city", string(*patch.City)))
+			return entities.PVZ{}, ErrInvalidCity
+		}
+	}
+	var updated entities.PVZ
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		pvz, err := uc.repo.GetByIDForUpdate(ctx, pvzID)
+		if err != nil {
+			return err
+		}
+		if pvz == nil {
+			return ErrPVZNotFound
+		}
+		patch.apply(pvz)
+		pvz.PVZDetails, err = normalizePVZDetails(pvz.PVZDetails)
+		if err != nil {
+			return err
+		}
+		updated, err = uc.repo.Update(ctx, *pvz)
+		return err
+	})
+	if err != nil {
+		return entities.PVZ{}, err
+	}
+	log.Info("pvz updated", slog.String("city", string(updated.City)))
+	return updated, nil
+}
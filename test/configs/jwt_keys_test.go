@@ -0,0 +1,119 @@
+package configs_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/configs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePEM пишет DER-блок в PEM-файл во временном каталоге и возвращает путь
+func writePEM(t *testing.T, name, blockType string, der []byte) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+	return path
+}
+
+// setJWTEnv сбрасывает переменные ключей JWT и задаёт PG_DSN, без которого LoadConfig паникует
+func setJWTEnv(t *testing.T, env map[string]string) {
+	for _, name := range []string{"JWT_SECRET", "JWT_KEYS", "JWT_ACTIVE_KID", "JWT_PRIVATE_KEY_FILES", "JWT_PUBLIC_KEY_FILES"} {
+		t.Setenv(name, "")
+	}
+	t.Setenv("PG_DSN", "postgres://localhost/test")
+	for name, v := range env {
+		t.Setenv(name, v)
+	}
+}
+
+func TestLoadConfig_JWTKeyFiles(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	edDER, err := x509.MarshalPKCS8PrivateKey(edPriv)
+	require.NoError(t, err)
+	edPubDER, err := x509.MarshalPKIXPublicKey(edPub)
+	require.NoError(t, err)
+
+	rsaPath := writePEM(t, "rs.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey))
+	edPath := writePEM(t, "ed.pem", "PRIVATE KEY", edDER)
+	edPubPath := writePEM(t, "ed.pub.pem", "PUBLIC KEY", edPubDER)
+
+	t.Run("закрытые и открытые ключи из файлов", func(t *testing.T) {
+		// Arrange
+		setJWTEnv(t, map[string]string{
+			"JWT_PRIVATE_KEY_FILES": "rs-1:" + rsaPath + ",ed-2:" + edPath,
+			"JWT_PUBLIC_KEY_FILES":  "ed-0:" + edPubPath,
+			"JWT_ACTIVE_KID":        "ed-2",
+		})
+
+		// Act
+		cfg := configs.LoadConfig()
+		keys, active := cfg.SigningKeys()
+
+		// Assert
+		assert.Equal(t, "ed-2", active)
+		require.Len(t, keys, 3)
+		assert.Equal(t, configs.JWTAlgRS256, keys["rs-1"].Alg)
+		assert.True(t, rsaKey.PublicKey.Equal(keys["rs-1"].Verify))
+		assert.Equal(t, configs.JWTAlgEdDSA, keys["ed-2"].Alg)
+		assert.True(t, edPriv.Equal(keys["ed-2"].Sign))
+		assert.Nil(t, keys["ed-0"].Sign)
+		assert.True(t, edPub.Equal(keys["ed-0"].Verify))
+		assert.Len(t, cfg.PublicKeys(), 3)
+	})
+
+	t.Run("JWT_SECRET остаётся ключом default рядом с файлами", func(t *testing.T) {
+		// Arrange
+		setJWTEnv(t, map[string]string{"JWT_SECRET": "secret", "JWT_PRIVATE_KEY_FILES": "rs-1:" + rsaPath})
+
+		// Act
+		cfg := configs.LoadConfig()
+		keys, active := cfg.SigningKeys()
+
+		// Assert
+		assert.Equal(t, configs.DefaultJWTKeyID, active)
+		assert.Equal(t, configs.JWTAlgHS256, keys[configs.DefaultJWTKeyID].Alg)
+		require.Len(t, cfg.PublicKeys(), 1)
+		assert.Equal(t, "rs-1", cfg.PublicKeys()[0].KID)
+	})
+
+	t.Run("ошибки конфигурации", func(t *testing.T) {
+		smallKey, err := rsa.GenerateKey(rand.Reader, 1024)
+		require.NoError(t, err)
+		smallPath := writePEM(t, "small.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(smallKey))
+		garbagePath := writePEM(t, "garbage.pem", "PRIVATE KEY", []byte("garbage"))
+
+		cases := map[string]map[string]string{
+			"нет ни одного ключа":           {},
+			"активный ключ только открытый": {"JWT_PUBLIC_KEY_FILES": "ed-0:" + edPubPath, "JWT_ACTIVE_KID": "ed-0"},
+			"неизвестный активный kid":      {"JWT_PRIVATE_KEY_FILES": "rs-1:" + rsaPath, "JWT_ACTIVE_KID": "rs-2"},
+			"файла нет":                     {"JWT_PRIVATE_KEY_FILES": "rs-1:/nonexistent.pem", "JWT_ACTIVE_KID": "rs-1"},
+			"не ключ":                       {"JWT_PRIVATE_KEY_FILES": "rs-1:" + garbagePath, "JWT_ACTIVE_KID": "rs-1"},
+			"RSA короче 2048 бит":           {"JWT_PRIVATE_KEY_FILES": "rs-1:" + smallPath, "JWT_ACTIVE_KID": "rs-1"},
+			"kid в обоих списках": {
+				"JWT_PRIVATE_KEY_FILES": "ed-0:" + edPath, "JWT_PUBLIC_KEY_FILES": "ed-0:" + edPubPath, "JWT_ACTIVE_KID": "ed-0",
+			},
+			"kid совпадает с JWT_KEYS": {
+				"JWT_KEYS": "rs-1:secret", "JWT_PRIVATE_KEY_FILES": "rs-1:" + rsaPath, "JWT_ACTIVE_KID": "rs-1",
+			},
+		}
+		for name, env := range cases {
+			t.Run(name, func(t *testing.T) {
+				// Arrange
+				setJWTEnv(t, env)
+
+				// Act & Assert
+				assert.Panics(t, func() { configs.LoadConfig() })
+			})
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package configs_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/configs"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig_RolePermissions(t *testing.T) {
+	writePolicy := func(t *testing.T, body string) string {
+		path := filepath.Join(t.TempDir(), "rbac.json")
+		if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	t.Run("без файла — встроенная политика", func(t *testing.T) {
+		// Arrange
+		setJWTEnv(t, map[string]string{"JWT_SECRET": "secret", "RBAC_POLICY_FILE": ""})
+
+		// Act
+		cfg := configs.LoadConfig()
+
+		// Assert
+		assert.Equal(t, entities.DefaultRolePermissions(), cfg.RolePermissions)
+	})
+
+	t.Run("политика из файла", func(t *testing.T) {
+		// Arrange
+		setJWTEnv(t, map[string]string{
+			"JWT_SECRET":       "secret",
+			"RBAC_POLICY_FILE": writePolicy(t, `{"moderator": ["pvz:read"], "auditor": ["pvz:read", "reception:read"]}`),
+		})
+
+		// Act
+		cfg := configs.LoadConfig()
+
+		// Assert
+		assert.Equal(t, map[entities.UserRole][]entities.Permission{
+			entities.UserRoleModerator: {entities.PermPVZRead},
+			"auditor":                  {entities.PermPVZRead, entities.PermReceptionRead},
+		}, cfg.RolePermissions)
+	})
+
+	t.Run("ошибки политики", func(t *testing.T) {
+		cases := map[string]string{
+			"файла нет":         filepath.Join(t.TempDir(), "missing.json"),
+			"не JSON":           writePolicy(t, `moderator: [pvz:read]`),
+			"неизвестное право": writePolicy(t, `{"moderator": ["pvz:destroy"]}`),
+		}
+		for name, path := range cases {
+			t.Run(name, func(t *testing.T) {
+				// Arrange
+				setJWTEnv(t, map[string]string{"JWT_SECRET": "secret", "RBAC_POLICY_FILE": path})
+
+				// Act & Assert
+				assert.Panics(t, func() { configs.LoadConfig() })
+			})
+		}
+	})
+}
@@ -0,0 +1,34 @@
+package configs_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/configs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig_ShutdownDrainDelay(t *testing.T) {
+	t.Run("по умолчанию 5s", func(t *testing.T) {
+		// Arrange
+		setJWTEnv(t, map[string]string{"JWT_SECRET": "secret", "SHUTDOWN_DRAIN_DELAY": ""})
+
+		// Act
+		cfg := configs.LoadConfig()
+
+		// Assert
+		assert.Equal(t, 5*time.Second, cfg.ShutdownDrainDelay)
+		assert.Equal(t, 15*time.Second, cfg.ShutdownTimeout)
+	})
+
+	t.Run("из окружения", func(t *testing.T) {
+		// Arrange
+		setJWTEnv(t, map[string]string{"JWT_SECRET": "secret", "SHUTDOWN_DRAIN_DELAY": "12s"})
+
+		// Act
+		cfg := configs.LoadConfig()
+
+		// Assert
+		assert.Equal(t, 12*time.Second, cfg.ShutdownDrainDelay)
+	})
+}
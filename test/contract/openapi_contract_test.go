@@ -0,0 +1,1395 @@
+package contract_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/configs"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/controllers"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "contract_secret"
+
+// --- In-memory хранилище вместо Postgres ---
+
+type memStore struct {
+	mu         sync.Mutex
+	users      map[string]entities.User
+	hashes     map[string]string
+	pvzs       []entities.PVZ
+	receptions []entities.Reception
+	products   []entities.Product
+	removals   []entities.ProductRemoval
+	history    []entities.ReceptionTransition
+	catalog    []entities.CatalogEntry
+	sessions   map[uuid.UUID]entities.Session
+	refresh    map[string]entities.RefreshToken
+	staff      []entities.StaffAssignment
+}
+
+func newMemStore() *memStore {
+	s := &memStore{
+		users:    map[string]entities.User{},
+		hashes:   map[string]string{},
+		sessions: map[uuid.UUID]entities.Session{},
+		refresh:  map[string]entities.RefreshToken{},
+	}
+	for kind, names := range usecases.DefaultCatalog() {
+		for _, name := range names {
+			s.catalog = append(s.catalog, entities.CatalogEntry{ID: int64(len(s.catalog) + 1), Kind: kind, Name: name, Active: true})
+		}
+	}
+	return s
+}
+
+type memUserRepo struct{ s *memStore }
+
+func (r memUserRepo) Create(_ context.Context, user entities.User, passwordHash string) (entities.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.users[user.Email] = user
+	r.s.hashes[user.Email] = passwordHash
+	return user, nil
+}
+
+func (r memUserRepo) GetByEmail(_ context.Context, email string) (*entities.User, string, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	u, ok := r.s.users[email]
+	if !ok {
+		return nil, "", nil
+	}
+	return &u, r.s.hashes[email], nil
+}
+
+func (r memUserRepo) EnsureUser(_ context.Context, user entities.User) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if u, ok := r.s.users[user.Email]; ok {
+		if u.ID != user.ID {
+			return usecases.ErrEmailTaken
+		}
+		return nil
+	}
+	r.s.users[user.Email] = user
+	return nil
+}
+
+func (r memUserRepo) GetByID(_ context.Context, id uuid.UUID) (*entities.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, u := range r.s.users {
+		if u.ID == id {
+			return &u, nil
+		}
+	}
+	return nil, nil
+}
+
+type memUserRepoForRegister struct{ memUserRepo }
+
+func (r memUserRepoForRegister) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
+	u, _, err := r.memUserRepo.GetByEmail(ctx, email)
+	return u, err
+}
+
+type memPVZRepo struct{ s *memStore }
+
+func (r memPVZRepo) Save(_ context.Context, pvz entities.PVZ) (entities.PVZ, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.pvzs = append(r.s.pvzs, pvz)
+	return pvz, nil
+}
+
+func (r memPVZRepo) List(_ context.Context, _, _ *time.Time, _, _ int, _ bool) ([]entities.PVZ, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	return append([]entities.PVZ(nil), r.s.pvzs...), nil
+}
+
+func (r memPVZRepo) GetByID(_ context.Context, id uuid.UUID) (*entities.PVZ, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, pvz := range r.s.pvzs {
+		if pvz.ID == id {
+			return &pvz, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetByIDForUpdate и GetByIDForShare — в памяти блокировка не нужна, usecase работает через NopTxManager
+func (r memPVZRepo) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.PVZ, error) {
+	return r.GetByID(ctx, id)
+}
+
+func (r memPVZRepo) GetByIDForShare(ctx context.Context, id uuid.UUID) (*entities.PVZ, error) {
+	return r.GetByID(ctx, id)
+}
+
+func (r memPVZRepo) Update(_ context.Context, pvz entities.PVZ) (entities.PVZ, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for i := range r.s.pvzs {
+		if r.s.pvzs[i].ID == pvz.ID {
+			r.s.pvzs[i] = pvz
+		}
+	}
+	return pvz, nil
+}
+
+// Nearest считает расстояние до каждого ПВЗ в памяти (без ограничивающего прямоугольника)
+func (r memPVZRepo) Nearest(_ context.Context, point entities.GeoPoint, radius float64, limit int) ([]entities.NearestPVZ, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	res := []entities.NearestPVZ{}
+	for _, pvz := range r.s.pvzs {
+		if pvz.IsArchived() || pvz.Location == nil {
+			continue
+		}
+		if d := point.DistanceTo(*pvz.Location); d <= radius {
+			res = append(res, entities.NearestPVZ{PVZ: pvz, Distance: d})
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Distance < res[j].Distance })
+	if len(res) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
+
+// ListWithReceptions собирает ПВЗ с приёмками и товарами из памяти (фильтры и пагинация не нужны)
+func (r memPVZRepo) ListWithReceptions(_ context.Context, _, _ *time.Time, _, _ int, includeArchived bool) ([]entities.PVZWithReceptions, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	res := make([]entities.PVZWithReceptions, 0, len(r.s.pvzs))
+	for _, pvz := range r.s.pvzs {
+		if pvz.IsArchived() && !includeArchived {
+			continue
+		}
+		item := entities.PVZWithReceptions{PVZ: pvz}
+		for _, rec := range r.s.receptions {
+			if rec.PVZID != pvz.ID {
+				continue
+			}
+			withProducts := entities.ReceptionWithProducts{Reception: rec}
+			for _, p := range r.s.products {
+				if p.ReceptionID == rec.ID {
+					withProducts.Products = append(withProducts.Products, p)
+				}
+			}
+			item.Receptions = append(item.Receptions, withProducts)
+		}
+		res = append(res, item)
+	}
+	return res, nil
+}
+
+type memReceptionRepo struct{ s *memStore }
+
+func (r memReceptionRepo) Save(_ context.Context, rec entities.Reception) (entities.Reception, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for i := range r.s.receptions {
+		if r.s.receptions[i].ID == rec.ID {
+			r.s.receptions[i] = rec
+			return rec, nil
+		}
+	}
+	r.s.receptions = append(r.s.receptions, rec)
+	return rec, nil
+}
+
+func (r memReceptionRepo) GetActive(_ context.Context, pvzID uuid.UUID) (*entities.Reception, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, rec := range r.s.receptions {
+		if rec.PVZID == pvzID && rec.IsActive() {
+			return &rec, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetActiveForUpdate — в памяти блокировка не нужна, usecase работает через NopTxManager
+func (r memReceptionRepo) GetActiveForUpdate(ctx context.Context, pvzID uuid.UUID) (*entities.Reception, error) {
+	return r.GetActive(ctx, pvzID)
+}
+
+func (r memReceptionRepo) GetByID(_ context.Context, id uuid.UUID) (*entities.Reception, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, rec := range r.s.receptions {
+		if rec.ID == id {
+			return &rec, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r memReceptionRepo) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.Reception, error) {
+	return r.GetByID(ctx, id)
+}
+
+func (r memReceptionRepo) SaveTransition(_ context.Context, t entities.ReceptionTransition) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.history = append(r.s.history, t)
+	return nil
+}
+
+func (r memReceptionRepo) ListTransitions(_ context.Context, receptionID uuid.UUID) ([]entities.ReceptionTransition, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	res := []entities.ReceptionTransition{}
+	for _, t := range r.s.history {
+		if t.ReceptionID == receptionID {
+			res = append(res, t)
+		}
+	}
+	return res, nil
+}
+
+// ListByPVZ фильтрует и сортирует как PGReceptionRepository: новые приёмки первыми
+func (r memReceptionRepo) ListByPVZ(_ context.Context, pvzID uuid.UUID, filter usecases.ReceptionFilter) ([]entities.Reception, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	res := []entities.Reception{}
+	for _, rec := range r.s.receptions {
+		if rec.PVZID != pvzID ||
+			filter.Status != nil && rec.Status != *filter.Status ||
+			filter.StartDate != nil && rec.DateTime.Before(*filter.StartDate) ||
+			filter.EndDate != nil && rec.DateTime.After(*filter.EndDate) {
+			continue
+		}
+		res = append(res, rec)
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].DateTime.After(res[j].DateTime) })
+	if filter.Limit > 0 {
+		from := min((filter.Page-1)*filter.Limit, len(res))
+		res = res[from:min(from+filter.Limit, len(res))]
+	}
+	return res, nil
+}
+
+type memProductRepo struct{ s *memStore }
+
+// Save назначает позицию следующей за последней в приёмке и не пускает повтор штрихкода, как PGProductRepository
+func (r memProductRepo) Save(_ context.Context, p entities.Product) (entities.Product, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	p.Position = 1
+	for _, existing := range r.s.products {
+		if existing.ReceptionID != p.ReceptionID {
+			continue
+		}
+		if p.Barcode != "" && existing.Barcode == p.Barcode {
+			return entities.Product{}, usecases.ErrDuplicateBarcode
+		}
+		if existing.Position >= p.Position {
+			p.Position = existing.Position + 1
+		}
+	}
+	r.s.products = append(r.s.products, p)
+	return p, nil
+}
+
+func (r memProductRepo) DeleteLast(ctx context.Context, receptionID uuid.UUID) error {
+	_, err := memProductRepoForDelete(r).DeleteLast(ctx, receptionID)
+	return err
+}
+
+func (r memProductRepo) ListByReception(_ context.Context, receptionID uuid.UUID) ([]entities.Product, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var res []entities.Product
+	for _, p := range r.s.products {
+		if p.ReceptionID == receptionID {
+			res = append(res, p)
+		}
+	}
+	return res, nil
+}
+
+func (r memProductRepo) ExistingBarcodes(_ context.Context, receptionID uuid.UUID, barcodes []string) ([]string, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var res []string
+	for _, p := range r.s.products {
+		if p.ReceptionID == receptionID && p.Barcode != "" && slices.Contains(barcodes, p.Barcode) {
+			res = append(res, p.Barcode)
+		}
+	}
+	return res, nil
+}
+
+func (r memProductRepo) SaveBatch(ctx context.Context, receptionID uuid.UUID, products []entities.Product) ([]entities.Product, error) {
+	res := make([]entities.Product, 0, len(products))
+	for _, p := range products {
+		p.ReceptionID = receptionID
+		saved, err := r.Save(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, saved)
+	}
+	return res, nil
+}
+
+// FindByBarcode собирает товар, приёмку и ПВЗ, новые приёмы первыми
+func (r memProductRepo) FindByBarcode(_ context.Context, barcode string) ([]entities.ProductLocation, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	res := []entities.ProductLocation{}
+	for _, p := range r.s.products {
+		if p.Barcode != barcode {
+			continue
+		}
+		loc := entities.ProductLocation{Product: p}
+		for _, rec := range r.s.receptions {
+			if rec.ID == p.ReceptionID {
+				loc.Reception = rec
+			}
+		}
+		for _, pvz := range r.s.pvzs {
+			if pvz.ID == loc.Reception.PVZID {
+				loc.PVZ = pvz
+			}
+		}
+		res = append(res, loc)
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Product.DateTime.After(res[j].Product.DateTime) })
+	return res, nil
+}
+
+func (r memProductRepo) GetByID(_ context.Context, id uuid.UUID) (*entities.Product, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, p := range r.s.products {
+		if p.ID == id {
+			return &p, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r memProductRepo) Delete(_ context.Context, id uuid.UUID) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.products = slices.DeleteFunc(r.s.products, func(p entities.Product) bool { return p.ID == id })
+	return nil
+}
+
+func (r memProductRepo) SaveRemoval(_ context.Context, removal entities.ProductRemoval) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.removals = append(r.s.removals, removal)
+	return nil
+}
+
+func (r memProductRepo) VoidByReception(_ context.Context, receptionID, removedBy uuid.UUID, email string, at time.Time) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	kept := r.s.products[:0]
+	voided := 0
+	for _, p := range r.s.products {
+		if p.ReceptionID != receptionID {
+			kept = append(kept, p)
+			continue
+		}
+		r.s.removals = append(r.s.removals, entities.ProductRemoval{ID: uuid.New(), Product: p, RemovedBy: removedBy, RemovedByEmail: email, RemovedAt: at})
+		voided++
+	}
+	r.s.products = kept
+	return voided, nil
+}
+
+type memProductRepoForDelete struct{ s *memStore }
+
+func (r memProductRepoForDelete) DeleteLast(_ context.Context, receptionID uuid.UUID) (*entities.Product, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for i := len(r.s.products) - 1; i >= 0; i-- {
+		if r.s.products[i].ReceptionID == receptionID {
+			p := r.s.products[i]
+			r.s.products = append(r.s.products[:i], r.s.products[i+1:]...)
+			return &p, nil
+		}
+	}
+	return nil, nil
+}
+
+type memCatalogRepo struct{ s *memStore }
+
+func (r memCatalogRepo) List(_ context.Context, kind entities.CatalogKind) ([]entities.CatalogEntry, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var res []entities.CatalogEntry
+	for _, e := range r.s.catalog {
+		if e.Kind == kind {
+			res = append(res, e)
+		}
+	}
+	return res, nil
+}
+
+func (r memCatalogRepo) Add(_ context.Context, kind entities.CatalogKind, name string) (entities.CatalogEntry, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, e := range r.s.catalog {
+		if e.Kind == kind && e.Name == name {
+			return entities.CatalogEntry{}, usecases.ErrCatalogEntryExists
+		}
+	}
+	e := entities.CatalogEntry{ID: int64(len(r.s.catalog) + 1), Kind: kind, Name: name, Active: true}
+	r.s.catalog = append(r.s.catalog, e)
+	return e, nil
+}
+
+func (r memCatalogRepo) Update(_ context.Context, kind entities.CatalogKind, id int64, patch usecases.CatalogEntryPatch) (*entities.CatalogEntry, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for i := range r.s.catalog {
+		e := &r.s.catalog[i]
+		if e.Kind != kind || e.ID != id {
+			continue
+		}
+		if patch.Name != nil {
+			e.Name = *patch.Name
+		}
+		if patch.Active != nil {
+			e.Active = *patch.Active
+		}
+		res := *e
+		return &res, nil
+	}
+	return nil, nil
+}
+
+type memSessionRepo struct{ s *memStore }
+
+func (r memSessionRepo) CreateSession(_ context.Context, session entities.Session) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.sessions[session.ID] = session
+	return nil
+}
+
+func (r memSessionRepo) GetSession(_ context.Context, id uuid.UUID) (*entities.Session, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	session, ok := r.s.sessions[id]
+	if !ok {
+		return nil, nil
+	}
+	return &session, nil
+}
+
+func (r memSessionRepo) IsSessionActive(_ context.Context, id uuid.UUID) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	session, ok := r.s.sessions[id]
+	return ok && !session.IsRevoked(), nil
+}
+
+func (r memSessionRepo) RevokeSession(_ context.Context, id uuid.UUID, at time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if session, ok := r.s.sessions[id]; ok && !session.IsRevoked() {
+		session.RevokedAt = &at
+		r.s.sessions[id] = session
+	}
+	return nil
+}
+
+func (r memSessionRepo) RevokeUserSessions(_ context.Context, userID uuid.UUID, at time.Time) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var revoked int64
+	for id, session := range r.s.sessions {
+		if session.UserID == userID && !session.IsRevoked() {
+			session.RevokedAt = &at
+			r.s.sessions[id] = session
+			revoked++
+		}
+	}
+	return revoked, nil
+}
+
+func (r memSessionRepo) SaveRefreshToken(_ context.Context, token entities.RefreshToken) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.refresh[token.Hash] = token
+	return nil
+}
+
+func (r memSessionRepo) GetRefreshTokenForUpdate(_ context.Context, hash string) (*entities.RefreshToken, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	token, ok := r.s.refresh[hash]
+	if !ok {
+		return nil, nil
+	}
+	return &token, nil
+}
+
+func (r memSessionRepo) MarkRefreshTokenUsed(_ context.Context, hash string, at time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	token := r.s.refresh[hash]
+	token.UsedAt = &at
+	r.s.refresh[hash] = token
+	return nil
+}
+
+type memStaffRepo struct{ s *memStore }
+
+func (r memStaffRepo) Assign(_ context.Context, a entities.StaffAssignment) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, cur := range r.s.staff {
+		if cur.PVZID == a.PVZID && cur.UserID == a.UserID {
+			return nil
+		}
+	}
+	r.s.staff = append(r.s.staff, a)
+	return nil
+}
+
+func (r memStaffRepo) Unassign(_ context.Context, pvzID, userID uuid.UUID) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for i, cur := range r.s.staff {
+		if cur.PVZID == pvzID && cur.UserID == userID {
+			r.s.staff = slices.Delete(r.s.staff, i, i+1)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r memStaffRepo) IsAssigned(_ context.Context, userID, pvzID uuid.UUID) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	return slices.ContainsFunc(r.s.staff, func(a entities.StaffAssignment) bool {
+		return a.PVZID == pvzID && a.UserID == userID
+	}), nil
+}
+
+func (r memStaffRepo) ListByPVZ(_ context.Context, pvzID uuid.UUID) ([]entities.StaffAssignment, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	res := []entities.StaffAssignment{}
+	for _, a := range r.s.staff {
+		if a.PVZID == pvzID {
+			res = append(res, a)
+		}
+	}
+	return res, nil
+}
+
+// --- Сборка приложения как в cmd/service/main.go ---
+
+func setupServer() *gin.Engine {
+	s := newMemStore()
+	cfg := &configs.Config{JWTSecret: testSecret, JWTIssuer: "pvz-service", JWTAudience: "pvz-api", AccessTokenTTL: 15 * time.Minute, RefreshTokenTTL: time.Hour}
+	sessions := memSessionRepo{s}
+	issuer := usecases.NewTokenIssuer(sessions, cfg)
+	users := memUserRepo{s}
+	pvzRepo := memPVZRepo{s}
+	receptionRepo := memReceptionRepo{s}
+	productRepo := memProductRepo{s}
+	catalogRepo := memCatalogRepo{s}
+	catalog := usecases.NewCatalogCache(catalogRepo, 0)
+	staffRepo := memStaffRepo{s}
+	authz := usecases.NewAuthorizer(entities.DefaultRolePermissions(), staffRepo)
+
+	authCtrl := controllers.NewAuthController(
+		usecases.NewDummyLoginUseCase(users, issuer),
+		usecases.NewRegisterUseCase(memUserRepoForRegister{users}),
+		usecases.NewLoginUseCase(users, issuer),
+		usecases.NewRefreshTokenUseCase(sessions, issuer, usecases.NopTxManager{}),
+		usecases.NewLogoutUseCase(sessions),
+		usecases.NewRevokeUserSessionsUseCase(sessions, authz),
+	)
+	pvzCtrl := controllers.NewPVZController(
+		usecases.NewCreatePVZUseCase(pvzRepo, catalog, usecases.NopMetrics{}, authz),
+		usecases.NewListPVZsUseCase(pvzRepo, authz),
+		usecases.NewCloseReceptionUseCase(receptionRepo, usecases.NopTxManager{}, authz),
+		usecases.NewDeleteLastProductUseCase(memProductRepoForDelete{s}, receptionRepo, usecases.NopTxManager{}, authz),
+		usecases.NewGetPVZUseCase(pvzRepo, authz),
+		usecases.NewUpdatePVZUseCase(pvzRepo, catalog, usecases.NopTxManager{}, authz),
+		usecases.NewArchivePVZUseCase(pvzRepo, receptionRepo, usecases.NopTxManager{}, authz),
+		usecases.NewFindNearestPVZsUseCase(pvzRepo, authz),
+		usecases.NewAssignStaffUseCase(staffRepo, users, pvzRepo, usecases.NopTxManager{}, authz),
+		usecases.NewListPVZStaffUseCase(pvzRepo, staffRepo, authz),
+	)
+	productCtrl := controllers.NewProductController(
+		usecases.NewAddProductUseCase(productRepo, receptionRepo, catalog, usecases.NopTxManager{}, usecases.NopMetrics{}, authz),
+		usecases.NewFindProductsByBarcodeUseCase(productRepo, authz),
+		usecases.NewAddProductsBatchUseCase(productRepo, receptionRepo, catalog, usecases.NopTxManager{}, usecases.NopMetrics{}, authz),
+	)
+	receptionCtrl := controllers.NewReceptionController(
+		usecases.NewCreateReceptionUseCase(receptionRepo, pvzRepo, usecases.NopTxManager{}, usecases.NopMetrics{}, authz),
+		usecases.NewGetReceptionUseCase(receptionRepo, productRepo, authz),
+		usecases.NewListReceptionsUseCase(pvzRepo, receptionRepo, authz),
+		usecases.NewDeleteProductUseCase(productRepo, receptionRepo, usecases.NopTxManager{}, authz),
+		usecases.NewReopenReceptionUseCase(receptionRepo, pvzRepo, usecases.NopTxManager{}, time.Hour, authz),
+		usecases.NewListReceptionTransitionsUseCase(receptionRepo, authz),
+		usecases.NewChangeReceptionStatusUseCase(receptionRepo, productRepo, usecases.NopTxManager{}, authz),
+	)
+	catalogCtrl := controllers.NewCatalogController(
+		usecases.NewListCatalogUseCase(catalogRepo, authz),
+		usecases.NewAddCatalogEntryUseCase(catalogRepo, catalog, authz),
+		usecases.NewUpdateCatalogEntryUseCase(catalogRepo, catalog, authz),
+	)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	controllers.RegisterRoutes(r, controllers.NewServer(authCtrl, pvzCtrl, productCtrl, receptionCtrl, catalogCtrl), controllers.NewTokenVerifier(cfg, sessions), authz)
+	return r
+}
+
+// --- Проверка ответов по swagger.yaml ---
+
+type contractClient struct {
+	t      *testing.T
+	engine *gin.Engine
+	router routers.Router
+	doc    *openapi3.T
+	users  map[string]string // access-токен → userId из ответа /dummyLogin
+}
+
+func newContractClient(t *testing.T) *contractClient {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("../../swagger.yaml")
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(loader.Context))
+	router, err := legacy.NewRouter(doc)
+	require.NoError(t, err)
+	return &contractClient{t: t, engine: setupServer(), router: router, doc: doc, users: map[string]string{}}
+}
+
+// do выполняет запрос и сверяет статус и тело ответа со схемой операции
+func (c *contractClient) do(method, path, token string, body any, wantStatus int) []byte {
+	c.t.Helper()
+	var raw []byte
+	if body != nil {
+		var err error
+		raw, err = json.Marshal(body)
+		require.NoError(c.t, err)
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(raw))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	c.engine.ServeHTTP(w, req)
+	require.Equal(c.t, wantStatus, w.Code, w.Body.String())
+
+	route, pathParams, err := c.router.FindRoute(req)
+	require.NoError(c.t, err)
+	input := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{
+			Request:    req,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc},
+		},
+		Status: w.Code,
+		Header: w.Header(),
+		Body:   io.NopCloser(bytes.NewReader(w.Body.Bytes())),
+	}
+	require.NoError(c.t, openapi3filter.ValidateResponse(context.Background(), input), w.Body.String())
+	return w.Body.Bytes()
+}
+
+func (c *contractClient) token(role string) string {
+	var resp struct {
+		Token  string `json:"token"`
+		UserID string `json:"userId"`
+	}
+	require.NoError(c.t, json.Unmarshal(c.do(http.MethodPost, "/dummyLogin", "", map[string]string{"role": role}, http.StatusOK), &resp))
+	c.users[resp.Token] = resp.UserID
+	return resp.Token
+}
+
+// registered регистрирует нового пользователя роли и возвращает его токен из /login
+func (c *contractClient) registered(role string) string {
+	creds := map[string]string{"email": role + "-" + uuid.NewString() + "@avito.ru", "password": "secret"}
+	c.do(http.MethodPost, "/register", "", map[string]string{"email": creds["email"], "password": creds["password"], "role": role}, http.StatusCreated)
+	var resp struct {
+		Token  string `json:"token"`
+		UserID string `json:"userId"`
+	}
+	require.NoError(c.t, json.Unmarshal(c.do(http.MethodPost, "/login", "", creds, http.StatusOK), &resp))
+	c.users[resp.Token] = resp.UserID
+	return resp.Token
+}
+
+// userID — id пользователя, которому c.token или c.registered выдал token
+func (c *contractClient) userID(token string) string {
+	id, ok := c.users[token]
+	require.True(c.t, ok, "токен выдан не через c.token")
+	return id
+}
+
+// assign назначает сотрудника с токеном staff на ПВЗ от имени модератора
+func (c *contractClient) assign(moderator, staff, pvzID string) {
+	c.do(http.MethodPut, "/pvz/"+pvzID+"/staff/"+c.userID(staff), moderator, nil, http.StatusNoContent)
+}
+
+func TestOpenAPIContract(t *testing.T) {
+	t.Run("полный сценарий приёмки соответствует схеме", func(t *testing.T) {
+		// Arrange
+		c := newContractClient(t)
+		moderator := c.token("moderator")
+		staff := c.token("pvz_staff")
+
+		// Act & Assert
+		var pvz struct {
+			ID uuid.UUID `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPost, "/pvz", moderator, map[string]string{"city": "Москва"}, http.StatusCreated), &pvz))
+		pvzID := pvz.ID.String()
+		c.assign(moderator, staff, pvzID)
+
+		c.do(http.MethodPost, "/receptions", staff, map[string]string{"pvzId": pvzID}, http.StatusCreated)
+		var product struct {
+			Position int `json:"position"`
+		}
+		c.do(http.MethodPost, "/products", staff, map[string]string{"pvzId": pvzID, "type": "электроника"}, http.StatusCreated)
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPost, "/products", staff, map[string]string{"pvzId": pvzID, "type": "обувь"}, http.StatusCreated), &product))
+		require.Equal(t, 2, product.Position)
+		c.do(http.MethodPost, "/pvz/"+pvzID+"/delete_last_product", staff, nil, http.StatusOK)
+		c.do(http.MethodPost, "/pvz/"+pvzID+"/close_last_reception", staff, nil, http.StatusOK)
+
+		var list []struct {
+			Receptions []struct {
+				Products []struct {
+					Type     string `json:"type"`
+					Position int    `json:"position"`
+				} `json:"products"`
+			} `json:"receptions"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodGet, "/pvz?page=1&limit=10", moderator, nil, http.StatusOK), &list))
+		require.Len(t, list, 1)
+		require.Len(t, list[0].Receptions, 1)
+		require.Len(t, list[0].Receptions[0].Products, 1)
+		require.Equal(t, "электроника", list[0].Receptions[0].Products[0].Type)
+		require.Equal(t, 1, list[0].Receptions[0].Products[0].Position)
+	})
+
+	t.Run("регистрация и логин соответствуют схеме", func(t *testing.T) {
+		// Arrange
+		c := newContractClient(t)
+		creds := map[string]string{"email": "staff@avito.ru", "password": "secret"}
+
+		// Act & Assert
+		c.do(http.MethodPost, "/register", "", map[string]string{"email": creds["email"], "password": creds["password"], "role": "pvz_staff"}, http.StatusCreated)
+		c.do(http.MethodPost, "/login", "", creds, http.StatusOK)
+		c.do(http.MethodPost, "/login", "", map[string]string{"email": creds["email"], "password": "wrong"}, http.StatusUnauthorized)
+	})
+
+	t.Run("ошибки соответствуют схеме", func(t *testing.T) {
+		// Arrange
+		c := newContractClient(t)
+		client := c.token("client")
+		staff := c.token("pvz_staff")
+
+		// Act & Assert
+		c.do(http.MethodPost, "/pvz", "", map[string]string{"city": "Москва"}, http.StatusUnauthorized)
+		c.do(http.MethodPost, "/pvz", client, map[string]string{"city": "Москва"}, http.StatusForbidden)
+		c.do(http.MethodGet, "/pvz?limit=100", staff, nil, http.StatusBadRequest)
+		c.do(http.MethodPost, "/pvz/"+uuid.NewString()+"/close_last_reception", staff, nil, http.StatusForbidden)
+		c.do(http.MethodPost, "/dummyLogin", "", map[string]string{"role": "admin"}, http.StatusBadRequest)
+	})
+
+	t.Run("конфликты и отсутствие товаров соответствуют схеме", func(t *testing.T) {
+		// Arrange
+		c := newContractClient(t)
+		moderator := c.token("moderator")
+		staff := c.token("pvz_staff")
+		var pvz struct {
+			ID uuid.UUID `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPost, "/pvz", moderator, map[string]string{"city": "Казань"}, http.StatusCreated), &pvz))
+		pvzID := pvz.ID.String()
+		c.assign(moderator, staff, pvzID)
+		c.do(http.MethodPost, "/receptions", staff, map[string]string{"pvzId": pvzID}, http.StatusCreated)
+
+		// Act
+		conflict := c.do(http.MethodPost, "/receptions", staff, map[string]string{"pvzId": pvzID}, http.StatusConflict)
+		notFound := c.do(http.MethodPost, "/pvz/"+pvzID+"/delete_last_product", staff, nil, http.StatusNotFound)
+
+		// Assert
+		require.JSONEq(t, `{"code":"conflict","message":"у ПВЗ уже есть открытая приёмка"}`, string(conflict))
+		require.JSONEq(t, `{"code":"not_found","message":"нет товаров для удаления"}`, string(notFound))
+	})
+	t.Run("справочники соответствуют схеме", func(t *testing.T) {
+		// Arrange
+		c := newContractClient(t)
+		moderator := c.token("moderator")
+		staff := c.token("pvz_staff")
+		var city struct {
+			ID int64 `json:"id"`
+		}
+
+		// Act & Assert: новый город доступен сразу после добавления
+		c.do(http.MethodPost, "/pvz", moderator, map[string]string{"city": "Новосибирск"}, http.StatusBadRequest)
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPost, "/catalogs/city", moderator, map[string]string{"name": "Новосибирск"}, http.StatusCreated), &city))
+		c.do(http.MethodPost, "/catalogs/city", moderator, map[string]string{"name": "Новосибирск"}, http.StatusConflict)
+		c.do(http.MethodPost, "/catalogs/city", staff, map[string]string{"name": "Омск"}, http.StatusForbidden)
+		c.do(http.MethodPost, "/pvz", moderator, map[string]string{"city": "Новосибирск"}, http.StatusCreated)
+
+		// отключённый город не виден сотруднику и не принимается
+		c.do(http.MethodPatch, "/catalogs/city/"+strconv.FormatInt(city.ID, 10), moderator, map[string]any{"active": false}, http.StatusOK)
+		c.do(http.MethodPost, "/pvz", moderator, map[string]string{"city": "Новосибирск"}, http.StatusBadRequest)
+		var visible []struct {
+			Name string `json:"name"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodGet, "/catalogs/city", staff, nil, http.StatusOK), &visible))
+		require.Len(t, visible, 3)
+		c.do(http.MethodPatch, "/catalogs/city/999", moderator, map[string]any{"name": "Тверь"}, http.StatusNotFound)
+		c.do(http.MethodPatch, "/catalogs/city/"+strconv.FormatInt(city.ID, 10), moderator, map[string]any{}, http.StatusBadRequest)
+	})
+
+	t.Run("просмотр, изменение и архивация ПВЗ соответствуют схеме", func(t *testing.T) {
+		// Arrange
+		c := newContractClient(t)
+		moderator := c.token("moderator")
+		staff := c.token("pvz_staff")
+		var pvz struct {
+			ID         uuid.UUID  `json:"id"`
+			City       string     `json:"city"`
+			ArchivedAt *time.Time `json:"archivedAt"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPost, "/pvz", moderator, map[string]string{"city": "Москва"}, http.StatusCreated), &pvz))
+		pvzPath := "/pvz/" + pvz.ID.String()
+		c.assign(moderator, staff, pvz.ID.String())
+
+		// Act & Assert: изменение города
+		c.do(http.MethodPatch, pvzPath, staff, map[string]string{"city": "Казань"}, http.StatusForbidden)
+		c.do(http.MethodPatch, pvzPath, moderator, map[string]string{"city": "Тверь"}, http.StatusBadRequest)
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPatch, pvzPath, moderator, map[string]string{"city": "Казань"}, http.StatusOK), &pvz))
+		require.Equal(t, "Казань", pvz.City)
+		c.do(http.MethodGet, "/pvz/"+uuid.NewString(), staff, nil, http.StatusNotFound)
+
+		// с открытой приёмкой архивировать нельзя
+		c.do(http.MethodPost, "/receptions", staff, map[string]string{"pvzId": pvz.ID.String()}, http.StatusCreated)
+		c.do(http.MethodPost, pvzPath+"/archive", moderator, nil, http.StatusConflict)
+		c.do(http.MethodPost, pvzPath+"/close_last_reception", staff, nil, http.StatusOK)
+
+		// архивный ПВЗ скрыт из списка и не принимает приёмки
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPost, pvzPath+"/archive", moderator, nil, http.StatusOK), &pvz))
+		require.NotNil(t, pvz.ArchivedAt)
+		c.do(http.MethodPost, pvzPath+"/archive", moderator, nil, http.StatusOK)
+		c.do(http.MethodPost, "/receptions", staff, map[string]string{"pvzId": pvz.ID.String()}, http.StatusConflict)
+		var list []json.RawMessage
+		require.NoError(t, json.Unmarshal(c.do(http.MethodGet, "/pvz", moderator, nil, http.StatusOK), &list))
+		require.Empty(t, list)
+		require.NoError(t, json.Unmarshal(c.do(http.MethodGet, "/pvz?includeArchived=true", moderator, nil, http.StatusOK), &list))
+		require.Len(t, list, 1)
+		require.NoError(t, json.Unmarshal(c.do(http.MethodGet, pvzPath, staff, nil, http.StatusOK), &pvz))
+		require.NotNil(t, pvz.ArchivedAt)
+
+		// после возврата в работу приёмки снова открываются
+		var restored map[string]any
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPost, pvzPath+"/unarchive", moderator, nil, http.StatusOK), &restored))
+		require.NotContains(t, restored, "archivedAt")
+		c.do(http.MethodPost, "/receptions", staff, map[string]string{"pvzId": pvz.ID.String()}, http.StatusCreated)
+	})
+
+	t.Run("поиск ближайших ПВЗ соответствует схеме", func(t *testing.T) {
+		// Arrange: два ПВЗ в центре Москвы, один в Казани, один без координат
+		c := newContractClient(t)
+		moderator := c.token("moderator")
+		client := c.token("client")
+		create := func(city, name string, location map[string]float64) {
+			body := map[string]any{"city": city, "name": name}
+			if location != nil {
+				body["location"] = location
+			}
+			c.do(http.MethodPost, "/pvz", moderator, body, http.StatusCreated)
+		}
+		create("Москва", "Тверская", map[string]float64{"lat": 55.7650, "lon": 37.6050})
+		create("Москва", "Красная площадь", map[string]float64{"lat": 55.7539, "lon": 37.6208})
+		create("Казань", "Кремль", map[string]float64{"lat": 55.7986, "lon": 49.1064})
+		create("Москва", "Без координат", nil)
+
+		// Act
+		var nearest []struct {
+			Pvz struct {
+				Name string `json:"name"`
+			} `json:"pvz"`
+			Distance float64 `json:"distance"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodGet, "/pvz/nearest?lat=55.7558&lon=37.6173&radius=3000", client, nil, http.StatusOK), &nearest))
+
+		// Assert: ближайшие первыми, Казань и ПВЗ без координат не попали
+		require.Len(t, nearest, 2)
+		require.Equal(t, "Красная площадь", nearest[0].Pvz.Name)
+		require.Equal(t, "Тверская", nearest[1].Pvz.Name)
+		require.Less(t, nearest[0].Distance, nearest[1].Distance)
+		c.do(http.MethodGet, "/pvz/nearest?lat=95&lon=37.6", client, nil, http.StatusBadRequest)
+		c.do(http.MethodGet, "/pvz/nearest?lat=55.75&lon=37.6&radius=100000", client, nil, http.StatusBadRequest)
+		c.do(http.MethodGet, "/pvz/nearest?lat=55.75", client, nil, http.StatusBadRequest)
+		c.do(http.MethodPost, "/pvz", moderator, map[string]any{"city": "Москва", "location": map[string]float64{"lat": 55.75, "lon": 200}}, http.StatusBadRequest)
+	})
+
+	t.Run("приёмка с товарами и история приёмок ПВЗ соответствуют схеме", func(t *testing.T) {
+		// Arrange: закрытая приёмка с товаром и открытая пустая
+		c := newContractClient(t)
+		moderator := c.token("moderator")
+		staff := c.token("pvz_staff")
+		var pvz struct {
+			ID uuid.UUID `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPost, "/pvz", moderator, map[string]string{"city": "Москва"}, http.StatusCreated), &pvz))
+		pvzID := pvz.ID.String()
+		c.assign(moderator, staff, pvzID)
+		var closed struct {
+			ID uuid.UUID `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPost, "/receptions", staff, map[string]string{"pvzId": pvzID}, http.StatusCreated), &closed))
+		c.do(http.MethodPost, "/products", staff, map[string]string{"pvzId": pvzID, "type": "обувь"}, http.StatusCreated)
+		c.do(http.MethodPost, "/pvz/"+pvzID+"/close_last_reception", staff, nil, http.StatusOK)
+		time.Sleep(time.Millisecond)
+		c.do(http.MethodPost, "/receptions", staff, map[string]string{"pvzId": pvzID}, http.StatusCreated)
+
+		// Act
+		var rec struct {
+			Reception struct {
+				Status string `json:"status"`
+			} `json:"reception"`
+			Products []struct {
+				Type string `json:"type"`
+			} `json:"products"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodGet, "/receptions/"+closed.ID.String(), moderator, nil, http.StatusOK), &rec))
+		var all, closedOnly, page2 []struct {
+			Status string `json:"status"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodGet, "/pvz/"+pvzID+"/receptions", staff, nil, http.StatusOK), &all))
+		require.NoError(t, json.Unmarshal(c.do(http.MethodGet, "/pvz/"+pvzID+"/receptions?status=close", staff, nil, http.StatusOK), &closedOnly))
+		require.NoError(t, json.Unmarshal(c.do(http.MethodGet, "/pvz/"+pvzID+"/receptions?page=2&limit=1", staff, nil, http.StatusOK), &page2))
+
+		// Assert: новые приёмки первыми
+		require.Equal(t, "close", rec.Reception.Status)
+		require.Len(t, rec.Products, 1)
+		require.Equal(t, "обувь", rec.Products[0].Type)
+		require.Len(t, all, 2)
+		require.Equal(t, "in_progress", all[0].Status)
+		require.Len(t, closedOnly, 1)
+		require.Len(t, page2, 1)
+		require.Equal(t, "close", page2[0].Status)
+		c.do(http.MethodGet, "/receptions/"+uuid.NewString(), staff, nil, http.StatusNotFound)
+		c.do(http.MethodGet, "/pvz/"+uuid.NewString()+"/receptions", staff, nil, http.StatusNotFound)
+		c.do(http.MethodGet, "/pvz/"+pvzID+"/receptions?status=draft", staff, nil, http.StatusBadRequest)
+		c.do(http.MethodGet, "/receptions/"+closed.ID.String(), c.token("client"), nil, http.StatusForbidden)
+	})
+
+	t.Run("штрихкоды и поиск посылки соответствуют схеме", func(t *testing.T) {
+		// Arrange
+		c := newContractClient(t)
+		moderator := c.token("moderator")
+		staff := c.token("pvz_staff")
+		var pvz struct {
+			ID uuid.UUID `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPost, "/pvz", moderator, map[string]string{"city": "Москва", "name": "Тверская"}, http.StatusCreated), &pvz))
+		pvzID := pvz.ID.String()
+		c.assign(moderator, staff, pvzID)
+		c.do(http.MethodPost, "/receptions", staff, map[string]string{"pvzId": pvzID}, http.StatusCreated)
+
+		// Act
+		scan := map[string]string{"pvzId": pvzID, "type": "обувь", "barcode": "4601234567893"}
+		c.do(http.MethodPost, "/products", staff, scan, http.StatusCreated)
+		c.do(http.MethodPost, "/products", staff, scan, http.StatusConflict)
+		c.do(http.MethodPost, "/products", staff, map[string]string{"pvzId": pvzID, "type": "обувь"}, http.StatusCreated)
+		var found []struct {
+			Product struct {
+				Barcode string `json:"barcode"`
+			} `json:"product"`
+			Reception struct {
+				Status string `json:"status"`
+			} `json:"reception"`
+			Pvz struct {
+				Name string `json:"name"`
+			} `json:"pvz"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodGet, "/products?barcode=4601234567893", moderator, nil, http.StatusOK), &found))
+
+		// Assert
+		require.Len(t, found, 1)
+		require.Equal(t, "4601234567893", found[0].Product.Barcode)
+		require.Equal(t, "in_progress", found[0].Reception.Status)
+		require.Equal(t, "Тверская", found[0].Pvz.Name)
+		require.Equal(t, "[]", string(bytes.TrimSpace(c.do(http.MethodGet, "/products?barcode=000", staff, nil, http.StatusOK))))
+		c.do(http.MethodGet, "/products", staff, nil, http.StatusBadRequest)
+		c.do(http.MethodGet, "/products?barcode=4601234567893", c.token("client"), nil, http.StatusForbidden)
+		c.do(http.MethodPost, "/products", staff, map[string]string{"pvzId": pvzID, "type": "обувь", "barcode": "460 123"}, http.StatusBadRequest)
+	})
+
+	t.Run("пакетный приём товаров соответствует схеме", func(t *testing.T) {
+		// Arrange: один штрихкод уже отсканирован поштучно
+		c := newContractClient(t)
+		moderator := c.token("moderator")
+		staff := c.token("pvz_staff")
+		var pvz struct {
+			ID uuid.UUID `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPost, "/pvz", moderator, map[string]string{"city": "Москва"}, http.StatusCreated), &pvz))
+		pvzID := pvz.ID.String()
+		c.assign(moderator, staff, pvzID)
+		c.do(http.MethodPost, "/products/batch", staff, map[string]any{"pvzId": pvzID, "products": []map[string]string{{"type": "обувь"}}}, http.StatusBadRequest)
+		c.do(http.MethodPost, "/receptions", staff, map[string]string{"pvzId": pvzID}, http.StatusCreated)
+		c.do(http.MethodPost, "/products", staff, map[string]string{"pvzId": pvzID, "type": "обувь", "barcode": "111"}, http.StatusCreated)
+
+		// Act
+		batch := map[string]any{"pvzId": pvzID, "products": []map[string]string{
+			{"type": "электроника", "barcode": "222"},
+			{"type": "обувь", "barcode": "111"},
+			{"type": "еда"},
+			{"type": "одежда", "barcode": "222"},
+			{"type": "одежда"},
+		}}
+		var resp struct {
+			Accepted int `json:"accepted"`
+			Rejected int `json:"rejected"`
+			Results  []struct {
+				Index   int `json:"index"`
+				Product *struct {
+					Position int `json:"position"`
+				} `json:"product"`
+				Error string `json:"error"`
+			} `json:"results"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPost, "/products/batch", staff, batch, http.StatusOK), &resp))
+
+		// Assert: принятые товары продолжают позиции приёмки в порядке запроса
+		require.Equal(t, 2, resp.Accepted)
+		require.Equal(t, 3, resp.Rejected)
+		require.Len(t, resp.Results, 5)
+		require.Equal(t, 2, resp.Results[0].Product.Position)
+		require.Equal(t, 3, resp.Results[4].Product.Position)
+		for _, i := range []int{1, 2, 3} {
+			require.Equal(t, i, resp.Results[i].Index)
+			require.Nil(t, resp.Results[i].Product)
+			require.NotEmpty(t, resp.Results[i].Error)
+		}
+		c.do(http.MethodPost, "/products/batch", staff, map[string]any{"pvzId": pvzID, "products": []map[string]string{}}, http.StatusBadRequest)
+		c.do(http.MethodPost, "/products/batch", moderator, batch, http.StatusForbidden)
+	})
+
+	t.Run("удаление товара по id соответствует схеме", func(t *testing.T) {
+		// Arrange: в открытой приёмке два товара
+		c := newContractClient(t)
+		moderator := c.token("moderator")
+		staff := c.token("pvz_staff")
+		var pvz struct {
+			ID uuid.UUID `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPost, "/pvz", moderator, map[string]string{"city": "Москва"}, http.StatusCreated), &pvz))
+		pvzID := pvz.ID.String()
+		c.assign(moderator, staff, pvzID)
+		var rec, first, second struct {
+			ID uuid.UUID `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPost, "/receptions", staff, map[string]string{"pvzId": pvzID}, http.StatusCreated), &rec))
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPost, "/products", staff, map[string]string{"pvzId": pvzID, "type": "обувь"}, http.StatusCreated), &first))
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPost, "/products", staff, map[string]string{"pvzId": pvzID, "type": "одежда"}, http.StatusCreated), &second))
+		path := "/receptions/" + rec.ID.String() + "/products/"
+
+		// Act: удаляем первый (не последний) товар
+		c.do(http.MethodDelete, path+first.ID.String(), moderator, nil, http.StatusForbidden)
+		var removal struct {
+			Product struct {
+				ID uuid.UUID `json:"id"`
+			} `json:"product"`
+			RemovedBy uuid.UUID `json:"removedBy"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodDelete, path+first.ID.String(), staff, nil, http.StatusOK), &removal))
+
+		// Assert
+		require.Equal(t, first.ID, removal.Product.ID)
+		require.NotEqual(t, uuid.Nil, removal.RemovedBy)
+		c.do(http.MethodDelete, path+first.ID.String(), staff, nil, http.StatusNotFound)
+		c.do(http.MethodDelete, "/receptions/"+uuid.NewString()+"/products/"+second.ID.String(), staff, nil, http.StatusNotFound)
+		c.do(http.MethodPost, "/pvz/"+pvzID+"/close_last_reception", staff, nil, http.StatusOK)
+		c.do(http.MethodDelete, path+second.ID.String(), staff, nil, http.StatusBadRequest)
+	})
+
+	t.Run("переоткрытие приёмки и история статусов соответствуют схеме", func(t *testing.T) {
+		// Arrange: приёмка закрыта сотрудником
+		c := newContractClient(t)
+		moderator := c.token("moderator")
+		staff := c.token("pvz_staff")
+		var pvz struct {
+			ID uuid.UUID `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPost, "/pvz", moderator, map[string]string{"city": "Москва"}, http.StatusCreated), &pvz))
+		pvzID := pvz.ID.String()
+		c.assign(moderator, staff, pvzID)
+		var rec struct {
+			ID uuid.UUID `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPost, "/receptions", staff, map[string]string{"pvzId": pvzID}, http.StatusCreated), &rec))
+		var closed struct {
+			ClosedAt *time.Time `json:"closedAt"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPost, "/pvz/"+pvzID+"/close_last_reception", staff, nil, http.StatusOK), &closed))
+		require.NotNil(t, closed.ClosedAt)
+		path := "/receptions/" + rec.ID.String()
+
+		// Act
+		c.do(http.MethodPost, path+"/reopen", staff, map[string]string{"reason": "машина ещё разгружается"}, http.StatusForbidden)
+		c.do(http.MethodPost, path+"/reopen", moderator, map[string]string{"reason": " "}, http.StatusBadRequest)
+		var reopened struct {
+			Status   string     `json:"status"`
+			ClosedAt *time.Time `json:"closedAt"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPost, path+"/reopen", moderator, map[string]string{"reason": "машина ещё разгружается"}, http.StatusOK), &reopened))
+		var history []struct {
+			From   string `json:"from"`
+			To     string `json:"to"`
+			Reason string `json:"reason"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodGet, path+"/transitions", staff, nil, http.StatusOK), &history))
+
+		// Assert
+		require.Equal(t, "in_progress", reopened.Status)
+		require.Nil(t, reopened.ClosedAt)
+		require.Len(t, history, 2)
+		require.Equal(t, "close", history[0].To)
+		require.Equal(t, "in_progress", history[1].To)
+		require.Equal(t, "машина ещё разгружается", history[1].Reason)
+		c.do(http.MethodPost, path+"/reopen", moderator, map[string]string{"reason": "ещё раз"}, http.StatusConflict)
+		c.do(http.MethodPost, "/receptions/"+uuid.NewString()+"/reopen", moderator, map[string]string{"reason": "ещё раз"}, http.StatusNotFound)
+		c.do(http.MethodGet, "/receptions/"+uuid.NewString()+"/transitions", staff, nil, http.StatusNotFound)
+	})
+
+	t.Run("пауза, продолжение и отмена приёмки соответствуют схеме", func(t *testing.T) {
+		// Arrange: открытая приёмка с товаром
+		c := newContractClient(t)
+		moderator := c.token("moderator")
+		staff := c.token("pvz_staff")
+		var pvz struct {
+			ID uuid.UUID `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPost, "/pvz", moderator, map[string]string{"city": "Москва"}, http.StatusCreated), &pvz))
+		pvzID := pvz.ID.String()
+		c.assign(moderator, staff, pvzID)
+		var rec struct {
+			ID uuid.UUID `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPost, "/receptions", staff, map[string]string{"pvzId": pvzID}, http.StatusCreated), &rec))
+		c.do(http.MethodPost, "/products", staff, map[string]string{"pvzId": pvzID, "type": "обувь"}, http.StatusCreated)
+		path := "/receptions/" + rec.ID.String()
+
+		// Act: пауза останавливает сканирование, но ПВЗ остаётся занят
+		c.do(http.MethodPost, path+"/pause", moderator, nil, http.StatusForbidden)
+		var paused, cancelled struct {
+			Status string `json:"status"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPost, path+"/pause", staff, nil, http.StatusOK), &paused))
+		c.do(http.MethodPost, "/products", staff, map[string]string{"pvzId": pvzID, "type": "обувь"}, http.StatusBadRequest)
+		c.do(http.MethodPost, "/receptions", staff, map[string]string{"pvzId": pvzID}, http.StatusConflict)
+		c.do(http.MethodPost, path+"/pause", staff, nil, http.StatusConflict)
+		c.do(http.MethodPost, path+"/resume", staff, nil, http.StatusOK)
+		c.do(http.MethodPost, path+"/cancel", staff, map[string]string{"reason": " "}, http.StatusBadRequest)
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPost, path+"/cancel", staff, map[string]string{"reason": "машина уехала"}, http.StatusOK), &cancelled))
+		var detail struct {
+			Products []struct {
+				ID uuid.UUID `json:"id"`
+			} `json:"products"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodGet, path, staff, nil, http.StatusOK), &detail))
+		var history []struct {
+			To string `json:"to"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodGet, path+"/transitions", staff, nil, http.StatusOK), &history))
+
+		// Assert
+		require.Equal(t, "paused", paused.Status)
+		require.Equal(t, "cancelled", cancelled.Status)
+		require.Empty(t, detail.Products)
+		require.Len(t, history, 3)
+		require.Equal(t, "cancelled", history[2].To)
+		c.do(http.MethodPost, path+"/cancel", staff, map[string]string{"reason": "ещё раз"}, http.StatusConflict)
+		c.do(http.MethodPost, path+"/resume", staff, nil, http.StatusConflict)
+		c.do(http.MethodPost, "/receptions/"+uuid.NewString()+"/pause", staff, nil, http.StatusNotFound)
+		c.do(http.MethodPost, "/receptions", staff, map[string]string{"pvzId": pvzID}, http.StatusCreated)
+	})
+
+	t.Run("обмен refresh-токена и выход соответствуют схеме", func(t *testing.T) {
+		// Arrange: сессия модератора
+		c := newContractClient(t)
+		var login struct {
+			Token        string `json:"token"`
+			RefreshToken string `json:"refreshToken"`
+			ExpiresIn    int    `json:"expiresIn"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPost, "/dummyLogin", "", map[string]string{"role": "moderator"}, http.StatusOK), &login))
+
+		// Act
+		var refreshed struct {
+			Token        string `json:"token"`
+			RefreshToken string `json:"refreshToken"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPost, "/token/refresh", "", map[string]string{"refreshToken": login.RefreshToken}, http.StatusOK), &refreshed))
+		c.do(http.MethodPost, "/pvz", refreshed.Token, map[string]string{"city": "Москва"}, http.StatusCreated)
+		c.do(http.MethodPost, "/logout", refreshed.Token, nil, http.StatusNoContent)
+
+		// Assert: после выхода не принимаются ни access-токены сессии, ни её refresh-токен
+		require.Equal(t, 900, login.ExpiresIn)
+		require.NotEqual(t, login.RefreshToken, refreshed.RefreshToken)
+		c.do(http.MethodPost, "/pvz", refreshed.Token, map[string]string{"city": "Москва"}, http.StatusUnauthorized)
+		c.do(http.MethodPost, "/pvz", login.Token, map[string]string{"city": "Москва"}, http.StatusUnauthorized)
+		c.do(http.MethodPost, "/token/refresh", "", map[string]string{"refreshToken": refreshed.RefreshToken}, http.StatusUnauthorized)
+		c.do(http.MethodPost, "/logout", "", nil, http.StatusUnauthorized)
+	})
+
+	t.Run("защищённые операции без токена — 401 раньше разбора параметров", func(t *testing.T) {
+		// Arrange: все операции swagger.yaml с security, параметры заведомо неверные
+		c := newContractClient(t)
+		param := regexp.MustCompile(`\{[^}]+\}`)
+
+		for path, item := range c.doc.Paths.Map() {
+			for method, op := range item.Operations() {
+				if op.Security == nil || len(*op.Security) == 0 {
+					continue
+				}
+				// Act & Assert
+				c.do(method, param.ReplaceAllString(path, "not-a-uuid")+"?page=abc&limit=abc", "", nil, http.StatusUnauthorized)
+			}
+		}
+	})
+
+	t.Run("email тестового пользователя занят — /dummyLogin отвечает 409", func(t *testing.T) {
+		// Arrange: через /register заняли email тестового сотрудника
+		c := newContractClient(t)
+		c.do(http.MethodPost, "/register", "", map[string]string{"email": "dummy-pvz_staff@avito.ru", "password": "secret", "role": "pvz_staff"}, http.StatusCreated)
+
+		// Act & Assert: ошибка сразу при входе, а не позже при назначении на ПВЗ
+		c.do(http.MethodPost, "/dummyLogin", "", map[string]string{"role": "pvz_staff"}, http.StatusConflict)
+		c.do(http.MethodPost, "/dummyLogin", "", map[string]string{"role": "moderator"}, http.StatusOK)
+	})
+
+	t.Run("модератор завершает все сессии пользователя", func(t *testing.T) {
+		// Arrange: две сессии одного сотрудника (у /dummyLogin один пользователь на роль)
+		c := newContractClient(t)
+		moderator := c.token("moderator")
+		staff := c.token("pvz_staff")
+		staffAgain := c.token("pvz_staff")
+		var login struct {
+			RefreshToken string `json:"refreshToken"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPost, "/dummyLogin", "", map[string]string{"role": "pvz_staff"}, http.StatusOK), &login))
+		require.Equal(t, c.userID(staff), c.userID(staffAgain))
+
+		// Act
+		c.do(http.MethodPost, "/users/"+c.userID(staff)+"/revoke_sessions", staff, nil, http.StatusForbidden)
+		c.do(http.MethodPost, "/users/"+c.userID(staff)+"/revoke_sessions", moderator, nil, http.StatusNoContent)
+		c.do(http.MethodPost, "/users/"+uuid.NewString()+"/revoke_sessions", moderator, nil, http.StatusNoContent)
+
+		// Assert: не принимаются ни access-, ни refresh-токены сотрудника; сессия модератора жива
+		c.do(http.MethodGet, "/pvz", staff, nil, http.StatusUnauthorized)
+		c.do(http.MethodGet, "/pvz", staffAgain, nil, http.StatusUnauthorized)
+		c.do(http.MethodPost, "/token/refresh", "", map[string]string{"refreshToken": login.RefreshToken}, http.StatusUnauthorized)
+		c.do(http.MethodGet, "/pvz", moderator, nil, http.StatusOK)
+		c.do(http.MethodPost, "/users/not-a-uuid/revoke_sessions", moderator, nil, http.StatusBadRequest)
+	})
+
+	t.Run("повторное использование refresh-токена завершает сессию", func(t *testing.T) {
+		// Arrange
+		c := newContractClient(t)
+		var login, refreshed struct {
+			Token        string `json:"token"`
+			RefreshToken string `json:"refreshToken"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPost, "/dummyLogin", "", map[string]string{"role": "moderator"}, http.StatusOK), &login))
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPost, "/token/refresh", "", map[string]string{"refreshToken": login.RefreshToken}, http.StatusOK), &refreshed))
+
+		// Act
+		c.do(http.MethodPost, "/token/refresh", "", map[string]string{"refreshToken": login.RefreshToken}, http.StatusUnauthorized)
+
+		// Assert
+		c.do(http.MethodPost, "/pvz", refreshed.Token, map[string]string{"city": "Москва"}, http.StatusUnauthorized)
+		c.do(http.MethodPost, "/token/refresh", "", map[string]string{"refreshToken": refreshed.RefreshToken}, http.StatusUnauthorized)
+		c.do(http.MethodPost, "/token/refresh", "", map[string]string{"refreshToken": "garbage"}, http.StatusUnauthorized)
+	})
+
+	t.Run("назначение сотрудников на ПВЗ соответствует схеме", func(t *testing.T) {
+		// Arrange: два ПВЗ, сотрудник назначен только на первый, второй сотрудник — ни на какой
+		c := newContractClient(t)
+		moderator := c.token("moderator")
+		staff, stranger := c.token("pvz_staff"), c.registered("pvz_staff")
+		var own, other struct {
+			ID uuid.UUID `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPost, "/pvz", moderator, map[string]string{"city": "Москва"}, http.StatusCreated), &own))
+		require.NoError(t, json.Unmarshal(c.do(http.MethodPost, "/pvz", moderator, map[string]string{"city": "Казань"}, http.StatusCreated), &other))
+		ownPath, otherPath := "/pvz/"+own.ID.String(), "/pvz/"+other.ID.String()
+
+		// Act
+		c.assign(moderator, staff, own.ID.String())
+		c.assign(moderator, staff, own.ID.String())
+		var assigned []struct {
+			UserID     string `json:"userId"`
+			AssignedBy string `json:"assignedBy"`
+		}
+		require.NoError(t, json.Unmarshal(c.do(http.MethodGet, ownPath+"/staff", moderator, nil, http.StatusOK), &assigned))
+
+		// Assert: операции с приёмками доступны только на своём ПВЗ
+		require.Len(t, assigned, 1)
+		require.Equal(t, c.userID(staff), assigned[0].UserID)
+		require.Equal(t, c.userID(moderator), assigned[0].AssignedBy)
+		c.do(http.MethodPost, "/receptions", staff, map[string]string{"pvzId": own.ID.String()}, http.StatusCreated)
+		c.do(http.MethodPost, "/receptions", staff, map[string]string{"pvzId": other.ID.String()}, http.StatusForbidden)
+		c.do(http.MethodPost, "/products", stranger, map[string]string{"pvzId": own.ID.String(), "type": "обувь"}, http.StatusForbidden)
+		c.do(http.MethodPost, ownPath+"/delete_last_product", stranger, nil, http.StatusForbidden)
+		c.do(http.MethodPost, ownPath+"/close_last_reception", stranger, nil, http.StatusForbidden)
+		c.do(http.MethodPost, ownPath+"/close_last_reception", staff, nil, http.StatusOK)
+
+		// Снятие с ПВЗ закрывает доступ
+		c.do(http.MethodDelete, ownPath+"/staff/"+c.userID(staff), moderator, nil, http.StatusNoContent)
+		c.do(http.MethodDelete, ownPath+"/staff/"+c.userID(staff), moderator, nil, http.StatusNotFound)
+		c.do(http.MethodPost, "/receptions", staff, map[string]string{"pvzId": own.ID.String()}, http.StatusForbidden)
+		require.Equal(t, "[]", string(c.do(http.MethodGet, ownPath+"/staff", moderator, nil, http.StatusOK)))
+
+		// Права и ошибки
+		c.do(http.MethodPut, otherPath+"/staff/"+c.userID(stranger), staff, nil, http.StatusForbidden)
+		c.do(http.MethodGet, ownPath+"/staff", staff, nil, http.StatusForbidden)
+		c.do(http.MethodPut, "/pvz/"+uuid.NewString()+"/staff/"+c.userID(staff), moderator, nil, http.StatusNotFound)
+		c.do(http.MethodGet, "/pvz/"+uuid.NewString()+"/staff", moderator, nil, http.StatusNotFound)
+		c.do(http.MethodPut, otherPath+"/staff/not-a-uuid", moderator, nil, http.StatusBadRequest)
+		c.do(http.MethodPut, otherPath+"/staff/"+uuid.NewString(), moderator, nil, http.StatusNotFound)
+		c.do(http.MethodPut, otherPath+"/staff/"+c.userID(c.token("client")), moderator, nil, http.StatusBadRequest)
+		c.do(http.MethodPut, otherPath+"/staff/"+c.userID(moderator), moderator, nil, http.StatusBadRequest)
+		c.do(http.MethodPost, otherPath+"/archive", moderator, nil, http.StatusOK)
+		c.do(http.MethodPut, otherPath+"/staff/"+c.userID(staff), moderator, nil, http.StatusConflict)
+	})
+}
@@ -0,0 +1,74 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeoPointDistanceTo(t *testing.T) {
+	// Arrange
+	moscow := entities.GeoPoint{Lat: 55.7558, Lon: 37.6173}
+	spb := entities.GeoPoint{Lat: 59.9386, Lon: 30.3141}
+
+	// Act
+	d := moscow.DistanceTo(spb)
+
+	// Assert: около 634 км по большому кругу
+	assert.InDelta(t, 634_000, d, 2_000)
+	assert.InDelta(t, d, spb.DistanceTo(moscow), 1e-6)
+	assert.Zero(t, moscow.DistanceTo(moscow))
+}
+
+func TestGeoPointValid(t *testing.T) {
+	assert.True(t, entities.GeoPoint{Lat: -90, Lon: 180}.Valid())
+	assert.False(t, entities.GeoPoint{Lat: 90.1, Lon: 0}.Valid())
+	assert.False(t, entities.GeoPoint{Lat: 0, Lon: -180.1}.Valid())
+}
+
+func TestGeoPointBoundingBox(t *testing.T) {
+	t.Run("все точки круга внутри прямоугольника", func(t *testing.T) {
+		// Arrange
+		center := entities.GeoPoint{Lat: 55.7558, Lon: 37.6173}
+		radius := 5000.0
+
+		// Act
+		box := center.BoundingBox(radius)
+
+		// Assert: точки на границе круга по сторонам света попадают в прямоугольник
+		require.False(t, box.WholeLon)
+		for _, p := range []entities.GeoPoint{
+			{Lat: box.MaxLat, Lon: center.Lon},
+			{Lat: box.MinLat, Lon: center.Lon},
+		} {
+			assert.InDelta(t, radius, center.DistanceTo(p), 1)
+		}
+		assert.Greater(t, center.DistanceTo(entities.GeoPoint{Lat: center.Lat, Lon: box.MaxLon}), radius)
+		assert.Less(t, box.MinLon, center.Lon)
+	})
+
+	t.Run("пересечение 180-го меридиана — без ограничения по долготе", func(t *testing.T) {
+		box := entities.GeoPoint{Lat: 64.7, Lon: 179.99}.BoundingBox(5000)
+		assert.True(t, box.WholeLon)
+	})
+
+	t.Run("рядом с полюсом — без ограничения по долготе", func(t *testing.T) {
+		box := entities.GeoPoint{Lat: 89.99, Lon: 0}.BoundingBox(5000)
+		assert.True(t, box.WholeLon)
+		assert.Equal(t, 90.0, box.MaxLat)
+	})
+}
+
+func TestPVZDetailsNormalize(t *testing.T) {
+	// Act
+	d, ok := entities.PVZDetails{Name: "  ПВЗ  ", Address: " ул. Ленина, 1"}.Normalize()
+
+	// Assert
+	require.True(t, ok)
+	assert.Equal(t, "ПВЗ", d.Name)
+	assert.Equal(t, "ул. Ленина, 1", d.Address)
+	_, ok = entities.PVZDetails{WorkingHours: string(make([]rune, entities.PVZWorkingHoursMaxLen+1))}.Normalize()
+	assert.False(t, ok)
+}
@@ -0,0 +1,26 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPVZArchive(t *testing.T) {
+	// Arrange
+	p := entities.PVZ{City: entities.CityMoscow}
+	first := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+
+	// Act
+	p.Archive(first)
+	p.Archive(first.Add(time.Hour))
+
+	// Assert: повторная архивация не сдвигает дату
+	require.True(t, p.IsArchived())
+	require.Equal(t, first, *p.ArchivedAt)
+
+	p.Unarchive()
+	require.False(t, p.IsArchived())
+}
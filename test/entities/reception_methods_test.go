@@ -1,7 +1,9 @@
 package entities_test
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
@@ -84,3 +86,139 @@ func TestReceptionClose(t *testing.T) {
 	// Assert
 	assert.Error(t, err, "should not close already closed reception")
 }
+
+func TestReceptionReopen(t *testing.T) {
+	// Arrange
+	r := entities.Reception{Status: entities.ReceptionInProgress}
+	require.NoError(t, r.Close())
+	require.NotNil(t, r.ClosedAt)
+	closedAt := *r.ClosedAt
+
+	// Act + Assert: окно переоткрытия считается от времени закрытия включительно
+	assert.True(t, r.ClosedWithin(closedAt.Add(30*time.Minute), 30*time.Minute))
+	assert.False(t, r.ClosedWithin(closedAt.Add(31*time.Minute), 30*time.Minute))
+
+	// Act
+	err := r.Reopen()
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, r.IsOpen())
+	assert.Nil(t, r.ClosedAt)
+	assert.False(t, r.ClosedWithin(closedAt, time.Hour), "открытая приёмка не попадает в окно переоткрытия")
+	assert.Error(t, r.Reopen(), "should not reopen open reception")
+
+	// Закрытая до появления closed_at — время неизвестно, переоткрыть нельзя
+	legacy := entities.Reception{Status: entities.ReceptionClosed}
+	assert.False(t, legacy.ClosedWithin(time.Now(), time.Hour))
+}
+
+func TestNormalizeReceptionReason(t *testing.T) {
+	cases := map[string]struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		"пробелы по краям убираются": {in: "  машина ещё разгружается ", want: "машина ещё разгружается", ok: true},
+		"пустая причина":             {in: "   ", ok: false},
+		"ровно 500 символов":         {in: strings.Repeat("я", 500), want: strings.Repeat("я", 500), ok: true},
+		"длиннее 500 символов":       {in: strings.Repeat("я", 501), ok: false},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			got, ok := entities.NormalizeReceptionReason(tc.in)
+
+			// Assert
+			assert.Equal(t, tc.ok, ok)
+			if tc.ok {
+				assert.Equal(t, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestReceptionStateMachine(t *testing.T) {
+	statuses := []entities.ReceptionStatus{entities.ReceptionInProgress, entities.ReceptionPaused, entities.ReceptionClosed, entities.ReceptionCancelled}
+	allowed := map[[2]entities.ReceptionStatus]bool{
+		{entities.ReceptionInProgress, entities.ReceptionPaused}:    true,
+		{entities.ReceptionInProgress, entities.ReceptionClosed}:    true,
+		{entities.ReceptionInProgress, entities.ReceptionCancelled}: true,
+		{entities.ReceptionPaused, entities.ReceptionInProgress}:    true,
+		{entities.ReceptionPaused, entities.ReceptionClosed}:        true,
+		{entities.ReceptionPaused, entities.ReceptionCancelled}:     true,
+		{entities.ReceptionClosed, entities.ReceptionInProgress}:    true,
+	}
+
+	t.Run("таблица переходов", func(t *testing.T) {
+		for _, from := range statuses {
+			for _, to := range statuses {
+				// Act + Assert
+				assert.Equal(t, allowed[[2]entities.ReceptionStatus{from, to}], entities.CanTransition(from, to), "%s -> %s", from, to)
+			}
+		}
+	})
+
+	t.Run("пауза и продолжение: ПВЗ остаётся занят, сканировать нельзя", func(t *testing.T) {
+		// Arrange
+		r := entities.Reception{Status: entities.ReceptionInProgress}
+
+		// Act
+		require.NoError(t, r.Pause())
+
+		// Assert
+		assert.True(t, r.IsActive())
+		assert.False(t, r.IsOpen())
+		assert.Error(t, r.AddProduct(uuid.New()))
+		assert.Error(t, r.Pause(), "повторная пауза")
+		require.NoError(t, r.Resume())
+		assert.True(t, r.IsOpen())
+		assert.Error(t, r.Resume(), "продолжить можно только приёмку на паузе")
+	})
+
+	t.Run("закрытие с паузы", func(t *testing.T) {
+		// Arrange
+		r := entities.Reception{Status: entities.ReceptionPaused}
+
+		// Act
+		err := r.Close()
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, entities.ReceptionClosed, r.Status)
+		assert.NotNil(t, r.ClosedAt)
+	})
+
+	t.Run("отмена — конечный статус, ПВЗ освобождается", func(t *testing.T) {
+		// Arrange
+		r := entities.Reception{Status: entities.ReceptionPaused}
+
+		// Act
+		require.NoError(t, r.Cancel())
+
+		// Assert
+		assert.Equal(t, entities.ReceptionCancelled, r.Status)
+		assert.False(t, r.IsActive())
+		assert.Error(t, r.Cancel())
+		assert.Error(t, r.Reopen())
+		assert.Error(t, r.Close())
+		assert.False(t, r.ClosedWithin(time.Now(), time.Hour))
+	})
+
+	t.Run("закрытую приёмку нельзя поставить на паузу или отменить", func(t *testing.T) {
+		// Arrange
+		r := entities.Reception{Status: entities.ReceptionClosed}
+
+		// Act + Assert
+		assert.Error(t, r.Pause())
+		assert.Error(t, r.Cancel())
+		assert.Equal(t, entities.ReceptionClosed, r.Status)
+	})
+
+	t.Run("известные статусы", func(t *testing.T) {
+		for _, st := range statuses {
+			assert.True(t, entities.ValidateReceptionStatus(st))
+		}
+		assert.False(t, entities.ValidateReceptionStatus("open"))
+	})
+}
@@ -1,26 +1,13 @@
 package entities_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
 	"github.com/stretchr/testify/require"
 )
 
-func TestValidateCity(t *testing.T) {
-	// Arrange
-	valid := []entities.City{entities.CityMoscow, entities.CitySPB, entities.CityKazan}
-	invalid := []entities.City{"Тверь", "Воронеж", ""}
-
-	// Act & Assert
-	for _, c := range valid {
-		require.True(t, entities.ValidateCity(c), "city %s should be valid", c)
-	}
-	for _, c := range invalid {
-		require.False(t, entities.ValidateCity(c), "city %s should be invalid", c)
-	}
-}
-
 func TestValidateUserRole(t *testing.T) {
 	// Arrange
 	valid := []entities.UserRole{entities.UserRoleClient, entities.UserRoleModerator, entities.UserRolePVZStaff}
@@ -35,16 +22,74 @@ func TestValidateUserRole(t *testing.T) {
 	}
 }
 
-func TestValidateProductType(t *testing.T) {
+func TestValidateCatalogKind(t *testing.T) {
 	// Arrange
-	valid := []entities.ProductType{entities.ProductElectronics, entities.ProductClothes, entities.ProductShoes}
-	invalid := []entities.ProductType{"еда", "", "машина"}
+	valid := []entities.CatalogKind{entities.CatalogCity, entities.CatalogProductType}
+	invalid := []entities.CatalogKind{"users", "", "pvz"}
 
 	// Act & Assert
-	for _, tpe := range valid {
-		require.True(t, entities.ValidateProductType(tpe), "%s should be valid type", tpe)
+	for _, k := range valid {
+		require.True(t, entities.ValidateCatalogKind(k), "%s should be valid catalog", k)
+	}
+	for _, k := range invalid {
+		require.False(t, entities.ValidateCatalogKind(k), "%s should be invalid catalog", k)
 	}
-	for _, tpe := range invalid {
-		require.False(t, entities.ValidateProductType(tpe), "%s should be invalid type", tpe)
+}
+
+func TestNormalizeCatalogName(t *testing.T) {
+	// Arrange
+	cases := []struct {
+		name string
+		in   string
+		want string
+		ok   bool
+	}{
+		{"обычное название", "Новосибирск", "Новосибирск", true},
+		{"пробелы по краям", "  косметика ", "косметика", true},
+		{"пустое", "   ", "", false},
+		{"100 символов кириллицей", strings.Repeat("я", entities.CatalogEntryMaxLen), strings.Repeat("я", entities.CatalogEntryMaxLen), true},
+		{"длиннее лимита", strings.Repeat("я", entities.CatalogEntryMaxLen+1), "", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			got, ok := entities.NormalizeCatalogName(tc.in)
+
+			// Assert
+			require.Equal(t, tc.ok, ok)
+			if tc.ok {
+				require.Equal(t, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestNormalizeBarcode(t *testing.T) {
+	// Arrange
+	cases := []struct {
+		name string
+		in   string
+		want string
+		ok   bool
+	}{
+		{"EAN-13", "4601234567893", "4601234567893", true},
+		{"пробелы по краям", " AB-12/c ", "AB-12/c", true},
+		{"пустой — товар без штрихкода", "  ", "", true},
+		{"64 символа", strings.Repeat("9", entities.ProductBarcodeMaxLen), strings.Repeat("9", entities.ProductBarcodeMaxLen), true},
+		{"длиннее лимита", strings.Repeat("9", entities.ProductBarcodeMaxLen+1), "", false},
+		{"пробел внутри", "460 123", "", false},
+		{"кириллица", "штрихкод", "", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			got, ok := entities.NormalizeBarcode(tc.in)
+
+			// Assert
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.want, got)
+		})
 	}
 }
@@ -0,0 +1,345 @@
+package grpcserver_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/configs"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/infrastructure/grpcserver"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/controllers"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/pb/pvz_v1"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const testSecret = "test_secret"
+
+var testConfig = &configs.Config{JWTSecret: testSecret, JWTIssuer: "pvz-service", JWTAudience: "pvz-api", AccessTokenTTL: time.Minute, RefreshTokenTTL: time.Hour}
+
+// sessionStore — сессии в памяти: выдаются withToken, проверяются интерсептором
+type sessionStore struct {
+	mu      sync.Mutex
+	revoked map[uuid.UUID]bool
+}
+
+var sessions = &sessionStore{revoked: map[uuid.UUID]bool{}}
+
+func (s *sessionStore) CreateSession(_ context.Context, session entities.Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.revoked[session.ID] = false
+	return nil
+}
+
+func (s *sessionStore) SaveRefreshToken(context.Context, entities.RefreshToken) error { return nil }
+
+func (s *sessionStore) IsSessionActive(_ context.Context, id uuid.UUID) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	revoked, ok := s.revoked[id]
+	return ok && !revoked, nil
+}
+
+func (s *sessionStore) RevokeSession(_ context.Context, id uuid.UUID, _ time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.revoked[id] = true
+	return nil
+}
+
+type mockPVZRepo struct {
+	listFn func(ctx context.Context, startDate, endDate *time.Time, page, limit int) ([]entities.PVZ, error)
+}
+
+func (m *mockPVZRepo) List(ctx context.Context, startDate, endDate *time.Time, page, limit int, _ bool) ([]entities.PVZ, error) {
+	return m.listFn(ctx, startDate, endDate, page, limit)
+}
+
+// newTestClient поднимает gRPC-сервер поверх bufconn и возвращает клиента
+func newTestClient(t *testing.T, service *grpcserver.PVZGrpcService) pvz_v1.PVZServiceClient {
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpcserver.NewServer(service, controllers.NewTokenVerifier(testConfig, sessions), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return pvz_v1.NewPVZServiceClient(conn)
+}
+
+func TestPVZGrpcService_GetPVZList(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("happy path", func(t *testing.T) {
+		// Arrange
+		regDate := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+		pvzs := []entities.PVZ{
+			{ID: uuid.New(), RegistrationDate: regDate, City: entities.CityMoscow},
+			{ID: uuid.New(), RegistrationDate: regDate, City: entities.CityKazan},
+		}
+		repo := &mockPVZRepo{listFn: func(ctx context.Context, startDate, endDate *time.Time, page, limit int) ([]entities.PVZ, error) {
+			// Без фильтров и пагинации
+			assert.Nil(t, startDate)
+			assert.Nil(t, endDate)
+			assert.Equal(t, 0, page)
+			assert.Equal(t, 0, limit)
+			return pvzs, nil
+		}}
+		// Токен не передаём — метод публичный
+		client := newTestClient(t, grpcserver.NewPVZGrpcService(repo, nil, nil, nil, nil, nil))
+
+		// Act
+		resp, err := client.GetPVZList(ctx, &pvz_v1.GetPVZListRequest{})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, resp.Pvzs, 2)
+		assert.Equal(t, pvzs[0].ID.String(), resp.Pvzs[0].Id)
+		assert.Equal(t, string(entities.CityMoscow), resp.Pvzs[0].City)
+		assert.True(t, regDate.Equal(resp.Pvzs[0].RegistrationDate.AsTime()))
+		assert.Equal(t, string(entities.CityKazan), resp.Pvzs[1].City)
+	})
+
+	t.Run("ошибка репозитория", func(t *testing.T) {
+		repo := &mockPVZRepo{listFn: func(context.Context, *time.Time, *time.Time, int, int) ([]entities.PVZ, error) {
+			return nil, assert.AnError
+		}}
+		client := newTestClient(t, grpcserver.NewPVZGrpcService(repo, nil, nil, nil, nil, nil))
+
+		_, err := client.GetPVZList(ctx, &pvz_v1.GetPVZListRequest{})
+
+		require.Error(t, err)
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+type mockCreatePVZUC struct{ mock.Mock }
+
+func (m *mockCreatePVZUC) Execute(ctx context.Context, user entities.User, city entities.City, details entities.PVZDetails) (entities.PVZ, error) {
+	args := m.Called(ctx, user, city, details)
+	return args.Get(0).(entities.PVZ), args.Error(1)
+}
+
+type mockCreateReceptionUC struct{ mock.Mock }
+
+func (m *mockCreateReceptionUC) Execute(ctx context.Context, user entities.User, pvzID uuid.UUID) (entities.Reception, error) {
+	args := m.Called(ctx, user, pvzID)
+	return args.Get(0).(entities.Reception), args.Error(1)
+}
+
+type mockAddProductUC struct{ mock.Mock }
+
+func (m *mockAddProductUC) Execute(ctx context.Context, user entities.User, pvzID uuid.UUID, productType entities.ProductType, barcode string) (entities.Product, error) {
+	args := m.Called(ctx, user, pvzID, productType, barcode)
+	return args.Get(0).(entities.Product), args.Error(1)
+}
+
+type mockDeleteLastProductUC struct{ mock.Mock }
+
+func (m *mockDeleteLastProductUC) Execute(ctx context.Context, user entities.User, pvzID uuid.UUID) error {
+	args := m.Called(ctx, user, pvzID)
+	return args.Error(0)
+}
+
+type mockCloseReceptionUC struct{ mock.Mock }
+
+func (m *mockCloseReceptionUC) Execute(ctx context.Context, user entities.User, pvzID uuid.UUID) (entities.Reception, error) {
+	args := m.Called(ctx, user, pvzID)
+	return args.Get(0).(entities.Reception), args.Error(1)
+}
+
+// nopDummyUsers — тестовые пользователи /dummyLogin никуда не сохраняются
+type nopDummyUsers struct{}
+
+func (nopDummyUsers) EnsureUser(context.Context, entities.User) error { return nil }
+
+// withToken выпускает токен для роли и кладёт его в исходящие метаданные
+func withToken(t *testing.T, role entities.UserRole) context.Context {
+	pair, err := usecases.NewDummyLoginUseCase(nopDummyUsers{}, usecases.NewTokenIssuer(sessions, testConfig)).Execute(context.Background(), role)
+	require.NoError(t, err)
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+pair.AccessToken)
+}
+
+var anyCtx = mock.MatchedBy(func(ctx context.Context) bool { return true })
+
+// userFromToken совпадает с пользователем из токена dummyLogin: у роли один пользователь с постоянными id и email
+func userFromToken(role entities.UserRole) any {
+	return mock.MatchedBy(func(u entities.User) bool {
+		return u.Role == role && u.Email == "dummy-"+string(role)+"@avito.ru" && u.ID != uuid.Nil
+	})
+}
+
+func TestAuthUnaryInterceptor(t *testing.T) {
+	createPVZ := new(mockCreatePVZUC)
+	client := newTestClient(t, grpcserver.NewPVZGrpcService(nil, createPVZ, nil, nil, nil, nil))
+
+	t.Run("нет токена", func(t *testing.T) {
+		_, err := client.CreatePVZ(context.Background(), &pvz_v1.CreatePVZRequest{City: string(entities.CityMoscow)})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("невалидный токен", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer garbage")
+		_, err := client.CreatePVZ(ctx, &pvz_v1.CreatePVZRequest{City: string(entities.CityMoscow)})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("токен отозванной сессии", func(t *testing.T) {
+		ctx := withToken(t, entities.UserRoleModerator)
+		sessions.mu.Lock()
+		for id := range sessions.revoked {
+			sessions.revoked[id] = true
+		}
+		sessions.mu.Unlock()
+
+		_, err := client.CreatePVZ(ctx, &pvz_v1.CreatePVZRequest{City: string(entities.CityMoscow)})
+
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("пользователь из токена попадает в usecase", func(t *testing.T) {
+		user := userFromToken(entities.UserRoleModerator)
+		details := entities.PVZDetails{Name: "ПВЗ на Тверской", Location: &entities.GeoPoint{Lat: 55.7575, Lon: 37.6134}}
+		pvz := entities.PVZ{ID: uuid.New(), City: entities.CityMoscow, PVZDetails: details}
+		createPVZ.On("Execute", anyCtx, user, entities.CityMoscow, details).Return(pvz, nil).Once()
+
+		resp, err := client.CreatePVZ(withToken(t, entities.UserRoleModerator), &pvz_v1.CreatePVZRequest{
+			City:     string(entities.CityMoscow),
+			Name:     details.Name,
+			Location: &pvz_v1.GeoPoint{Lat: 55.7575, Lon: 37.6134},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, pvz.ID.String(), resp.Id)
+		assert.Equal(t, details.Name, resp.Name)
+		assert.InDelta(t, 37.6134, resp.GetLocation().GetLon(), 1e-9)
+		createPVZ.AssertExpectations(t)
+	})
+}
+
+// verifierFunc — TokenVerifier без JWT и хранилища сессий
+type verifierFunc func(token string) (entities.User, error)
+
+func (f verifierFunc) Verify(_ context.Context, token string) (entities.User, uuid.UUID, error) {
+	user, err := f(token)
+	return user, uuid.New(), err
+}
+
+func TestAuthUnaryInterceptor_Verifier(t *testing.T) {
+	// Arrange: интерсептор с подменённой проверкой токена
+	moderator := entities.User{ID: uuid.New(), Role: entities.UserRoleModerator}
+	interceptor := grpcserver.AuthUnaryInterceptor(verifierFunc(func(token string) (entities.User, error) {
+		if token != "good" {
+			return entities.User{}, usecases.ErrUnauthorized
+		}
+		return moderator, nil
+	}))
+	info := &grpc.UnaryServerInfo{FullMethod: pvz_v1.PVZService_CreatePVZ_FullMethodName}
+	handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		user, ok := grpcserver.UserFromContext(ctx)
+		require.True(t, ok)
+		return user, nil
+	}
+	incoming := func(auth string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", auth))
+	}
+
+	// Act
+	got, err := interceptor(incoming("Bearer good"), nil, info, handler)
+	_, errBad := interceptor(incoming("Bearer bad"), nil, info, handler)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, moderator, got)
+	assert.Equal(t, codes.Unauthenticated, status.Code(errBad))
+}
+
+func TestPVZGrpcService_ReceptionFlow(t *testing.T) {
+	staff := userFromToken(entities.UserRolePVZStaff)
+	pvzID := uuid.New()
+	recID := uuid.New()
+	createRec := new(mockCreateReceptionUC)
+	addProduct := new(mockAddProductUC)
+	deleteLast := new(mockDeleteLastProductUC)
+	closeRec := new(mockCloseReceptionUC)
+	client := newTestClient(t, grpcserver.NewPVZGrpcService(nil, nil, createRec, addProduct, deleteLast, closeRec))
+	ctx := withToken(t, entities.UserRolePVZStaff)
+
+	t.Run("CreateReception", func(t *testing.T) {
+		createRec.On("Execute", anyCtx, staff, pvzID).
+			Return(entities.Reception{ID: recID, PVZID: pvzID, Status: entities.ReceptionInProgress}, nil).Once()
+
+		resp, err := client.CreateReception(ctx, &pvz_v1.CreateReceptionRequest{PvzId: pvzID.String()})
+
+		require.NoError(t, err)
+		assert.Equal(t, recID.String(), resp.Id)
+		assert.Equal(t, pvz_v1.ReceptionStatus_RECEPTION_STATUS_IN_PROGRESS, resp.Status)
+	})
+
+	t.Run("AddProduct", func(t *testing.T) {
+		product := entities.Product{ID: uuid.New(), ReceptionID: recID, Type: entities.ProductShoes, Barcode: "4601234567893"}
+		addProduct.On("Execute", anyCtx, staff, pvzID, entities.ProductShoes, "4601234567893").Return(product, nil).Once()
+
+		resp, err := client.AddProduct(ctx, &pvz_v1.AddProductRequest{PvzId: pvzID.String(), Type: string(entities.ProductShoes), Barcode: "4601234567893"})
+
+		require.NoError(t, err)
+		assert.Equal(t, product.ID.String(), resp.Id)
+		assert.Equal(t, recID.String(), resp.ReceptionId)
+		assert.Equal(t, "4601234567893", resp.Barcode)
+	})
+
+	t.Run("AddProduct: ошибка usecase", func(t *testing.T) {
+		addProduct.On("Execute", anyCtx, staff, pvzID, entities.ProductType("еда"), "").Return(entities.Product{}, usecases.ErrInvalidProductType).Once()
+
+		_, err := client.AddProduct(ctx, &pvz_v1.AddProductRequest{PvzId: pvzID.String(), Type: "еда"})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("DeleteLastProduct", func(t *testing.T) {
+		deleteLast.On("Execute", anyCtx, staff, pvzID).Return(nil).Once()
+
+		_, err := client.DeleteLastProduct(ctx, &pvz_v1.DeleteLastProductRequest{PvzId: pvzID.String()})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("CloseLastReception", func(t *testing.T) {
+		closeRec.On("Execute", anyCtx, staff, pvzID).
+			Return(entities.Reception{ID: recID, PVZID: pvzID, Status: entities.ReceptionClosed}, nil).Once()
+
+		resp, err := client.CloseLastReception(ctx, &pvz_v1.CloseLastReceptionRequest{PvzId: pvzID.String()})
+
+		require.NoError(t, err)
+		assert.Equal(t, pvz_v1.ReceptionStatus_RECEPTION_STATUS_CLOSED, resp.Status)
+	})
+
+	t.Run("некорректный pvzId", func(t *testing.T) {
+		_, err := client.CloseLastReception(ctx, &pvz_v1.CloseLastReceptionRequest{PvzId: "not-a-uuid"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	createRec.AssertExpectations(t)
+	addProduct.AssertExpectations(t)
+	deleteLast.AssertExpectations(t)
+	closeRec.AssertExpectations(t)
+}
@@ -0,0 +1,56 @@
+package metrics_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/infrastructure/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(t *testing.T, e *metrics.PrometheusExporter) string {
+	w := httptest.NewRecorder()
+	e.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	body, err := io.ReadAll(w.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestPrometheusExporter_GinMiddleware(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	e := metrics.NewPrometheusExporter()
+	r := gin.New()
+	r.Use(e.GinMiddleware())
+	r.GET("/pvz/:pvzId", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+	// Act
+	for _, path := range []string{"/pvz/1", "/pvz/2", "/unknown"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	// Assert: метка route — шаблон маршрута, а не конкретный путь
+	body := scrape(t, e)
+	assert.Contains(t, body, `http_requests_total{method="GET",route="/pvz/:pvzId",status="200"} 2`)
+	assert.Contains(t, body, `http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	assert.Contains(t, body, `http_request_duration_seconds_bucket{method="GET",route="/pvz/:pvzId",status="200",le="0.1"} 2`)
+}
+
+func TestPrometheusExporter_BusinessMetrics(t *testing.T) {
+	e := metrics.NewPrometheusExporter()
+
+	e.IncPVZCreated()
+	e.IncReceptionCreated()
+	e.IncReceptionCreated()
+	e.IncProductAdded()
+
+	body := scrape(t, e)
+	assert.Contains(t, body, "pvz_created_total 1")
+	assert.Contains(t, body, "receptions_created_total 2")
+	assert.Contains(t, body, "products_added_total 1")
+}
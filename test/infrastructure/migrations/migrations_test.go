@@ -0,0 +1,152 @@
+package migrations_test
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/joho/godotenv"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/configs"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/infrastructure/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	// Загружаем переменные окружения из .env файла
+	_ = godotenv.Load("../../../.env")
+}
+
+func TestLoad(t *testing.T) {
+	// Act
+	migs, err := migrations.Load()
+
+	// Assert: версии идут подряд с 1, у каждой есть up и down
+	require.NoError(t, err)
+	require.NotEmpty(t, migs)
+	for i, m := range migs {
+		assert.Equal(t, int64(i+1), m.Version, "версии должны идти подряд")
+		assert.NotEmpty(t, m.Name)
+		assert.NotEmpty(t, strings.TrimSpace(m.Up))
+		assert.NotEmpty(t, strings.TrimSpace(m.Down))
+	}
+}
+
+// setupSchemaDB открывает соединение с отдельной схемой, чтобы откаты не мешали
+// тестам других пакетов, которые параллельно работают с той же БД
+func setupSchemaDB(t *testing.T, schema string) *sql.DB {
+	dsn := configs.GetTestPGDSN()
+	if dsn == "" {
+		t.Skip("TEST_PG_DSN not set")
+	}
+	admin, err := sql.Open("pgx", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { admin.Close() })
+	_, err = admin.Exec(`DROP SCHEMA IF EXISTS ` + schema + ` CASCADE; CREATE SCHEMA ` + schema)
+	require.NoError(t, err)
+	t.Cleanup(func() { _, _ = admin.Exec(`DROP SCHEMA IF EXISTS ` + schema + ` CASCADE`) })
+
+	sep := "?"
+	if strings.Contains(dsn, "?") {
+		sep = "&"
+	}
+	db, err := sql.Open("pgx", dsn+sep+"search_path="+schema)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func tableExists(t *testing.T, db *sql.DB, name string) bool {
+	var exists bool
+	require.NoError(t, db.QueryRow(`SELECT to_regclass($1) IS NOT NULL`, name).Scan(&exists))
+	return exists
+}
+
+func TestMigrator_UpDownTo(t *testing.T) {
+	// Arrange
+	db := setupSchemaDB(t, "migrations_test_updown")
+	m, err := migrations.NewMigrator(db)
+	require.NoError(t, err)
+	ctx := context.Background()
+	latest := m.Latest()
+
+	// Act: up
+	require.NoError(t, m.Up(ctx))
+
+	// Assert
+	st, err := m.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, latest, st.Current)
+	assert.Empty(t, st.Pending)
+	assert.True(t, tableExists(t, db, "product"))
+
+	// Act: повторный up ничего не делает
+	require.NoError(t, m.Up(ctx))
+
+	// Act: down откатывает одну версию
+	require.NoError(t, m.Down(ctx))
+	st, err = m.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, latest-1, st.Current)
+	require.Len(t, st.Pending, 1)
+	assert.Equal(t, latest, st.Pending[0].Version)
+
+	// Act: to 0 откатывает всё
+	require.NoError(t, m.To(ctx, 0))
+	st, err = m.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), st.Current)
+	assert.False(t, tableExists(t, db, "product"))
+	assert.False(t, tableExists(t, db, "users"))
+
+	// Act: to N поднимает до конкретной версии
+	require.NoError(t, m.To(ctx, 1))
+	st, err = m.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), st.Current)
+	assert.True(t, tableExists(t, db, "product"))
+
+	// Act: неизвестная версия
+	assert.Error(t, m.To(ctx, latest+1))
+}
+
+func TestMigrator_AdoptsLegacySchemaMigrations(t *testing.T) {
+	// Arrange: схема накатана golang-migrate до версии 1
+	db := setupSchemaDB(t, "migrations_test_legacy")
+	migs, err := migrations.Load()
+	require.NoError(t, err)
+	_, err = db.Exec(migs[0].Up)
+	require.NoError(t, err)
+	_, err = db.Exec(`CREATE TABLE schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL);
+INSERT INTO schema_migrations (version, dirty) VALUES (1, false)`)
+	require.NoError(t, err)
+	m, err := migrations.NewMigrator(db)
+	require.NoError(t, err)
+
+	// Act
+	st, err := m.Status(context.Background())
+
+	// Assert: версия 1 считается применённой, остальные ждут
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), st.Current)
+	assert.Len(t, st.Pending, len(migs)-1)
+	require.NoError(t, m.Up(context.Background()))
+}
+
+func TestMigrator_RefusesDirtyLegacySchema(t *testing.T) {
+	// Arrange
+	db := setupSchemaDB(t, "migrations_test_dirty")
+	_, err := db.Exec(`CREATE TABLE schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL);
+INSERT INTO schema_migrations (version, dirty) VALUES (2, true)`)
+	require.NoError(t, err)
+	m, err := migrations.NewMigrator(db)
+	require.NoError(t, err)
+
+	// Act
+	err = m.Up(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "dirty")
+}
@@ -0,0 +1,69 @@
+package infrastructure_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/infrastructure/repositories"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPGCatalogRepository(t *testing.T) {
+	// Arrange: справочники общие для всех тестовых пакетов, поэтому только свои уникальные названия
+	db := setupPVZTestDB(t)
+	repo := repositories.NewPGCatalogRepository(db)
+	ctx := context.Background()
+	city := "Город-" + uuid.NewString()[:8]
+	renamed := city + "-2"
+	t.Cleanup(func() {
+		_, _ = db.Exec(`DELETE FROM pvz WHERE city IN ($1, $2)`, city, renamed)
+		_, _ = db.Exec(`DELETE FROM city WHERE name IN ($1, $2)`, city, renamed)
+	})
+
+	// Act: add
+	added, err := repo.Add(ctx, entities.CatalogCity, city)
+	require.NoError(t, err)
+	require.True(t, added.Active)
+	_, err = repo.Add(ctx, entities.CatalogCity, city)
+	require.ErrorIs(t, err, usecases.ErrCatalogEntryExists)
+
+	// Act: список содержит начальное наполнение и новый город
+	list, err := repo.List(ctx, entities.CatalogCity)
+	require.NoError(t, err)
+	names := map[string]bool{}
+	for _, e := range list {
+		names[e.Name] = true
+	}
+	require.True(t, names[string(entities.CityMoscow)])
+	require.True(t, names[city])
+
+	// Act: переименование переносится на созданные ПВЗ
+	pvzID := uuid.New()
+	_, err = db.Exec(`INSERT INTO pvz (id, registration_date, city) VALUES ($1, $2, $3)`, pvzID, time.Now().UTC(), city)
+	require.NoError(t, err)
+	inactive := false
+	updated, err := repo.Update(ctx, entities.CatalogCity, added.ID, usecases.CatalogEntryPatch{Name: &renamed, Active: &inactive})
+	require.NoError(t, err)
+	require.NotNil(t, updated)
+	require.Equal(t, renamed, updated.Name)
+	require.False(t, updated.Active)
+	var pvzCity string
+	require.NoError(t, db.QueryRow(`SELECT city FROM pvz WHERE id = $1`, pvzID).Scan(&pvzCity))
+	require.Equal(t, renamed, pvzCity)
+
+	// Act: занятое имя и несуществующий id
+	moscow := string(entities.CityMoscow)
+	_, err = repo.Update(ctx, entities.CatalogCity, added.ID, usecases.CatalogEntryPatch{Name: &moscow})
+	require.ErrorIs(t, err, usecases.ErrCatalogEntryExists)
+	missing, err := repo.Update(ctx, entities.CatalogCity, -1, usecases.CatalogEntryPatch{Active: &inactive})
+	require.NoError(t, err)
+	require.Nil(t, missing)
+
+	// Assert: ПВЗ в городе вне справочника не создать
+	_, err = db.Exec(`INSERT INTO pvz (id, registration_date, city) VALUES ($1, $2, $3)`, uuid.New(), time.Now().UTC(), "Город-которого-нет")
+	require.Error(t, err)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"os"
+	"strconv"
 	"testing"
 	"time"
 
@@ -12,7 +13,9 @@ import (
 	"github.com/joho/godotenv"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/configs"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/infrastructure/migrations"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/infrastructure/repositories"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -36,25 +39,13 @@ func setupProductTestDB(t *testing.T) *sql.DB {
 	db, err := sql.Open("pgx", dsn)
 	require.NoError(t, err)
 	t.Cleanup(func() { db.Close() })
+	// Схема — только из встроенных миграций, как в проде
+	m, err := migrations.NewMigrator(db)
+	require.NoError(t, err)
+	require.NoError(t, m.Up(context.Background()))
 	_, err = db.Exec(`
-CREATE TABLE IF NOT EXISTS pvz (
-    id UUID PRIMARY KEY,
-    registration_date TIMESTAMPTZ NOT NULL,
-    city TEXT NOT NULL
-);
-CREATE TABLE IF NOT EXISTS reception (
-    id UUID PRIMARY KEY,
-    pvz_id UUID NOT NULL REFERENCES pvz(id),
-    status TEXT NOT NULL,
-    date_time TIMESTAMPTZ NOT NULL
-);
-CREATE TABLE IF NOT EXISTS product (
-    id UUID PRIMARY KEY,
-    reception_id UUID NOT NULL REFERENCES reception(id),
-    type TEXT NOT NULL,
-    date_time TIMESTAMPTZ NOT NULL
-);
--- Очищаем таблицы в правильном порядке с учетом внешних ключей
+DELETE FROM product_removal;
+DELETE FROM reception_transition;
 DELETE FROM product;
 DELETE FROM reception;
 DELETE FROM pvz;
@@ -179,3 +170,233 @@ func TestPGProductRepository_ListByReception(t *testing.T) {
 	assert.Equal(t, products[1].ID, found[1].ID)
 	assert.Equal(t, products[2].ID, found[2].ID)
 }
+
+// TestPGProductRepository_Position — порядок LIFO задаёт позиция, а не date_time
+func TestPGProductRepository_Position(t *testing.T) {
+	// Arrange: три товара с одинаковым временем
+	db := setupProductTestDB(t)
+	repo := repositories.NewPGProductRepository(db)
+	ctx := context.Background()
+	pvzID := uuid.New()
+	_, err := db.Exec(`INSERT INTO pvz (id, registration_date, city) VALUES ($1, $2, $3)`, pvzID, time.Now().UTC(), "Москва")
+	require.NoError(t, err)
+	recID := uuid.New()
+	_, err = db.Exec(`INSERT INTO reception (id, pvz_id, status, date_time) VALUES ($1, $2, $3, $4)`, recID, pvzID, "in_progress", time.Now().UTC())
+	require.NoError(t, err)
+	sameTime := time.Now().UTC()
+	var saved []entities.Product
+	for _, typ := range []entities.ProductType{entities.ProductElectronics, entities.ProductClothes, entities.ProductShoes} {
+		p, err := repo.Save(ctx, entities.Product{ID: uuid.New(), ReceptionID: recID, Type: typ, DateTime: sameTime})
+		require.NoError(t, err)
+		saved = append(saved, p)
+	}
+
+	// Assert: позиции 1, 2, 3 и список в том же порядке
+	for i, p := range saved {
+		assert.Equal(t, i+1, p.Position)
+	}
+	list, err := repo.ListByReception(ctx, recID)
+	require.NoError(t, err)
+	require.Equal(t, saved, list)
+
+	// Act: удаляется товар с наибольшей позицией
+	deleted, err := repo.DeleteLast(ctx, recID)
+	require.NoError(t, err)
+	require.NotNil(t, deleted)
+	assert.Equal(t, saved[2].ID, deleted.ID)
+	assert.Equal(t, 3, deleted.Position)
+
+	// Act: следующий товар занимает освободившуюся позицию
+	next, err := repo.Save(ctx, entities.Product{ID: uuid.New(), ReceptionID: recID, Type: entities.ProductShoes, DateTime: sameTime.Add(-time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, 3, next.Position)
+	deleted, err = repo.DeleteLast(ctx, recID)
+	require.NoError(t, err)
+	assert.Equal(t, next.ID, deleted.ID)
+}
+
+func TestPGProductRepository_Barcode(t *testing.T) {
+	// Arrange: посылка принята в закрытую приёмку одного ПВЗ и снова — в открытую приёмку другого
+	db := setupProductTestDB(t)
+	repo := repositories.NewPGProductRepository(db)
+	ctx := context.Background()
+	pvzA, pvzB := uuid.New(), uuid.New()
+	_, err := db.Exec(`INSERT INTO pvz (id, registration_date, city, name) VALUES ($1, $3, 'Москва', 'А'), ($2, $3, 'Казань', 'Б')`, pvzA, pvzB, time.Now().UTC())
+	require.NoError(t, err)
+	closedRec, openRec := uuid.New(), uuid.New()
+	_, err = db.Exec(`INSERT INTO reception (id, pvz_id, status, date_time) VALUES ($1, $2, 'close', $5), ($3, $4, 'in_progress', $6)`,
+		closedRec, pvzA, openRec, pvzB, time.Now().Add(-time.Hour).UTC(), time.Now().UTC())
+	require.NoError(t, err)
+	first, err := repo.Save(ctx, entities.Product{ID: uuid.New(), ReceptionID: closedRec, Type: entities.ProductShoes, DateTime: time.Now().Add(-time.Hour).UTC(), Barcode: "4601234567893"})
+	require.NoError(t, err)
+	second, err := repo.Save(ctx, entities.Product{ID: uuid.New(), ReceptionID: openRec, Type: entities.ProductShoes, DateTime: time.Now().UTC(), Barcode: "4601234567893"})
+	require.NoError(t, err)
+	_, err = repo.Save(ctx, entities.Product{ID: uuid.New(), ReceptionID: openRec, Type: entities.ProductClothes, DateTime: time.Now().UTC()})
+	require.NoError(t, err)
+
+	t.Run("повторный штрихкод в той же приёмке — конфликт", func(t *testing.T) {
+		// Act
+		_, err := repo.Save(ctx, entities.Product{ID: uuid.New(), ReceptionID: openRec, Type: entities.ProductShoes, DateTime: time.Now().UTC(), Barcode: "4601234567893"})
+
+		// Assert
+		require.ErrorIs(t, err, usecases.ErrDuplicateBarcode)
+	})
+
+	t.Run("поиск возвращает ПВЗ и приёмки, новые первыми", func(t *testing.T) {
+		// Act
+		locations, err := repo.FindByBarcode(ctx, "4601234567893")
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, locations, 2)
+		assert.Equal(t, second.ID, locations[0].Product.ID)
+		assert.Equal(t, "4601234567893", locations[0].Product.Barcode)
+		assert.Equal(t, openRec, locations[0].Reception.ID)
+		assert.Equal(t, entities.ReceptionInProgress, locations[0].Reception.Status)
+		assert.Equal(t, pvzB, locations[0].PVZ.ID)
+		assert.Equal(t, "Б", locations[0].PVZ.Name)
+		assert.Equal(t, first.ID, locations[1].Product.ID)
+		assert.Equal(t, pvzA, locations[1].PVZ.ID)
+	})
+
+	t.Run("товары без штрихкода читаются с пустым barcode, неизвестный штрихкод — пустой список", func(t *testing.T) {
+		// Act
+		list, err := repo.ListByReception(ctx, openRec)
+		require.NoError(t, err)
+		missing, err := repo.FindByBarcode(ctx, "000")
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+		assert.Empty(t, list[1].Barcode)
+		require.NotNil(t, missing)
+		assert.Empty(t, missing)
+	})
+}
+
+func TestPGProductRepository_SaveBatch(t *testing.T) {
+	// Arrange: в приёмке уже есть товар, пакет максимального размера
+	db := setupProductTestDB(t)
+	repo := repositories.NewPGProductRepository(db)
+	ctx := context.Background()
+	pvzID := uuid.New()
+	_, err := db.Exec(`INSERT INTO pvz (id, registration_date, city) VALUES ($1, $2, $3)`, pvzID, time.Now().UTC(), "Москва")
+	require.NoError(t, err)
+	recID := uuid.New()
+	_, err = db.Exec(`INSERT INTO reception (id, pvz_id, status, date_time) VALUES ($1, $2, $3, $4)`, recID, pvzID, "in_progress", time.Now().UTC())
+	require.NoError(t, err)
+	_, err = repo.Save(ctx, entities.Product{ID: uuid.New(), ReceptionID: recID, Type: entities.ProductShoes, DateTime: time.Now().UTC(), Barcode: "0"})
+	require.NoError(t, err)
+	batch := make([]entities.Product, usecases.ProductBatchMaxSize)
+	for i := range batch {
+		batch[i] = entities.Product{ID: uuid.New(), Type: entities.ProductClothes, DateTime: time.Now().UTC(), Barcode: strconv.Itoa(i + 1)}
+	}
+
+	// Act
+	saved, err := repo.SaveBatch(ctx, recID, batch)
+
+	// Assert: позиции продолжают приёмку в порядке пакета
+	require.NoError(t, err)
+	require.Len(t, saved, len(batch))
+	assert.Equal(t, 2, saved[0].Position)
+	assert.Equal(t, len(batch)+1, saved[len(saved)-1].Position)
+	list, err := repo.ListByReception(ctx, recID)
+	require.NoError(t, err)
+	require.Len(t, list, len(batch)+1)
+	assert.Equal(t, saved[len(saved)-1].ID, list[len(batch)].ID)
+
+	t.Run("уже отсканированные штрихкоды", func(t *testing.T) {
+		// Act
+		existing, err := repo.ExistingBarcodes(ctx, recID, []string{"0", "1000", "1001"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"0", "1000"}, existing)
+	})
+
+	t.Run("повтор штрихкода — конфликт, пакет не вставлен", func(t *testing.T) {
+		// Act
+		_, err := repo.SaveBatch(ctx, recID, []entities.Product{
+			{ID: uuid.New(), Type: entities.ProductShoes, DateTime: time.Now().UTC(), Barcode: "new"},
+			{ID: uuid.New(), Type: entities.ProductShoes, DateTime: time.Now().UTC(), Barcode: "7"},
+		})
+
+		// Assert
+		require.ErrorIs(t, err, usecases.ErrDuplicateBarcode)
+		existing, err := repo.ExistingBarcodes(ctx, recID, []string{"new"})
+		require.NoError(t, err)
+		assert.Empty(t, existing)
+	})
+}
+
+func TestPGProductRepository_DeleteByIDWithRemoval(t *testing.T) {
+	// Arrange
+	db := setupProductTestDB(t)
+	repo := repositories.NewPGProductRepository(db)
+	receptionRepo := repositories.NewPGReceptionRepository(db)
+	ctx := context.Background()
+	pvzID, recID := uuid.New(), uuid.New()
+	_, err := db.Exec(`INSERT INTO pvz (id, registration_date, city) VALUES ($1, $2, 'Москва')`, pvzID, time.Now().UTC())
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO reception (id, pvz_id, status, date_time) VALUES ($1, $2, 'in_progress', $3)`, recID, pvzID, time.Now().UTC())
+	require.NoError(t, err)
+	product, err := repo.Save(ctx, entities.Product{ID: uuid.New(), ReceptionID: recID, Type: entities.ProductShoes, DateTime: time.Now().UTC(), Barcode: "4601234567893"})
+	require.NoError(t, err)
+
+	// Act
+	rec, errRec := receptionRepo.GetByIDForUpdate(ctx, recID)
+	got, errGet := repo.GetByID(ctx, product.ID)
+	errDelete := repo.Delete(ctx, product.ID)
+	removedBy := uuid.New()
+	errRemoval := repo.SaveRemoval(ctx, entities.ProductRemoval{ID: uuid.New(), Product: product, RemovedBy: removedBy, RemovedByEmail: "staff@avito.ru", RemovedAt: time.Now().UTC()})
+	gone, errGone := repo.GetByID(ctx, product.ID)
+
+	// Assert
+	require.NoError(t, errRec)
+	require.NotNil(t, rec)
+	assert.Equal(t, entities.ReceptionInProgress, rec.Status)
+	require.NoError(t, errGet)
+	require.NotNil(t, got)
+	assert.Equal(t, product.Barcode, got.Barcode)
+	assert.Equal(t, 1, got.Position)
+	require.NoError(t, errDelete)
+	require.NoError(t, errRemoval)
+	require.NoError(t, errGone)
+	assert.Nil(t, gone)
+	var (
+		loggedBy      uuid.UUID
+		loggedBarcode string
+	)
+	require.NoError(t, db.QueryRow(`SELECT removed_by, barcode FROM product_removal WHERE product_id = $1`, product.ID).Scan(&loggedBy, &loggedBarcode))
+	assert.Equal(t, removedBy, loggedBy)
+	assert.Equal(t, product.Barcode, loggedBarcode)
+}
+
+func TestPGProductRepository_VoidByReception(t *testing.T) {
+	// Arrange: два товара в приёмке
+	db := setupProductTestDB(t)
+	repo := repositories.NewPGProductRepository(db)
+	ctx := context.Background()
+	pvzID, recID := uuid.New(), uuid.New()
+	_, err := db.Exec(`INSERT INTO pvz (id, registration_date, city) VALUES ($1, $2, 'Москва')`, pvzID, time.Now().UTC())
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO reception (id, pvz_id, status, date_time) VALUES ($1, $2, 'in_progress', $3)`, recID, pvzID, time.Now().UTC())
+	require.NoError(t, err)
+	for _, barcode := range []string{"4601234567893", "4600000000008"} {
+		_, err = repo.Save(ctx, entities.Product{ID: uuid.New(), ReceptionID: recID, Type: entities.ProductShoes, DateTime: time.Now().UTC(), Barcode: barcode})
+		require.NoError(t, err)
+	}
+	removedBy := uuid.New()
+
+	// Act
+	voided, err := repo.VoidByReception(ctx, recID, removedBy, "staff@avito.ru", time.Now().UTC())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, voided)
+	var left, logged int
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM product WHERE reception_id = $1`, recID).Scan(&left))
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM product_removal WHERE reception_id = $1 AND removed_by = $2`, recID, removedBy).Scan(&logged))
+	assert.Zero(t, left)
+	assert.Equal(t, 2, logged)
+}
@@ -0,0 +1,72 @@
+package infrastructure_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/infrastructure/repositories"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+)
+
+// Бенчмарки листинга GET /pvz на полной странице: 30 ПВЗ × 5 приёмок × 50 товаров.
+// Запуск: TEST_PG_DSN=... go test ./test/infrastructure/repositories -run ^$ -bench PVZList -benchtime 5s
+// Цель из ТЗ — 1000 RPS при 100 мс на запрос: RunParallel показывает пропускную способность
+// (1e9 / ns/op ≥ 1000), p99 смотреть в нагрузочном тесте
+const (
+	benchPVZCount       = 30
+	benchRecPerPVZ      = 5
+	benchProductsPerRec = 50
+)
+
+func BenchmarkPVZList_Aggregated(b *testing.B) {
+	db := setupPVZTestDB(b)
+	seedPVZGraph(b, db, benchPVZCount, benchRecPerPVZ, benchProductsPerRec)
+	repo := repositories.NewPGPVZRepository(db)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := repo.ListWithReceptions(ctx, nil, nil, 1, benchPVZCount, false); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
+
+// BenchmarkPVZList_NPlusOne — прежняя схема (запрос на каждый ПВЗ и каждую приёмку), для сравнения
+func BenchmarkPVZList_NPlusOne(b *testing.B) {
+	db := setupPVZTestDB(b)
+	seedPVZGraph(b, db, benchPVZCount, benchRecPerPVZ, benchProductsPerRec)
+	pvzRepo := repositories.NewPGPVZRepository(db)
+	receptionRepo := repositories.NewPGReceptionRepository(db)
+	productRepo := repositories.NewPGProductRepository(db)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			pvzs, err := pvzRepo.List(ctx, nil, nil, 1, benchPVZCount, false)
+			if err != nil {
+				b.Error(err)
+				return
+			}
+			for _, pvz := range pvzs {
+				recs, err := receptionRepo.ListByPVZ(ctx, pvz.ID, usecases.ReceptionFilter{})
+				if err != nil {
+					b.Error(err)
+					return
+				}
+				for _, rec := range recs {
+					if _, err := productRepo.ListByReception(ctx, rec.ID); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			}
+		}
+	})
+}
@@ -12,7 +12,9 @@ import (
 	"github.com/joho/godotenv"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/configs"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/infrastructure/migrations"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/infrastructure/repositories"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
 	"github.com/stretchr/testify/require"
 )
 
@@ -27,7 +29,7 @@ func init() {
 	}
 }
 
-func setupPVZTestDB(t *testing.T) *sql.DB {
+func setupPVZTestDB(t testing.TB) *sql.DB {
 	dsn := configs.GetTestPGDSN()
 	if dsn == "" {
 		t.Skip("TEST_PG_DSN not set")
@@ -35,25 +37,13 @@ func setupPVZTestDB(t *testing.T) *sql.DB {
 	db, err := sql.Open("pgx", dsn)
 	require.NoError(t, err)
 	t.Cleanup(func() { db.Close() })
+	// Схема — только из встроенных миграций, как в проде
+	m, err := migrations.NewMigrator(db)
+	require.NoError(t, err)
+	require.NoError(t, m.Up(context.Background()))
 	_, err = db.Exec(`
-CREATE TABLE IF NOT EXISTS pvz (
-    id UUID PRIMARY KEY,
-    registration_date TIMESTAMPTZ NOT NULL,
-    city TEXT NOT NULL
-);
-CREATE TABLE IF NOT EXISTS reception (
-    id UUID PRIMARY KEY,
-    pvz_id UUID NOT NULL REFERENCES pvz(id),
-    status TEXT NOT NULL,
-    date_time TIMESTAMPTZ NOT NULL
-);
-CREATE TABLE IF NOT EXISTS product (
-    id UUID PRIMARY KEY,
-    reception_id UUID NOT NULL REFERENCES reception(id),
-    type TEXT NOT NULL,
-    date_time TIMESTAMPTZ NOT NULL
-);
--- Очищаем таблицы в правильном порядке с учетом внешних ключей
+DELETE FROM product_removal;
+DELETE FROM reception_transition;
 DELETE FROM product;
 DELETE FROM reception;
 DELETE FROM pvz;
@@ -85,19 +75,166 @@ func TestPGPVZRepository_SaveAndList(t *testing.T) {
 	require.NoError(t, err)
 
 	// Act: list all
-	res, err := repo.List(ctx, nil, nil, 1, 10)
+	res, err := repo.List(ctx, nil, nil, 1, 10, false)
 	require.NoError(t, err)
 	require.Len(t, res, 2)
 
 	// Act: filter by date
 	start := time.Now().Add(-2 * time.Hour)
-	res, err = repo.List(ctx, &start, nil, 1, 10)
+	res, err = repo.List(ctx, &start, nil, 1, 10, false)
 	require.NoError(t, err)
 	require.Len(t, res, 1)
 	require.Equal(t, pvz2.ID, res[0].ID)
 
 	// Act: pagination
-	res, err = repo.List(ctx, nil, nil, 2, 1)
+	res, err = repo.List(ctx, nil, nil, 2, 1, false)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+}
+
+func TestPGPVZRepository_GetUpdateArchive(t *testing.T) {
+	db := setupPVZTestDB(t)
+	repo := repositories.NewPGPVZRepository(db)
+	ctx := context.Background()
+	pvz := entities.PVZ{ID: uuid.New(), RegistrationDate: time.Now().UTC(), City: "Москва"}
+	_, err := repo.Save(ctx, pvz)
+	require.NoError(t, err)
+
+	// Act: неизвестный id
+	missing, err := repo.GetByID(ctx, uuid.New())
+	require.NoError(t, err)
+	require.Nil(t, missing)
+
+	// Act: смена города и архивация
+	pvz.City = "Казань"
+	pvz.Archive(time.Now().UTC())
+	updated, err := repo.Update(ctx, pvz)
+	require.NoError(t, err)
+	require.Equal(t, entities.City("Казань"), updated.City)
+	require.True(t, updated.IsArchived())
+
+	got, err := repo.GetByID(ctx, pvz.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	require.True(t, got.IsArchived())
+
+	// Assert: архивный ПВЗ виден в листинге только по запросу
+	res, err := repo.List(ctx, nil, nil, 1, 10, false)
+	require.NoError(t, err)
+	require.Empty(t, res)
+	withRecs, err := repo.ListWithReceptions(ctx, nil, nil, 1, 10, true)
+	require.NoError(t, err)
+	require.Len(t, withRecs, 1)
+}
+
+func TestPGPVZRepository_Nearest(t *testing.T) {
+	db := setupPVZTestDB(t)
+	repo := repositories.NewPGPVZRepository(db)
+	ctx := context.Background()
+	save := func(name string, location *entities.GeoPoint, archived bool) {
+		pvz := entities.PVZ{ID: uuid.New(), RegistrationDate: time.Now().UTC(), City: "Москва",
+			PVZDetails: entities.PVZDetails{Name: name, Address: "адрес " + name, Location: location}}
+		if archived {
+			pvz.Archive(time.Now().UTC())
+		}
+		_, err := repo.Save(ctx, pvz)
+		require.NoError(t, err)
+		if archived {
+			_, err = repo.Update(ctx, pvz)
+			require.NoError(t, err)
+		}
+	}
+	save("Тверская", &entities.GeoPoint{Lat: 55.7650, Lon: 37.6050}, false)
+	save("Красная площадь", &entities.GeoPoint{Lat: 55.7539, Lon: 37.6208}, false)
+	save("Архивный", &entities.GeoPoint{Lat: 55.7558, Lon: 37.6173}, true)
+	save("Казань", &entities.GeoPoint{Lat: 55.7986, Lon: 49.1064}, false)
+	save("Без координат", nil, false)
+	center := entities.GeoPoint{Lat: 55.7558, Lon: 37.6173}
+
+	// Act
+	res, err := repo.Nearest(ctx, center, 3000, 10)
+
+	// Assert: расстояние из SQL совпадает с расчётом в Go
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	require.Equal(t, "Красная площадь", res[0].PVZ.Name)
+	require.Equal(t, "адрес Красная площадь", res[0].PVZ.Address)
+	require.InDelta(t, center.DistanceTo(*res[0].PVZ.Location), res[0].Distance, 0.01)
+	require.Equal(t, "Тверская", res[1].PVZ.Name)
+
+	res, err = repo.Nearest(ctx, center, 3000, 1)
 	require.NoError(t, err)
 	require.Len(t, res, 1)
+
+	res, err = repo.Nearest(ctx, entities.GeoPoint{Lat: 0, Lon: 0}, 3000, 10)
+	require.NoError(t, err)
+	require.Empty(t, res)
+}
+
+// seedPVZGraph создаёт pvzCount ПВЗ, у каждого recPerPVZ приёмок по productsPerRec товаров
+func seedPVZGraph(t testing.TB, db *sql.DB, pvzCount, recPerPVZ, productsPerRec int) []uuid.UUID {
+	base := time.Now().Add(-time.Hour).UTC()
+	pvzIDs := make([]uuid.UUID, 0, pvzCount)
+	for i := 0; i < pvzCount; i++ {
+		pvzID := uuid.New()
+		_, err := db.Exec(`INSERT INTO pvz (id, registration_date, city) VALUES ($1, $2, $3)`, pvzID, base.Add(time.Duration(i)*time.Second), "Москва")
+		require.NoError(t, err)
+		pvzIDs = append(pvzIDs, pvzID)
+		for j := 0; j < recPerPVZ; j++ {
+			recID := uuid.New()
+			_, err := db.Exec(`INSERT INTO reception (id, pvz_id, status, date_time) VALUES ($1, $2, $3, $4)`, recID, pvzID, "close", base.Add(time.Duration(j)*time.Minute))
+			require.NoError(t, err)
+			for k := 0; k < productsPerRec; k++ {
+				_, err := db.Exec(`INSERT INTO product (id, reception_id, type, date_time, position) VALUES ($1, $2, $3, $4, $5)`, uuid.New(), recID, "обувь", base.Add(time.Duration(j)*time.Minute+time.Duration(k)*time.Millisecond), k+1)
+				require.NoError(t, err)
+			}
+		}
+	}
+	return pvzIDs
+}
+
+func TestPGPVZRepository_ListWithReceptions(t *testing.T) {
+	// Arrange
+	db := setupPVZTestDB(t)
+	repo := repositories.NewPGPVZRepository(db)
+	ctx := context.Background()
+	pvzIDs := seedPVZGraph(t, db, 3, 2, 3)
+	empty := entities.PVZ{ID: uuid.New(), RegistrationDate: time.Now().UTC(), City: "Казань"}
+	_, err := repo.Save(ctx, empty)
+	require.NoError(t, err)
+
+	// Act
+	res, err := repo.ListWithReceptions(ctx, nil, nil, 1, 10, false)
+
+	// Assert: граф совпадает с построчной выборкой через ListByPVZ/ListByReception
+	require.NoError(t, err)
+	require.Len(t, res, 4)
+	receptionRepo := repositories.NewPGReceptionRepository(db)
+	productRepo := repositories.NewPGProductRepository(db)
+	for i, pvzID := range pvzIDs {
+		require.Equal(t, pvzID, res[i].PVZ.ID)
+		require.Len(t, res[i].Receptions, 2)
+		recs, err := receptionRepo.ListByPVZ(ctx, pvzID, usecases.ReceptionFilter{})
+		require.NoError(t, err)
+		require.Len(t, recs, 2)
+		for _, rec := range res[i].Receptions {
+			require.Equal(t, pvzID, rec.Reception.PVZID)
+			products, err := productRepo.ListByReception(ctx, rec.Reception.ID)
+			require.NoError(t, err)
+			require.Equal(t, products, rec.Products)
+		}
+	}
+	require.Equal(t, empty.ID, res[3].PVZ.ID)
+	require.Empty(t, res[3].Receptions)
+
+	// Act: пагинация по (registration_date, id) — страницы не пересекаются
+	page2, err := repo.ListWithReceptions(ctx, nil, nil, 2, 2, false)
+	require.NoError(t, err)
+	require.Len(t, page2, 2)
+	require.Equal(t, pvzIDs[2], page2[0].PVZ.ID)
+
+	// Act: пустая страница
+	none, err := repo.ListWithReceptions(ctx, nil, nil, 10, 10, false)
+	require.NoError(t, err)
+	require.Empty(t, none)
 }
@@ -12,7 +12,10 @@ import (
 	"github.com/joho/godotenv"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/configs"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/infrastructure/migrations"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/infrastructure/repositories"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -35,25 +38,13 @@ func setupReceptionTestDB(t *testing.T) *sql.DB {
 	db, err := sql.Open("pgx", dsn)
 	require.NoError(t, err)
 	t.Cleanup(func() { db.Close() })
+	// Схема — только из встроенных миграций, как в проде
+	m, err := migrations.NewMigrator(db)
+	require.NoError(t, err)
+	require.NoError(t, m.Up(context.Background()))
 	_, err = db.Exec(`
-CREATE TABLE IF NOT EXISTS pvz (
-    id UUID PRIMARY KEY,
-    registration_date TIMESTAMPTZ NOT NULL,
-    city TEXT NOT NULL
-);
-CREATE TABLE IF NOT EXISTS reception (
-    id UUID PRIMARY KEY,
-    pvz_id UUID NOT NULL REFERENCES pvz(id),
-    status TEXT NOT NULL,
-    date_time TIMESTAMPTZ NOT NULL
-);
-CREATE TABLE IF NOT EXISTS product (
-    id UUID PRIMARY KEY,
-    reception_id UUID NOT NULL REFERENCES reception(id),
-    type TEXT NOT NULL,
-    date_time TIMESTAMPTZ NOT NULL
-);
--- Очищаем таблицы в правильном порядке с учетом внешних ключей
+DELETE FROM product_removal;
+DELETE FROM reception_transition;
 DELETE FROM product;
 DELETE FROM reception;
 DELETE FROM pvz;
@@ -62,20 +53,25 @@ DELETE FROM pvz;
 	return db
 }
 
-func TestPGReceptionRepository_Save_GetActive_CloseLast(t *testing.T) {
-	// Arrange
+func TestPGReceptionRepository_Save_GetActive_Close(t *testing.T) {
+	// Arrange: открытая приёмка и назначенный на ПВЗ сотрудник
 	db := setupReceptionTestDB(t)
 	repo := repositories.NewPGReceptionRepository(db)
 	ctx := context.Background()
 	pvzID := uuid.New()
 	_, err := db.Exec(`INSERT INTO pvz (id, registration_date, city) VALUES ($1, $2, $3)`, pvzID, time.Now().UTC(), "Москва")
 	require.NoError(t, err)
+	staff, err := repositories.NewPGUserRepository(db).Create(ctx, entities.User{ID: uuid.New(), Email: uuid.NewString() + "@avito.ru", Role: entities.UserRolePVZStaff, RegistrationDate: time.Now().UTC()}, "hash")
+	require.NoError(t, err)
+	staffRepo := repositories.NewPGStaffAssignmentRepository(db)
+	require.NoError(t, staffRepo.Assign(ctx, entities.StaffAssignment{PVZID: pvzID, UserID: staff.ID, AssignedAt: time.Now().UTC(), AssignedBy: uuid.New()}))
+	uc := usecases.NewCloseReceptionUseCase(repo, repositories.NewPGTxManager(db), usecases.NewAuthorizer(entities.DefaultRolePermissions(), staffRepo))
 
 	rec := entities.Reception{
 		ID:       uuid.New(),
 		PVZID:    pvzID,
 		Status:   entities.ReceptionInProgress,
-		DateTime: time.Now().UTC(),
+		DateTime: time.Now().Add(-time.Hour).UTC(),
 	}
 
 	// Act: save
@@ -90,15 +86,32 @@ func TestPGReceptionRepository_Save_GetActive_CloseLast(t *testing.T) {
 	require.Equal(t, rec.ID, got.ID)
 	require.Equal(t, entities.ReceptionInProgress, got.Status)
 
-	// Act: close last
-	closedAt := time.Now().Add(1 * time.Hour).UTC()
-	err = repo.CloseLast(ctx, pvzID, closedAt)
+	// Act: закрытие тем же путём, что и в проде (GetActiveForUpdate + Save + SaveTransition)
+	closed, err := uc.Execute(ctx, staff, pvzID)
 	require.NoError(t, err)
 
-	// Assert: get active returns nil
+	// Assert: активной приёмки нет, время создания не тронуто, закрытие — в closed_at и истории
 	got, err = repo.GetActive(ctx, pvzID)
 	require.NoError(t, err)
 	require.Nil(t, got)
+	stored, err := repo.GetByID(ctx, rec.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, entities.ReceptionClosed, stored.Status)
+	assert.WithinDuration(t, rec.DateTime, stored.DateTime, time.Millisecond)
+	require.NotNil(t, stored.ClosedAt)
+	assert.WithinDuration(t, *closed.ClosedAt, *stored.ClosedAt, time.Millisecond)
+	transitions, err := repo.ListTransitions(ctx, rec.ID)
+	require.NoError(t, err)
+	require.Len(t, transitions, 1)
+	assert.Equal(t, entities.ReceptionClosed, transitions[0].To)
+	assert.Equal(t, staff.ID, transitions[0].ChangedBy)
+
+	// Act: повторное закрытие
+	_, err = uc.Execute(ctx, staff, pvzID)
+
+	// Assert
+	require.ErrorIs(t, err, usecases.ErrNoReceptionToClose)
 }
 
 func TestPGReceptionRepository_ListByPVZ(t *testing.T) {
@@ -127,13 +140,223 @@ func TestPGReceptionRepository_ListByPVZ(t *testing.T) {
 	_, err = repo.Save(ctx, rec2)
 	require.NoError(t, err)
 
+	t.Run("без фильтров — все приёмки, новые первыми", func(t *testing.T) {
+		// Act
+		list, err := repo.ListByPVZ(ctx, pvzID, usecases.ReceptionFilter{})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+		require.Equal(t, rec2.ID, list[0].ID)
+		require.Equal(t, rec1.ID, list[1].ID)
+	})
+
+	t.Run("фильтр по статусу", func(t *testing.T) {
+		// Arrange
+		status := entities.ReceptionInProgress
+
+		// Act
+		list, err := repo.ListByPVZ(ctx, pvzID, usecases.ReceptionFilter{Status: &status})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		require.Equal(t, rec1.ID, list[0].ID)
+	})
+
+	t.Run("фильтр по дате", func(t *testing.T) {
+		// Arrange
+		start := time.Now().Add(-90 * time.Minute).UTC()
+
+		// Act
+		list, err := repo.ListByPVZ(ctx, pvzID, usecases.ReceptionFilter{StartDate: &start})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		require.Equal(t, rec2.ID, list[0].ID)
+	})
+
+	t.Run("пагинация", func(t *testing.T) {
+		// Act
+		page2, err := repo.ListByPVZ(ctx, pvzID, usecases.ReceptionFilter{Page: 2, Limit: 1})
+		require.NoError(t, err)
+		page3, err := repo.ListByPVZ(ctx, pvzID, usecases.ReceptionFilter{Page: 3, Limit: 1})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, page2, 1)
+		require.Equal(t, rec1.ID, page2[0].ID)
+		require.NotNil(t, page3)
+		require.Empty(t, page3)
+	})
+}
+
+func TestPGReceptionRepository_GetByID(t *testing.T) {
+	// Arrange
+	db := setupReceptionTestDB(t)
+	repo := repositories.NewPGReceptionRepository(db)
+	ctx := context.Background()
+	pvzID := uuid.New()
+	_, err := db.Exec(`INSERT INTO pvz (id, registration_date, city) VALUES ($1, $2, $3)`, pvzID, time.Now().UTC(), "Москва")
+	require.NoError(t, err)
+	rec, err := repo.Save(ctx, entities.Reception{ID: uuid.New(), PVZID: pvzID, Status: entities.ReceptionInProgress, DateTime: time.Now().UTC()})
+	require.NoError(t, err)
+
+	// Act
+	got, err := repo.GetByID(ctx, rec.ID)
+	require.NoError(t, err)
+	missing, err := repo.GetByID(ctx, uuid.New())
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	require.Equal(t, rec.ID, got.ID)
+	require.Equal(t, pvzID, got.PVZID)
+	require.Nil(t, missing)
+}
+
+// TestPGReceptionRepository_ProductsOrder — Reception.Products заполняется в порядке позиции
+func TestPGReceptionRepository_ProductsOrder(t *testing.T) {
+	// Arrange
+	db := setupReceptionTestDB(t)
+	repo := repositories.NewPGReceptionRepository(db)
+	productRepo := repositories.NewPGProductRepository(db)
+	ctx := context.Background()
+	pvzID := uuid.New()
+	_, err := db.Exec(`INSERT INTO pvz (id, registration_date, city) VALUES ($1, $2, $3)`, pvzID, time.Now().UTC(), "Москва")
+	require.NoError(t, err)
+	rec, err := repo.Save(ctx, entities.Reception{ID: uuid.New(), PVZID: pvzID, Status: entities.ReceptionInProgress, DateTime: time.Now().UTC()})
+	require.NoError(t, err)
+	var want []uuid.UUID
+	for i := 0; i < 3; i++ {
+		// время убывает, порядок всё равно определяется позицией
+		p, err := productRepo.Save(ctx, entities.Product{ID: uuid.New(), ReceptionID: rec.ID, Type: entities.ProductShoes, DateTime: time.Now().Add(-time.Duration(i) * time.Minute).UTC()})
+		require.NoError(t, err)
+		want = append(want, p.ID)
+	}
+
 	// Act
-	list, err := repo.ListByPVZ(ctx, pvzID)
+	active, err := repo.GetActive(ctx, pvzID)
+	require.NoError(t, err)
+	list, err := repo.ListByPVZ(ctx, pvzID, usecases.ReceptionFilter{})
+	require.NoError(t, err)
 
 	// Assert
+	require.NotNil(t, active)
+	require.Equal(t, want, active.Products)
+	require.Len(t, list, 1)
+	require.Equal(t, want, list[0].Products)
+}
+
+func TestPGReceptionRepository_ReopenAndTransitions(t *testing.T) {
+	// Arrange: закрытая приёмка и история её закрытия
+	db := setupReceptionTestDB(t)
+	repo := repositories.NewPGReceptionRepository(db)
+	ctx := context.Background()
+	pvzID := uuid.New()
+	_, err := db.Exec(`INSERT INTO pvz (id, registration_date, city) VALUES ($1, $2, $3)`, pvzID, time.Now().UTC(), "Москва")
+	require.NoError(t, err)
+	rec := entities.Reception{ID: uuid.New(), PVZID: pvzID, Status: entities.ReceptionInProgress, DateTime: time.Now().UTC()}
+	require.NoError(t, rec.Close())
+	_, err = repo.Save(ctx, rec)
 	require.NoError(t, err)
-	require.Len(t, list, 2)
-	ids := []uuid.UUID{list[0].ID, list[1].ID}
-	require.Contains(t, ids, rec1.ID)
-	require.Contains(t, ids, rec2.ID)
+	staffID, moderatorID := uuid.New(), uuid.New()
+	require.NoError(t, repo.SaveTransition(ctx, entities.ReceptionTransition{ID: uuid.New(), ReceptionID: rec.ID, From: entities.ReceptionInProgress, To: entities.ReceptionClosed, ChangedBy: staffID, ChangedAt: *rec.ClosedAt}))
+
+	t.Run("время закрытия сохраняется", func(t *testing.T) {
+		// Act
+		got, err := repo.GetByIDForUpdate(ctx, rec.ID)
+
+		// Assert
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		require.NotNil(t, got.ClosedAt)
+		assert.WithinDuration(t, *rec.ClosedAt, *got.ClosedAt, time.Millisecond)
+	})
+
+	t.Run("вторая открытая приёмка на ПВЗ — конфликт", func(t *testing.T) {
+		// Arrange
+		_, err := repo.Save(ctx, entities.Reception{ID: uuid.New(), PVZID: pvzID, Status: entities.ReceptionInProgress, DateTime: time.Now().UTC()})
+		require.NoError(t, err)
+		require.NoError(t, rec.Reopen())
+
+		// Act
+		_, err = repo.Save(ctx, rec)
+
+		// Assert
+		require.ErrorIs(t, err, usecases.ErrReceptionAlreadyOpen)
+	})
+
+	t.Run("история статусов по порядку", func(t *testing.T) {
+		// Arrange
+		require.NoError(t, repo.SaveTransition(ctx, entities.ReceptionTransition{ID: uuid.New(), ReceptionID: rec.ID, From: entities.ReceptionClosed, To: entities.ReceptionInProgress, ChangedBy: moderatorID, Reason: "машина ещё разгружается", ChangedAt: time.Now().UTC()}))
+
+		// Act
+		history, err := repo.ListTransitions(ctx, rec.ID)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		assert.Equal(t, entities.ReceptionClosed, history[0].To)
+		assert.Equal(t, staffID, history[0].ChangedBy)
+		assert.Empty(t, history[0].Reason)
+		assert.Equal(t, entities.ReceptionInProgress, history[1].To)
+		assert.Equal(t, moderatorID, history[1].ChangedBy)
+		assert.Equal(t, "машина ещё разгружается", history[1].Reason)
+	})
+}
+
+func TestPGReceptionRepository_PausedAndCancelled(t *testing.T) {
+	// Arrange: приёмка на паузе
+	db := setupReceptionTestDB(t)
+	repo := repositories.NewPGReceptionRepository(db)
+	ctx := context.Background()
+	pvzID := uuid.New()
+	_, err := db.Exec(`INSERT INTO pvz (id, registration_date, city) VALUES ($1, $2, $3)`, pvzID, time.Now().UTC(), "Москва")
+	require.NoError(t, err)
+	rec := entities.Reception{ID: uuid.New(), PVZID: pvzID, Status: entities.ReceptionInProgress, DateTime: time.Now().UTC()}
+	_, err = repo.Save(ctx, rec)
+	require.NoError(t, err)
+	require.NoError(t, rec.Pause())
+	_, err = repo.Save(ctx, rec)
+	require.NoError(t, err)
+
+	t.Run("приёмка на паузе занимает ПВЗ", func(t *testing.T) {
+		// Act
+		active, errActive := repo.GetActive(ctx, pvzID)
+		_, errSecond := repo.Save(ctx, entities.Reception{ID: uuid.New(), PVZID: pvzID, Status: entities.ReceptionInProgress, DateTime: time.Now().UTC()})
+
+		// Assert
+		require.NoError(t, errActive)
+		require.NotNil(t, active)
+		assert.Equal(t, entities.ReceptionPaused, active.Status)
+		require.ErrorIs(t, errSecond, usecases.ErrReceptionAlreadyOpen)
+	})
+
+	t.Run("БД отклоняет недопустимые статусы и переходы", func(t *testing.T) {
+		// Act
+		_, errUnknown := db.Exec(`UPDATE reception SET status = 'unknown' WHERE id = $1`, rec.ID)
+		_, errHistory := db.Exec(`INSERT INTO reception_transition (id, reception_id, from_status, to_status, changed_by, changed_at) VALUES ($1, $2, 'cancelled', 'in_progress', $3, now())`, uuid.New(), rec.ID, uuid.New())
+
+		// Assert
+		require.Error(t, errUnknown)
+		require.Error(t, errHistory)
+	})
+
+	t.Run("отменённая приёмка освобождает ПВЗ и не меняет статус", func(t *testing.T) {
+		// Arrange
+		require.NoError(t, rec.Cancel())
+		_, err := repo.Save(ctx, rec)
+		require.NoError(t, err)
+
+		// Act
+		active, errActive := repo.GetActive(ctx, pvzID)
+		_, errResume := db.Exec(`UPDATE reception SET status = 'in_progress' WHERE id = $1`, rec.ID)
+
+		// Assert
+		require.NoError(t, errActive)
+		assert.Nil(t, active)
+		require.Error(t, errResume)
+	})
 }
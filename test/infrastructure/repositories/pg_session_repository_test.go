@@ -0,0 +1,127 @@
+package infrastructure_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/configs"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/infrastructure/migrations"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/infrastructure/repositories"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupSessionTestDB(t *testing.T) *sql.DB {
+	dsn := configs.GetTestPGDSN()
+	if dsn == "" {
+		t.Skip("TEST_PG_DSN not set")
+	}
+	db, err := sql.Open("pgx", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	// Схема — только из встроенных миграций, как в проде
+	m, err := migrations.NewMigrator(db)
+	require.NoError(t, err)
+	require.NoError(t, m.Up(context.Background()))
+	_, err = db.Exec(`
+DELETE FROM refresh_token;
+DELETE FROM auth_session;
+`)
+	require.NoError(t, err)
+	return db
+}
+
+func TestPGSessionRepository_RefreshAndRevoke(t *testing.T) {
+	// Arrange: сессия с refresh-токеном
+	db := setupSessionTestDB(t)
+	repo := repositories.NewPGSessionRepository(db)
+	ctx := context.Background()
+	session := entities.Session{ID: uuid.New(), UserID: uuid.New(), Role: entities.UserRolePVZStaff, Email: "staff@avito.ru", CreatedAt: time.Now().UTC()}
+	require.NoError(t, repo.CreateSession(ctx, session))
+	hash := usecases.HashRefreshToken("refresh")
+	require.NoError(t, repo.SaveRefreshToken(ctx, entities.RefreshToken{Hash: hash, SessionID: session.ID, IssuedAt: time.Now().UTC(), ExpiresAt: time.Now().Add(time.Hour).UTC()}))
+
+	t.Run("токен помечается использованным в транзакции", func(t *testing.T) {
+		// Act
+		err := repositories.NewPGTxManager(db).WithinTx(ctx, func(ctx context.Context) error {
+			token, err := repo.GetRefreshTokenForUpdate(ctx, hash)
+			if err != nil {
+				return err
+			}
+			require.NotNil(t, token)
+			assert.Nil(t, token.UsedAt)
+			assert.Equal(t, session.ID, token.SessionID)
+			return repo.MarkRefreshTokenUsed(ctx, hash, time.Now().UTC())
+		})
+		used, errUsed := repo.GetRefreshTokenForUpdate(ctx, hash)
+		missing, errMissing := repo.GetRefreshTokenForUpdate(ctx, usecases.HashRefreshToken("unknown"))
+
+		// Assert
+		require.NoError(t, err)
+		require.NoError(t, errUsed)
+		require.NotNil(t, used)
+		assert.NotNil(t, used.UsedAt)
+		require.NoError(t, errMissing)
+		assert.Nil(t, missing)
+	})
+
+	t.Run("отзыв сессии", func(t *testing.T) {
+		// Arrange
+		activeBefore, err := repo.IsSessionActive(ctx, session.ID)
+		require.NoError(t, err)
+		revokedAt := time.Now().UTC()
+
+		// Act
+		errRevoke := repo.RevokeSession(ctx, session.ID, revokedAt)
+		errAgain := repo.RevokeSession(ctx, session.ID, revokedAt.Add(time.Hour))
+		activeAfter, errActive := repo.IsSessionActive(ctx, session.ID)
+		unknown, errUnknown := repo.IsSessionActive(ctx, uuid.New())
+		got, errGet := repo.GetSession(ctx, session.ID)
+
+		// Assert
+		assert.True(t, activeBefore)
+		require.NoError(t, errRevoke)
+		require.NoError(t, errAgain)
+		require.NoError(t, errActive)
+		assert.False(t, activeAfter)
+		require.NoError(t, errUnknown)
+		assert.False(t, unknown)
+		require.NoError(t, errGet)
+		require.NotNil(t, got)
+		assert.Equal(t, session.Role, got.Role)
+		require.NotNil(t, got.RevokedAt)
+		assert.WithinDuration(t, revokedAt, *got.RevokedAt, time.Millisecond)
+	})
+
+	t.Run("отзыв всех сессий пользователя", func(t *testing.T) {
+		// Arrange: две активные сессии пользователя и одна чужая
+		userID := uuid.New()
+		first := entities.Session{ID: uuid.New(), UserID: userID, Role: entities.UserRolePVZStaff, CreatedAt: time.Now().UTC()}
+		second := entities.Session{ID: uuid.New(), UserID: userID, Role: entities.UserRolePVZStaff, CreatedAt: time.Now().UTC()}
+		foreign := entities.Session{ID: uuid.New(), UserID: uuid.New(), Role: entities.UserRolePVZStaff, CreatedAt: time.Now().UTC()}
+		for _, s := range []entities.Session{first, second, foreign} {
+			require.NoError(t, repo.CreateSession(ctx, s))
+		}
+
+		// Act
+		revoked, err := repo.RevokeUserSessions(ctx, userID, time.Now().UTC())
+		again, errAgain := repo.RevokeUserSessions(ctx, userID, time.Now().UTC())
+		firstActive, _ := repo.IsSessionActive(ctx, first.ID)
+		secondActive, _ := repo.IsSessionActive(ctx, second.ID)
+		foreignActive, _ := repo.IsSessionActive(ctx, foreign.ID)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), revoked)
+		require.NoError(t, errAgain)
+		assert.Equal(t, int64(0), again)
+		assert.False(t, firstActive)
+		assert.False(t, secondActive)
+		assert.True(t, foreignActive)
+	})
+}
@@ -0,0 +1,74 @@
+package infrastructure_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/infrastructure/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPGStaffAssignmentRepository(t *testing.T) {
+	// Arrange: два ПВЗ, сотрудник назначен на первый
+	db := setupProductTestDB(t)
+	repo := repositories.NewPGStaffAssignmentRepository(db)
+	ctx := context.Background()
+	pvzID, otherPVZID := uuid.New(), uuid.New()
+	for _, id := range []uuid.UUID{pvzID, otherPVZID} {
+		_, err := db.Exec(`INSERT INTO pvz (id, registration_date, city) VALUES ($1, $2, $3)`, id, time.Now().UTC(), "Москва")
+		require.NoError(t, err)
+	}
+	staff, err := repositories.NewPGUserRepository(db).Create(ctx, entities.User{ID: uuid.New(), Email: uuid.NewString() + "@avito.ru", Role: entities.UserRolePVZStaff, RegistrationDate: time.Now().UTC()}, "hash")
+	require.NoError(t, err)
+	staffID, moderatorID := staff.ID, uuid.New()
+	first := entities.StaffAssignment{PVZID: pvzID, UserID: staffID, AssignedAt: time.Now().UTC().Truncate(time.Microsecond), AssignedBy: moderatorID}
+	require.NoError(t, repo.Assign(ctx, first))
+
+	t.Run("повторное назначение ничего не меняет", func(t *testing.T) {
+		// Act
+		err := repo.Assign(ctx, entities.StaffAssignment{PVZID: pvzID, UserID: staffID, AssignedAt: time.Now().Add(time.Hour).UTC(), AssignedBy: uuid.New()})
+		list, errList := repo.ListByPVZ(ctx, pvzID)
+
+		// Assert
+		require.NoError(t, err)
+		require.NoError(t, errList)
+		require.Len(t, list, 1)
+		assert.Equal(t, moderatorID, list[0].AssignedBy)
+		assert.True(t, first.AssignedAt.Equal(list[0].AssignedAt))
+	})
+
+	t.Run("проверка назначения", func(t *testing.T) {
+		// Act
+		assigned, err1 := repo.IsAssigned(ctx, staffID, pvzID)
+		other, err2 := repo.IsAssigned(ctx, staffID, otherPVZID)
+		empty, err3 := repo.ListByPVZ(ctx, otherPVZID)
+
+		// Assert
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		require.NoError(t, err3)
+		assert.True(t, assigned)
+		assert.False(t, other)
+		assert.NotNil(t, empty)
+		assert.Empty(t, empty)
+	})
+
+	t.Run("снятие", func(t *testing.T) {
+		// Act
+		removed, err1 := repo.Unassign(ctx, pvzID, staffID)
+		again, err2 := repo.Unassign(ctx, pvzID, staffID)
+		assigned, err3 := repo.IsAssigned(ctx, staffID, pvzID)
+
+		// Assert
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		require.NoError(t, err3)
+		assert.True(t, removed)
+		assert.False(t, again)
+		assert.False(t, assigned)
+	})
+}
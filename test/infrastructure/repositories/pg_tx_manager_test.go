@@ -0,0 +1,100 @@
+package infrastructure_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/infrastructure/repositories"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPGTxManager_CommitAndRollback(t *testing.T) {
+	// Arrange
+	db := setupProductTestDB(t)
+	tx := repositories.NewPGTxManager(db)
+	pvzRepo := repositories.NewPGPVZRepository(db)
+	ctx := context.Background()
+
+	// Act: ошибка внутри транзакции — запись откатывается
+	rolledBack := entities.PVZ{ID: uuid.New(), RegistrationDate: time.Now().UTC(), City: entities.CityMoscow}
+	err := tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := pvzRepo.Save(ctx, rolledBack); err != nil {
+			return err
+		}
+		return assert.AnError
+	})
+	require.ErrorIs(t, err, assert.AnError)
+
+	// Act: успешная транзакция — запись фиксируется
+	committed := entities.PVZ{ID: uuid.New(), RegistrationDate: time.Now().UTC(), City: entities.CityMoscow}
+	err = tx.WithinTx(ctx, func(ctx context.Context) error {
+		_, err := pvzRepo.Save(ctx, committed)
+		return err
+	})
+	require.NoError(t, err)
+
+	// Assert
+	var n int
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM pvz WHERE id = $1`, rolledBack.ID).Scan(&n))
+	assert.Equal(t, 0, n)
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM pvz WHERE id = $1`, committed.ID).Scan(&n))
+	assert.Equal(t, 1, n)
+}
+
+// TestDeleteLastProduct_Concurrent — параллельные удаления не удаляют один и тот же товар дважды
+func TestDeleteLastProduct_Concurrent(t *testing.T) {
+	// Arrange
+	db := setupProductTestDB(t)
+	ctx := context.Background()
+	pvzID := uuid.New()
+	_, err := db.Exec(`INSERT INTO pvz (id, registration_date, city) VALUES ($1, $2, $3)`, pvzID, time.Now().UTC(), "Москва")
+	require.NoError(t, err)
+	recID := uuid.New()
+	_, err = db.Exec(`INSERT INTO reception (id, pvz_id, status, date_time) VALUES ($1, $2, $3, $4)`, recID, pvzID, "in_progress", time.Now().UTC())
+	require.NoError(t, err)
+
+	const n = 10
+	productRepo := repositories.NewPGProductRepository(db)
+	for i := 0; i < n; i++ {
+		_, err := productRepo.Save(ctx, entities.Product{
+			ID:          uuid.New(),
+			ReceptionID: recID,
+			Type:        entities.ProductShoes,
+			DateTime:    time.Now().Add(time.Duration(i) * time.Millisecond).UTC(),
+		})
+		require.NoError(t, err)
+	}
+	staff, err := repositories.NewPGUserRepository(db).Create(ctx, entities.User{ID: uuid.New(), Email: uuid.NewString() + "@avito.ru", Role: entities.UserRolePVZStaff, RegistrationDate: time.Now().UTC()}, "hash")
+	require.NoError(t, err)
+	staffRepo := repositories.NewPGStaffAssignmentRepository(db)
+	require.NoError(t, staffRepo.Assign(ctx, entities.StaffAssignment{PVZID: pvzID, UserID: staff.ID, AssignedAt: time.Now().UTC(), AssignedBy: uuid.New()}))
+	authz := usecases.NewAuthorizer(entities.DefaultRolePermissions(), staffRepo)
+	uc := usecases.NewDeleteLastProductUseCase(productRepo, repositories.NewPGReceptionRepository(db), repositories.NewPGTxManager(db), authz)
+
+	// Act
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- uc.Execute(ctx, staff, pvzID)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	// Assert
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+	var left int
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM product WHERE reception_id = $1`, recID).Scan(&left))
+	assert.Equal(t, 0, left)
+}
@@ -12,7 +12,9 @@ import (
 	"github.com/joho/godotenv"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/configs"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/infrastructure/migrations"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/infrastructure/repositories"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -36,14 +38,11 @@ func setupTestDB(t *testing.T) *sql.DB {
 	db, err := sql.Open("pgx", dsn)
 	require.NoError(t, err)
 	t.Cleanup(func() { db.Close() })
+	// Схема — только из встроенных миграций, как в проде
+	m, err := migrations.NewMigrator(db)
+	require.NoError(t, err)
+	require.NoError(t, m.Up(context.Background()))
 	_, err = db.Exec(`
-CREATE TABLE IF NOT EXISTS users (
-    id UUID PRIMARY KEY,
-    email TEXT NOT NULL UNIQUE,
-    role TEXT NOT NULL,
-    registration_date TIMESTAMPTZ NOT NULL,
-    password_hash TEXT NOT NULL
-);
 DELETE FROM users;
 `)
 	require.NoError(t, err)
@@ -90,3 +89,35 @@ func TestPGUserRepository_CreateAndGetByEmail(t *testing.T) {
 	require.Nil(t, none)
 	require.Empty(t, noneHash)
 }
+
+func TestPGUserRepository_EnsureUser(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	repo := repositories.NewPGUserRepository(db)
+	ctx := context.Background()
+	user := entities.User{
+		ID:               uuid.New(),
+		Email:            "dummy-pvz_staff@avito.ru",
+		Role:             entities.UserRolePVZStaff,
+		RegistrationDate: time.Now().UTC(),
+	}
+
+	// Act
+	err := repo.EnsureUser(ctx, user)
+	errAgain := repo.EnsureUser(ctx, user)
+	got, errGet := repo.GetByID(ctx, user.ID)
+	none, errNone := repo.GetByID(ctx, uuid.New())
+	clash := user
+	clash.ID = uuid.New()
+	errClash := repo.EnsureUser(ctx, clash)
+
+	// Assert: повтор по id — без изменений, чужой id с тем же email — ошибка, а не тихий пропуск
+	require.NoError(t, err)
+	require.NoError(t, errAgain)
+	require.NoError(t, errGet)
+	require.NotNil(t, got)
+	require.Equal(t, user.Role, got.Role)
+	require.NoError(t, errNone)
+	require.Nil(t, none)
+	require.ErrorIs(t, errClash, usecases.ErrEmailTaken)
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strconv"
 	"testing"
 	"time"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/configs"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/infrastructure/repositories"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/api"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/controllers"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
 	"github.com/stretchr/testify/require"
@@ -54,63 +56,68 @@ func (r *productRepoForDelete) DeleteLast(ctx context.Context, receptionID uuid.
 	return r.PGProductRepository.DeleteLast(ctx, receptionID)
 }
 
-type receptionRepoAdapter struct {
-	*repositories.PGReceptionRepository
-}
-
-func (r *receptionRepoAdapter) CloseLast(ctx context.Context, pvzID uuid.UUID) (entities.Reception, error) {
-	err := r.PGReceptionRepository.CloseLast(ctx, pvzID, time.Now().UTC())
-	if err != nil {
-		return entities.Reception{}, err
-	}
-	rec, err := r.GetActive(ctx, pvzID)
-	if err != nil {
-		return entities.Reception{}, err
-	}
-	if rec == nil {
-		return entities.Reception{}, sql.ErrNoRows
-	}
-	return *rec, nil
-}
-
 func setupTestServer(t *testing.T) (*gin.Engine, *sql.DB) {
 	db := setupTestDB(t)
 
 	// Инициализация репозиториев
 	pvzRepo := repositories.NewPGPVZRepository(db)
-	receptionRepo := &receptionRepoAdapter{repositories.NewPGReceptionRepository(db)}
+	receptionRepo := repositories.NewPGReceptionRepository(db)
 	productRepo := &productRepoAdapter{repositories.NewPGProductRepository(db)}
 	productRepoDelete := &productRepoForDelete{repositories.NewPGProductRepository(db)}
+	txManager := repositories.NewPGTxManager(db)
+	catalogRepo := repositories.NewPGCatalogRepository(db)
+	catalog := usecases.NewCatalogCache(catalogRepo, 0)
+	sessionRepo := repositories.NewPGSessionRepository(db)
+	userRepo := repositories.NewPGUserRepository(db)
+	staffRepo := repositories.NewPGStaffAssignmentRepository(db)
+	authz := usecases.NewAuthorizer(entities.DefaultRolePermissions(), staffRepo)
 
 	// Инициализация use cases
-	createPVZUC := usecases.NewCreatePVZUseCase(pvzRepo)
-	listPVZsUC := usecases.NewListPVZsUseCase(pvzRepo, receptionRepo, productRepo)
-	closeReceptionUC := usecases.NewCloseReceptionUseCase(receptionRepo)
-	deleteLastProductUC := usecases.NewDeleteLastProductUseCase(productRepoDelete, receptionRepo)
-	createReceptionUC := usecases.NewCreateReceptionUseCase(receptionRepo)
-	addProductUC := usecases.NewAddProductUseCase(productRepo, receptionRepo)
-	dummyLoginUC := usecases.NewDummyLoginUseCase(&configs.Config{JWTSecret: "test_secret"})
+	createPVZUC := usecases.NewCreatePVZUseCase(pvzRepo, catalog, usecases.NopMetrics{}, authz)
+	listPVZsUC := usecases.NewListPVZsUseCase(pvzRepo, authz)
+	closeReceptionUC := usecases.NewCloseReceptionUseCase(receptionRepo, txManager, authz)
+	deleteLastProductUC := usecases.NewDeleteLastProductUseCase(productRepoDelete, receptionRepo, txManager, authz)
+	createReceptionUC := usecases.NewCreateReceptionUseCase(receptionRepo, pvzRepo, txManager, usecases.NopMetrics{}, authz)
+	addProductUC := usecases.NewAddProductUseCase(productRepo, receptionRepo, catalog, txManager, usecases.NopMetrics{}, authz)
+	cfg := &configs.Config{JWTSecret: "test_secret", JWTIssuer: "pvz-service", JWTAudience: "pvz-api", AccessTokenTTL: 15 * time.Minute, RefreshTokenTTL: time.Hour}
+	dummyLoginUC := usecases.NewDummyLoginUseCase(userRepo, usecases.NewTokenIssuer(sessionRepo, cfg))
 
 	// Инициализация контроллеров
-	pvzCtrl := controllers.NewPVZController(createPVZUC, listPVZsUC, closeReceptionUC, deleteLastProductUC)
-	receptionCtrl := controllers.NewReceptionController(createReceptionUC)
-	productCtrl := controllers.NewProductController(addProductUC)
-	authCtrl := controllers.NewAuthController(dummyLoginUC, nil, nil)
-
-	// Настройка роутера
+	pvzCtrl := controllers.NewPVZController(
+		createPVZUC, listPVZsUC, closeReceptionUC, deleteLastProductUC,
+		usecases.NewGetPVZUseCase(pvzRepo, authz),
+		usecases.NewUpdatePVZUseCase(pvzRepo, catalog, txManager, authz),
+		usecases.NewArchivePVZUseCase(pvzRepo, receptionRepo, txManager, authz),
+		usecases.NewFindNearestPVZsUseCase(pvzRepo, authz),
+		usecases.NewAssignStaffUseCase(staffRepo, userRepo, pvzRepo, txManager, authz),
+		usecases.NewListPVZStaffUseCase(pvzRepo, staffRepo, authz),
+	)
+	receptionCtrl := controllers.NewReceptionController(
+		createReceptionUC,
+		usecases.NewGetReceptionUseCase(receptionRepo, productRepo, authz),
+		usecases.NewListReceptionsUseCase(pvzRepo, receptionRepo, authz),
+		usecases.NewDeleteProductUseCase(productRepo, receptionRepo, txManager, authz),
+		usecases.NewReopenReceptionUseCase(receptionRepo, pvzRepo, txManager, time.Hour, authz),
+		usecases.NewListReceptionTransitionsUseCase(receptionRepo, authz),
+		usecases.NewChangeReceptionStatusUseCase(receptionRepo, productRepo, txManager, authz),
+	)
+	productCtrl := controllers.NewProductController(
+		addProductUC,
+		usecases.NewFindProductsByBarcodeUseCase(productRepo, authz),
+		usecases.NewAddProductsBatchUseCase(productRepo, receptionRepo, catalog, txManager, usecases.NopMetrics{}, authz),
+	)
+	catalogCtrl := controllers.NewCatalogController(
+		usecases.NewListCatalogUseCase(catalogRepo, authz),
+		usecases.NewAddCatalogEntryUseCase(catalogRepo, catalog, authz),
+		usecases.NewUpdateCatalogEntryUseCase(catalogRepo, catalog, authz),
+	)
+	authCtrl := controllers.NewAuthController(dummyLoginUC, nil, nil, nil, nil, nil)
+
+	// Настройка роутера: маршруты из swagger.yaml
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
-
-	// Публичные роуты
-	r.POST("/dummyLogin", authCtrl.DummyLogin)
-
-	// Защищенные роуты
-	auth := r.Group("/", controllers.JWTAuthMiddleware("test_secret"))
-	auth.POST("/pvz", pvzCtrl.Create)
-	auth.POST("/receptions", receptionCtrl.Create)
-	auth.POST("/products", productCtrl.Add)
-	auth.POST("/pvz/:pvzId/close_last_reception", pvzCtrl.CloseLastReception)
-	auth.POST("/pvz/:pvzId/delete_last_product", pvzCtrl.DeleteLastProduct) // Добавляем эндпоинт для удаления товара
+	srv := controllers.NewServer(authCtrl, pvzCtrl, productCtrl, receptionCtrl, catalogCtrl)
+	controllers.RegisterRoutes(r, srv, controllers.NewTokenVerifier(cfg, sessionRepo), authz)
 
 	return r, db
 }
@@ -128,8 +135,10 @@ func TestPVZAPIIntegration(t *testing.T) {
 	// 2. Создаем ПВЗ
 	pvzID := createPVZ(t, r, moderatorToken)
 
-	// 3. Получаем токен сотрудника ПВЗ
-	staffToken := getToken(t, r, entities.UserRolePVZStaff)
+	// 3. Получаем токен сотрудника ПВЗ и назначаем его на ПВЗ
+	staff := dummyLogin(t, r, entities.UserRolePVZStaff)
+	staffToken := staff.Token
+	assignStaff(t, r, moderatorToken, staff.UserId, pvzID)
 
 	// 4. Создаем приёмку
 	receptionID := createReception(t, r, staffToken, pvzID)
@@ -155,7 +164,50 @@ func TestPVZAPIIntegration(t *testing.T) {
 	require.Len(t, products, 50)
 }
 
+// TestBatchIntakeIntegration — те же 50 товаров одним запросом POST /products/batch
+func TestBatchIntakeIntegration(t *testing.T) {
+	// Arrange
+	r, db := setupTestServer(t)
+	ctx := context.Background()
+	moderatorToken := getToken(t, r, entities.UserRoleModerator)
+	pvzID := createPVZ(t, r, moderatorToken)
+	staff := dummyLogin(t, r, entities.UserRolePVZStaff)
+	staffToken := staff.Token
+	assignStaff(t, r, moderatorToken, staff.UserId, pvzID)
+	receptionID := createReception(t, r, staffToken, pvzID)
+	items := make([]map[string]string, 0, 50)
+	for i := 0; i < 50; i++ {
+		items = append(items, map[string]string{"type": string(entities.ProductShoes), "barcode": strconv.Itoa(1000 + i)})
+	}
+
+	// Act
+	jsonBody, _ := json.Marshal(map[string]any{"pvzId": pvzID.String(), "products": items})
+	req := httptest.NewRequest(http.MethodPost, "/products/batch", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+staffToken)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp api.ProductBatchResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, 50, resp.Accepted)
+	require.Zero(t, resp.Rejected)
+	closeReception(t, r, staffToken, pvzID)
+	products, err := repositories.NewPGProductRepository(db).ListByReception(ctx, receptionID)
+	require.NoError(t, err)
+	require.Len(t, products, 50)
+	require.Equal(t, "1049", products[49].Barcode)
+	require.Equal(t, 50, products[49].Position)
+}
+
 func getToken(t *testing.T, r *gin.Engine, role entities.UserRole) string {
+	return dummyLogin(t, r, role).Token
+}
+
+// dummyLogin возвращает ответ /dummyLogin: токены и id пользователя
+func dummyLogin(t *testing.T, r *gin.Engine, role entities.UserRole) api.TokenResponse {
 	body := map[string]string{"role": string(role)}
 	jsonBody, _ := json.Marshal(body)
 	req := httptest.NewRequest(http.MethodPost, "/dummyLogin", bytes.NewBuffer(jsonBody))
@@ -164,11 +216,12 @@ func getToken(t *testing.T, r *gin.Engine, role entities.UserRole) string {
 	r.ServeHTTP(w, req)
 	require.Equal(t, http.StatusOK, w.Code)
 
-	var response map[string]string
+	var response api.TokenResponse
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	require.NoError(t, err)
-	require.Contains(t, response, "token")
-	return response["token"]
+	require.NotEmpty(t, response.Token)
+	require.NotEqual(t, uuid.Nil, response.UserId)
+	return response
 }
 
 func createPVZ(t *testing.T, r *gin.Engine, token string) uuid.UUID {
@@ -181,10 +234,20 @@ func createPVZ(t *testing.T, r *gin.Engine, token string) uuid.UUID {
 	r.ServeHTTP(w, req)
 	require.Equal(t, http.StatusCreated, w.Code)
 
-	var response entities.PVZ
+	var response api.PVZ
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	require.NoError(t, err)
-	return response.ID
+	require.NotNil(t, response.Id)
+	return *response.Id
+}
+
+// assignStaff назначает сотрудника staffID на ПВЗ от имени модератора
+func assignStaff(t *testing.T, r *gin.Engine, moderatorToken string, staffID, pvzID uuid.UUID) {
+	req := httptest.NewRequest(http.MethodPut, "/pvz/"+pvzID.String()+"/staff/"+staffID.String(), nil)
+	req.Header.Set("Authorization", "Bearer "+moderatorToken)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNoContent, w.Code)
 }
 
 func createReception(t *testing.T, r *gin.Engine, token string, pvzID uuid.UUID) uuid.UUID {
@@ -195,12 +258,13 @@ func createReception(t *testing.T, r *gin.Engine, token string, pvzID uuid.UUID)
 	req.Header.Set("Authorization", "Bearer "+token)
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, req)
-	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, http.StatusCreated, w.Code)
 
-	var response entities.Reception
+	var response api.Reception
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	require.NoError(t, err)
-	return response.ID
+	require.NotNil(t, response.Id)
+	return *response.Id
 }
 
 func addProduct(t *testing.T, r *gin.Engine, token string, pvzID uuid.UUID, productType entities.ProductType) {
@@ -5,12 +5,14 @@ import (
 	"database/sql"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/joho/godotenv"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/configs"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/infrastructure/migrations"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/infrastructure/repositories"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
 	"github.com/stretchr/testify/require"
@@ -35,24 +37,13 @@ func setupTestDB(t *testing.T) *sql.DB {
 	db, err := sql.Open("pgx", dsn)
 	require.NoError(t, err)
 	t.Cleanup(func() { db.Close() })
+	// Схема — только из встроенных миграций, как в проде
+	m, err := migrations.NewMigrator(db)
+	require.NoError(t, err)
+	require.NoError(t, m.Up(context.Background()))
 	_, err = db.Exec(`
-CREATE TABLE IF NOT EXISTS pvz (
-    id UUID PRIMARY KEY,
-    registration_date TIMESTAMPTZ NOT NULL,
-    city TEXT NOT NULL
-);
-CREATE TABLE IF NOT EXISTS reception (
-    id UUID PRIMARY KEY,
-    pvz_id UUID NOT NULL REFERENCES pvz(id),
-    status TEXT NOT NULL,
-    date_time TIMESTAMPTZ NOT NULL
-);
-CREATE TABLE IF NOT EXISTS product (
-    id UUID PRIMARY KEY,
-    reception_id UUID NOT NULL REFERENCES reception(id),
-    type TEXT NOT NULL,
-    date_time TIMESTAMPTZ NOT NULL
-);
+DELETE FROM product_removal;
+DELETE FROM reception_transition;
 DELETE FROM product;
 DELETE FROM reception;
 DELETE FROM pvz;
@@ -70,12 +61,18 @@ func TestPVZIntegration(t *testing.T) {
 	pvzRepo := repositories.NewPGPVZRepository(db)
 	receptionRepo := repositories.NewPGReceptionRepository(db)
 	productRepo := repositories.NewPGProductRepository(db)
+	txManager := repositories.NewPGTxManager(db)
+	catalog := usecases.NewCatalogCache(repositories.NewPGCatalogRepository(db), 0)
+	userRepo := repositories.NewPGUserRepository(db)
+	staffRepo := repositories.NewPGStaffAssignmentRepository(db)
+	authz := usecases.NewAuthorizer(entities.DefaultRolePermissions(), staffRepo)
 
 	// Инициализация use cases
-	createPVZUC := usecases.NewCreatePVZUseCase(pvzRepo)
-	createReceptionUC := usecases.NewCreateReceptionUseCase(receptionRepo)
-	addProductUC := usecases.NewAddProductUseCase(productRepo, receptionRepo)
-	closeReceptionUC := usecases.NewCloseReceptionUseCase(receptionRepo)
+	createPVZUC := usecases.NewCreatePVZUseCase(pvzRepo, catalog, usecases.NopMetrics{}, authz)
+	assignStaffUC := usecases.NewAssignStaffUseCase(staffRepo, userRepo, pvzRepo, txManager, authz)
+	createReceptionUC := usecases.NewCreateReceptionUseCase(receptionRepo, pvzRepo, txManager, usecases.NopMetrics{}, authz)
+	addProductUC := usecases.NewAddProductUseCase(productRepo, receptionRepo, catalog, txManager, usecases.NopMetrics{}, authz)
+	closeReceptionUC := usecases.NewCloseReceptionUseCase(receptionRepo, txManager, authz)
 
 	// Act: выполняем сценарий тестирования
 
@@ -85,22 +82,29 @@ func TestPVZIntegration(t *testing.T) {
 		Email: "moderator@avito.ru",
 		Role:  entities.UserRoleModerator,
 	}
-	pvz, err := createPVZUC.Execute(ctx, moderator, entities.CityMoscow)
+	pvz, err := createPVZUC.Execute(ctx, moderator, entities.CityMoscow, entities.PVZDetails{})
 	require.NoError(t, err)
 	require.NotNil(t, pvz)
 
-	// 2. Создание приёмки
-	staff := entities.User{
-		ID:    uuid.New(),
-		Email: "staff@avito.ru",
-		Role:  entities.UserRolePVZStaff,
-	}
+	// 2. Назначение сотрудника на ПВЗ
+	staff, err := userRepo.Create(ctx, entities.User{
+		ID:               uuid.New(),
+		Email:            "staff-" + uuid.NewString() + "@avito.ru",
+		Role:             entities.UserRolePVZStaff,
+		RegistrationDate: time.Now().UTC(),
+	}, "hash")
+	require.NoError(t, err)
+	_, err = createReceptionUC.Execute(ctx, staff, pvz.ID)
+	require.ErrorIs(t, err, usecases.ErrNotAssignedToPVZ)
+	require.NoError(t, assignStaffUC.Execute(ctx, moderator, pvz.ID, staff.ID, true))
+
+	// 3. Создание приёмки
 	reception, err := createReceptionUC.Execute(ctx, staff, pvz.ID)
 	require.NoError(t, err)
 	require.NotNil(t, reception)
 	require.Equal(t, entities.ReceptionInProgress, reception.Status)
 
-	// 3. Добавление 50 товаров
+	// 4. Добавление 50 товаров
 	for i := 0; i < 50; i++ {
 		productType := entities.ProductElectronics
 		if i%3 == 0 {
@@ -108,14 +112,14 @@ func TestPVZIntegration(t *testing.T) {
 		} else if i%3 == 1 {
 			productType = entities.ProductShoes
 		}
-		product, err := addProductUC.Execute(ctx, staff, pvz.ID, productType)
+		product, err := addProductUC.Execute(ctx, staff, pvz.ID, productType, "")
 		require.NoError(t, err)
 		require.NotNil(t, product)
 		require.Equal(t, productType, product.Type)
 		require.Equal(t, reception.ID, product.ReceptionID)
 	}
 
-	// 4. Закрытие приёмки
+	// 5. Закрытие приёмки
 	closedReception, err := closeReceptionUC.Execute(ctx, staff, pvz.ID)
 	require.NoError(t, err)
 	require.NotNil(t, closedReception)
@@ -7,20 +7,23 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/api"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/controllers"
-	"github.com/stretchr/testify/assert"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
 type mockDummyLoginUC struct{ mock.Mock }
 
-func (m *mockDummyLoginUC) Execute(ctx context.Context, role entities.UserRole) (string, error) {
+func (m *mockDummyLoginUC) Execute(ctx context.Context, role entities.UserRole) (usecases.TokenPair, error) {
 	args := m.Called(ctx, role)
-	return args.String(0), args.Error(1)
+	return args.Get(0).(usecases.TokenPair), args.Error(1)
 }
 
 type mockRegisterUC struct{ mock.Mock }
@@ -32,16 +35,30 @@ func (m *mockRegisterUC) Execute(ctx context.Context, email, password string, ro
 
 type mockLoginUC struct{ mock.Mock }
 
-func (m *mockLoginUC) Execute(ctx context.Context, email, password string) (string, error) {
+func (m *mockLoginUC) Execute(ctx context.Context, email, password string) (usecases.TokenPair, error) {
 	args := m.Called(ctx, email, password)
-	return args.String(0), args.Error(1)
+	return args.Get(0).(usecases.TokenPair), args.Error(1)
+}
+
+type mockRefreshTokenUC struct{ mock.Mock }
+
+func (m *mockRefreshTokenUC) Execute(ctx context.Context, refreshToken string) (usecases.TokenPair, error) {
+	args := m.Called(ctx, refreshToken)
+	return args.Get(0).(usecases.TokenPair), args.Error(1)
+}
+
+type mockLogoutUC struct{ mock.Mock }
+
+func (m *mockLogoutUC) Execute(ctx context.Context, sessionID uuid.UUID) error {
+	return m.Called(ctx, sessionID).Error(0)
 }
 
 func TestAuthController_DummyLogin(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	uc := new(mockDummyLoginUC)
-	ctrl := controllers.NewAuthController(uc, nil, nil)
+	ctrl := controllers.NewAuthController(uc, nil, nil, nil, nil, nil)
 	r := gin.New()
+	r.Use(controllers.ErrorHandlerMiddleware())
 	r.POST("/dummyLogin", ctrl.DummyLogin)
 
 	// happy path
@@ -50,12 +67,16 @@ func TestAuthController_DummyLogin(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 	ctx := req.Context()
-	uc.On("Execute", ctx, entities.UserRoleModerator).Return("token123", nil)
+	userID := uuid.New()
+	uc.On("Execute", ctx, entities.UserRoleModerator).Return(usecases.TokenPair{AccessToken: "token123", RefreshToken: "refresh123", ExpiresIn: 15 * time.Minute, UserID: userID}, nil)
 	r.ServeHTTP(w, req)
 	require.Equal(t, 200, w.Code)
-	var resp map[string]string
-	_ = json.Unmarshal(w.Body.Bytes(), &resp)
-	require.Equal(t, "token123", resp["token"])
+	var resp api.TokenResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, "token123", resp.Token)
+	require.Equal(t, "refresh123", resp.RefreshToken)
+	require.Equal(t, 900, resp.ExpiresIn)
+	require.Equal(t, userID, resp.UserId)
 	uc.AssertExpectations(t)
 
 	// ошибка usecase
@@ -64,16 +85,17 @@ func TestAuthController_DummyLogin(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json")
 	w = httptest.NewRecorder()
 	ctx = req.Context()
-	uc.On("Execute", ctx, entities.UserRoleClient).Return("", assert.AnError)
+	uc.On("Execute", ctx, entities.UserRoleClient).Return(usecases.TokenPair{}, usecases.ErrInvalidRole)
 	r.ServeHTTP(w, req)
-	require.Equal(t, 401, w.Code)
+	require.Equal(t, 400, w.Code)
 }
 
 func TestAuthController_Register(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	uc := new(mockRegisterUC)
-	ctrl := controllers.NewAuthController(nil, uc, nil)
+	ctrl := controllers.NewAuthController(nil, uc, nil, nil, nil, nil)
 	r := gin.New()
+	r.Use(controllers.ErrorHandlerMiddleware())
 	r.POST("/register", ctrl.Register)
 
 	user := entities.User{Email: "test@avito.ru", Role: entities.UserRoleModerator}
@@ -96,16 +118,17 @@ func TestAuthController_Register(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json")
 	w = httptest.NewRecorder()
 	ctx = req.Context()
-	uc.On("Execute", ctx, "fail@avito.ru", "fail", entities.UserRoleModerator).Return(entities.User{}, assert.AnError)
+	uc.On("Execute", ctx, "fail@avito.ru", "fail", entities.UserRoleModerator).Return(entities.User{}, usecases.ErrEmailTaken)
 	r.ServeHTTP(w, req)
-	require.Equal(t, 400, w.Code)
+	require.Equal(t, 409, w.Code)
 }
 
 func TestAuthController_Login(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	uc := new(mockLoginUC)
-	ctrl := controllers.NewAuthController(nil, nil, uc)
+	ctrl := controllers.NewAuthController(nil, nil, uc, nil, nil, nil)
 	r := gin.New()
+	r.Use(controllers.ErrorHandlerMiddleware())
 	r.POST("/login", ctrl.Login)
 
 	body := `{"email":"test@avito.ru","password":"pass"}`
@@ -113,12 +136,13 @@ func TestAuthController_Login(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 	ctx := req.Context()
-	uc.On("Execute", ctx, "test@avito.ru", "pass").Return("token456", nil)
+	uc.On("Execute", ctx, "test@avito.ru", "pass").Return(usecases.TokenPair{AccessToken: "token456", RefreshToken: "refresh456", ExpiresIn: 15 * time.Minute}, nil)
 	r.ServeHTTP(w, req)
 	require.Equal(t, 200, w.Code)
-	var resp map[string]string
-	_ = json.Unmarshal(w.Body.Bytes(), &resp)
-	require.Equal(t, "token456", resp["token"])
+	var resp api.TokenResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, "token456", resp.Token)
+	require.Equal(t, "refresh456", resp.RefreshToken)
 	uc.AssertExpectations(t)
 
 	// ошибка usecase
@@ -127,7 +151,64 @@ func TestAuthController_Login(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json")
 	w = httptest.NewRecorder()
 	ctx = req.Context()
-	uc.On("Execute", ctx, "fail@avito.ru", "fail").Return("", assert.AnError)
+	uc.On("Execute", ctx, "fail@avito.ru", "fail").Return(usecases.TokenPair{}, usecases.ErrInvalidCredentials)
 	r.ServeHTTP(w, req)
 	require.Equal(t, 401, w.Code)
 }
+
+func TestAuthController_RefreshAndLogout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	anyCtx := mock.MatchedBy(func(ctx context.Context) bool { return true })
+	sessionID := uuid.New()
+
+	setup := func(refresh *mockRefreshTokenUC, logout *mockLogoutUC) *gin.Engine {
+		ctrl := controllers.NewAuthController(nil, nil, nil, refresh, logout, nil)
+		r := gin.New()
+		r.Use(controllers.ErrorHandlerMiddleware())
+		r.POST("/token/refresh", ctrl.Refresh)
+		r.POST("/logout", func(ctx *gin.Context) { ctx.Set("session_id", sessionID) }, ctrl.Logout)
+		return r
+	}
+
+	t.Run("обмен refresh-токена", func(t *testing.T) {
+		// Arrange
+		refresh := new(mockRefreshTokenUC)
+		r := setup(refresh, nil)
+		refresh.On("Execute", anyCtx, "old").Return(usecases.TokenPair{AccessToken: "access", RefreshToken: "new", ExpiresIn: time.Minute}, nil)
+		refresh.On("Execute", anyCtx, "used").Return(usecases.TokenPair{}, usecases.ErrSessionRevoked)
+
+		// Act
+		ok := httptest.NewRecorder()
+		r.ServeHTTP(ok, httptest.NewRequest(http.MethodPost, "/token/refresh", bytes.NewBufferString(`{"refreshToken":"old"}`)))
+		revoked := httptest.NewRecorder()
+		r.ServeHTTP(revoked, httptest.NewRequest(http.MethodPost, "/token/refresh", bytes.NewBufferString(`{"refreshToken":"used"}`)))
+		broken := httptest.NewRecorder()
+		r.ServeHTTP(broken, httptest.NewRequest(http.MethodPost, "/token/refresh", bytes.NewBufferString(`{`)))
+
+		// Assert
+		require.Equal(t, http.StatusOK, ok.Code)
+		var resp api.TokenResponse
+		require.NoError(t, json.Unmarshal(ok.Body.Bytes(), &resp))
+		require.Equal(t, "access", resp.Token)
+		require.Equal(t, "new", resp.RefreshToken)
+		require.Equal(t, 60, resp.ExpiresIn)
+		require.Equal(t, http.StatusUnauthorized, revoked.Code)
+		require.Equal(t, http.StatusBadRequest, broken.Code)
+		refresh.AssertExpectations(t)
+	})
+
+	t.Run("выход отзывает сессию токена", func(t *testing.T) {
+		// Arrange
+		logout := new(mockLogoutUC)
+		r := setup(nil, logout)
+		logout.On("Execute", anyCtx, sessionID).Return(nil)
+
+		// Act
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/logout", nil))
+
+		// Assert
+		require.Equal(t, http.StatusNoContent, w.Code)
+		logout.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,103 @@
+package controllers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/api"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/controllers"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockListCatalogUC struct{ mock.Mock }
+
+func (m *mockListCatalogUC) Execute(ctx context.Context, user entities.User, kind entities.CatalogKind) ([]entities.CatalogEntry, error) {
+	args := m.Called(ctx, user, kind)
+	return args.Get(0).([]entities.CatalogEntry), args.Error(1)
+}
+
+type mockAddCatalogEntryUC struct{ mock.Mock }
+
+func (m *mockAddCatalogEntryUC) Execute(ctx context.Context, user entities.User, kind entities.CatalogKind, name string) (entities.CatalogEntry, error) {
+	args := m.Called(ctx, user, kind, name)
+	return args.Get(0).(entities.CatalogEntry), args.Error(1)
+}
+
+type mockUpdateCatalogEntryUC struct{ mock.Mock }
+
+func (m *mockUpdateCatalogEntryUC) Execute(ctx context.Context, user entities.User, kind entities.CatalogKind, id int64, patch usecases.CatalogEntryPatch) (entities.CatalogEntry, error) {
+	args := m.Called(ctx, user, kind, id, patch)
+	return args.Get(0).(entities.CatalogEntry), args.Error(1)
+}
+
+func TestCatalogController(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	moderator := entities.User{Role: entities.UserRoleModerator}
+	anyCtx := mock.MatchedBy(func(ctx context.Context) bool { return true })
+	setup := func(list *mockListCatalogUC, add *mockAddCatalogEntryUC, update *mockUpdateCatalogEntryUC) *gin.Engine {
+		ctrl := controllers.NewCatalogController(list, add, update)
+		r := gin.New()
+		r.Use(controllers.ErrorHandlerMiddleware(), func(ctx *gin.Context) { ctx.Set("user", moderator) })
+		r.GET("/catalogs/:kind", func(ctx *gin.Context) { ctrl.List(ctx, api.CatalogKind(ctx.Param("kind"))) })
+		r.POST("/catalogs/:kind", func(ctx *gin.Context) { ctrl.Add(ctx, api.CatalogKind(ctx.Param("kind"))) })
+		r.PATCH("/catalogs/:kind/7", func(ctx *gin.Context) { ctrl.Update(ctx, api.CatalogKind(ctx.Param("kind")), 7) })
+		return r
+	}
+	do := func(r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("список — пустой массив, а не null", func(t *testing.T) {
+		list := new(mockListCatalogUC)
+		list.On("Execute", anyCtx, moderator, entities.CatalogCity).Return([]entities.CatalogEntry(nil), nil)
+		w := do(setup(list, nil, nil), http.MethodGet, "/catalogs/city", "")
+		require.Equal(t, http.StatusOK, w.Code)
+		require.JSONEq(t, `[]`, w.Body.String())
+	})
+
+	t.Run("добавление — 201", func(t *testing.T) {
+		add := new(mockAddCatalogEntryUC)
+		add.On("Execute", anyCtx, moderator, entities.CatalogProductType, "косметика").
+			Return(entities.CatalogEntry{ID: 4, Kind: entities.CatalogProductType, Name: "косметика", Active: true}, nil)
+		w := do(setup(nil, add, nil), http.MethodPost, "/catalogs/product_type", `{"name":"косметика"}`)
+		require.Equal(t, http.StatusCreated, w.Code)
+		require.JSONEq(t, `{"id":4,"name":"косметика","active":true}`, w.Body.String())
+	})
+
+	t.Run("добавление дубля — 409", func(t *testing.T) {
+		add := new(mockAddCatalogEntryUC)
+		add.On("Execute", anyCtx, moderator, entities.CatalogCity, "Москва").Return(entities.CatalogEntry{}, usecases.ErrCatalogEntryExists)
+		w := do(setup(nil, add, nil), http.MethodPost, "/catalogs/city", `{"name":"Москва"}`)
+		require.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("изменение передаёт только указанные поля", func(t *testing.T) {
+		update := new(mockUpdateCatalogEntryUC)
+		inactive := false
+		update.On("Execute", anyCtx, moderator, entities.CatalogCity, int64(7), usecases.CatalogEntryPatch{Active: &inactive}).
+			Return(entities.CatalogEntry{ID: 7, Kind: entities.CatalogCity, Name: "Казань"}, nil)
+		w := do(setup(nil, nil, update), http.MethodPatch, "/catalogs/city/7", `{"active":false}`)
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp api.CatalogEntry
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.False(t, resp.Active)
+		update.AssertExpectations(t)
+	})
+
+	t.Run("некорректное тело — 400", func(t *testing.T) {
+		w := do(setup(nil, nil, nil), http.MethodPatch, "/catalogs/city/7", `{"active":"нет"}`)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,91 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/api"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/controllers"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   api.ErrorCode
+	}{
+		{"валидация", usecases.ErrInvalidCity, http.StatusBadRequest, api.ErrorCodeValidationError},
+		{"не авторизован", usecases.ErrInvalidCredentials, http.StatusUnauthorized, api.ErrorCodeUnauthorized},
+		{"нет прав", usecases.NewError(usecases.ErrForbidden, "нет прав"), http.StatusForbidden, api.ErrorCodeForbidden},
+		{"не найдено", usecases.ErrNoProductsToDelete, http.StatusNotFound, api.ErrorCodeNotFound},
+		{"конфликт", usecases.ErrReceptionAlreadyOpen, http.StatusConflict, api.ErrorCodeConflict},
+		{"нет открытой приёмки", usecases.ErrNoReceptionToClose, http.StatusBadRequest, api.ErrorCodeNoOpenReception},
+		{"обёрнутая ошибка", fmt.Errorf("wrap: %w", usecases.ErrEmailTaken), http.StatusConflict, api.ErrorCodeConflict},
+		{"неизвестная ошибка", assert.AnError, http.StatusInternalServerError, api.ErrorCodeInternalError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			status, body := controllers.MapError(tt.err)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, status)
+			assert.Equal(t, tt.wantCode, body.Code)
+		})
+	}
+
+	t.Run("внутренняя ошибка не раскрывается клиенту", func(t *testing.T) {
+		_, body := controllers.MapError(assert.AnError)
+		assert.Equal(t, "internal error", body.Message)
+	})
+}
+
+func TestErrorHandlerMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("ошибка из контроллера превращается в ответ с code", func(t *testing.T) {
+		// Arrange
+		r := gin.New()
+		r.Use(controllers.ErrorHandlerMiddleware())
+		r.GET("/", func(ctx *gin.Context) {
+			_ = ctx.Error(usecases.ErrReceptionAlreadyOpen)
+		})
+
+		// Act
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		// Assert
+		require.Equal(t, http.StatusConflict, w.Code)
+		var resp api.Error
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, api.ErrorCodeConflict, resp.Code)
+		assert.Equal(t, usecases.ErrReceptionAlreadyOpen.Message, resp.Message)
+	})
+
+	t.Run("уже записанный ответ не перезаписывается", func(t *testing.T) {
+		// Arrange
+		r := gin.New()
+		r.Use(controllers.ErrorHandlerMiddleware())
+		r.GET("/", func(ctx *gin.Context) {
+			ctx.JSON(http.StatusOK, gin.H{"ok": true})
+			_ = ctx.Error(assert.AnError)
+		})
+
+		// Act
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"ok":true}`, w.Body.String())
+	})
+}
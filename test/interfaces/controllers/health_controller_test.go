@@ -0,0 +1,102 @@
+package controllers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/controllers"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubReadinessUC struct{ res usecases.Readiness }
+
+func (s stubReadinessUC) Execute(context.Context) usecases.Readiness { return s.res }
+
+func setupHealthRouter(res usecases.Readiness) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	controllers.RegisterHealthRoutes(r, controllers.NewHealthController(stubReadinessUC{res: res}))
+	return r
+}
+
+func TestHealthController(t *testing.T) {
+	t.Run("live всегда 200, даже если БД недоступна", func(t *testing.T) {
+		// Arrange
+		r := setupHealthRouter(usecases.Readiness{DatabaseErr: assert.AnError})
+
+		// Act
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("ready 200 с версией схемы", func(t *testing.T) {
+		// Arrange
+		r := setupHealthRouter(usecases.Readiness{Ready: true, Migrations: &usecases.MigrationState{Version: 3, Latest: 3}})
+
+		// Act
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		var body struct {
+			Status string `json:"status"`
+			Checks struct {
+				Database   string                  `json:"database"`
+				Migrations usecases.MigrationState `json:"migrations"`
+			} `json:"checks"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "ok", body.Status)
+		assert.Equal(t, "ok", body.Checks.Database)
+		assert.Equal(t, int64(3), body.Checks.Migrations.Version)
+	})
+
+	t.Run("ready 503 без текста ошибки БД", func(t *testing.T) {
+		// Arrange
+		r := setupHealthRouter(usecases.Readiness{DatabaseErr: assert.AnError})
+
+		// Act
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+		// Assert
+		require.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Contains(t, w.Body.String(), `"database":"unavailable"`)
+		assert.NotContains(t, w.Body.String(), assert.AnError.Error())
+	})
+
+	t.Run("ready 503 во время остановки", func(t *testing.T) {
+		// Arrange
+		r := setupHealthRouter(usecases.Readiness{ShuttingDown: true, Migrations: &usecases.MigrationState{Version: 3, Latest: 3}})
+
+		// Act
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+		// Assert
+		require.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Contains(t, w.Body.String(), `"status":"shutting_down"`)
+	})
+
+	t.Run("ping оставлен для совместимости", func(t *testing.T) {
+		// Arrange
+		r := setupHealthRouter(usecases.Readiness{})
+
+		// Act
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ping", nil))
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+}
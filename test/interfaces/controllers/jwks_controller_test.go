@@ -0,0 +1,77 @@
+package controllers_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/configs"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/controllers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWKSController(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	cfg := &configs.Config{
+		JWTSecret: "secret",
+		JWTKeyFiles: map[string]configs.JWTKey{
+			"rs-1": {KID: "rs-1", Alg: configs.JWTAlgRS256, Sign: rsaKey, Verify: &rsaKey.PublicKey},
+			"ed-0": {KID: "ed-0", Alg: configs.JWTAlgEdDSA, Verify: edPub},
+		},
+		JWTActiveKID: "rs-1",
+	}
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	controllers.RegisterJWKSRoutes(r, controllers.NewJWKSController(cfg.PublicKeys()))
+
+	// Act
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil))
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Cache-Control"), "max-age")
+	var body struct {
+		Keys []controllers.JWK `json:"keys"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Len(t, body.Keys, 2, "HS256-ключ не публикуется")
+	assert.NotContains(t, w.Body.String(), "secret")
+
+	ed, rs := body.Keys[0], body.Keys[1]
+	assert.Equal(t, controllers.JWK{Kty: "OKP", Kid: "ed-0", Alg: "EdDSA", Use: "sig", Crv: "Ed25519",
+		X: base64.RawURLEncoding.EncodeToString(edPub)}, ed)
+	assert.Equal(t, "RSA", rs.Kty)
+	assert.Equal(t, "rs-1", rs.Kid)
+	assert.Equal(t, "RS256", rs.Alg)
+
+	t.Run("по опубликованному ключу проверяется подпись токена", func(t *testing.T) {
+		// Arrange
+		n, err := base64.RawURLEncoding.DecodeString(rs.N)
+		require.NoError(t, err)
+		e, err := base64.RawURLEncoding.DecodeString(rs.E)
+		require.NoError(t, err)
+		pub := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		signed, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{"sub": "x"}).SignedString(rsaKey)
+		require.NoError(t, err)
+
+		// Act
+		_, err = jwt.Parse(signed, func(*jwt.Token) (interface{}, error) { return pub, nil },
+			jwt.WithValidMethods([]string{rs.Alg}))
+
+		// Assert
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,326 @@
+package controllers_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/configs"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/api"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/controllers"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// activeSessions — все сессии активны, кроме перечисленных в revoked
+type activeSessions struct{ revoked map[uuid.UUID]bool }
+
+func (s activeSessions) IsSessionActive(_ context.Context, id uuid.UUID) (bool, error) {
+	return !s.revoked[id], nil
+}
+
+func TestTokenVerifier_Verify(t *testing.T) {
+	cfg := &configs.Config{
+		JWTKeys:      map[string]string{"2025-01": "old-secret", "2025-02": "new-secret"},
+		JWTActiveKID: "2025-02",
+		JWTIssuer:    "pvz-service",
+		JWTAudience:  "pvz-api",
+	}
+	userID, sessionID, revokedID := uuid.New(), uuid.New(), uuid.New()
+	verifier := controllers.NewTokenVerifier(cfg, activeSessions{revoked: map[uuid.UUID]bool{revokedID: true}})
+
+	// claims — валидные claims, которые подкручивает каждый кейс
+	claims := func() usecases.AccessClaims {
+		now := time.Now()
+		return usecases.AccessClaims{
+			Role:      entities.UserRolePVZStaff,
+			Email:     "staff@avito.ru",
+			SessionID: sessionID.String(),
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    "pvz-service",
+				Subject:   userID.String(),
+				Audience:  jwt.ClaimStrings{"pvz-api"},
+				IssuedAt:  jwt.NewNumericDate(now),
+				ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+			},
+		}
+	}
+	sign := func(t *testing.T, method jwt.SigningMethod, kid string, key any, c usecases.AccessClaims) string {
+		token := jwt.NewWithClaims(method, c)
+		if kid != "" {
+			token.Header["kid"] = kid
+		}
+		s, err := token.SignedString(key)
+		require.NoError(t, err)
+		return s
+	}
+
+	t.Run("токен активного и старого ключа принимается", func(t *testing.T) {
+		// Arrange
+		active := sign(t, jwt.SigningMethodHS256, "2025-02", []byte("new-secret"), claims())
+		old := sign(t, jwt.SigningMethodHS256, "2025-01", []byte("old-secret"), claims())
+
+		// Act
+		user, sid, err := verifier.Verify(context.Background(), active)
+		_, _, errOld := verifier.Verify(context.Background(), old)
+
+		// Assert
+		require.NoError(t, err)
+		require.NoError(t, errOld)
+		assert.Equal(t, userID, user.ID)
+		assert.Equal(t, entities.UserRolePVZStaff, user.Role)
+		assert.Equal(t, "staff@avito.ru", user.Email)
+		assert.Equal(t, sessionID, sid)
+	})
+
+	t.Run("токен выпущенный TokenIssuer проходит проверку", func(t *testing.T) {
+		// Arrange
+		sessions := activeSessionStore{}
+		issuer := usecases.NewTokenIssuer(sessions, &configs.Config{
+			JWTKeys: cfg.JWTKeys, JWTActiveKID: cfg.JWTActiveKID, JWTIssuer: cfg.JWTIssuer, JWTAudience: cfg.JWTAudience,
+			AccessTokenTTL: time.Minute, RefreshTokenTTL: time.Hour,
+		})
+		pair, err := issuer.StartSession(context.Background(), entities.User{ID: userID, Role: entities.UserRoleModerator})
+		require.NoError(t, err)
+
+		// Act
+		user, _, err := verifier.Verify(context.Background(), pair.AccessToken)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, userID, user.ID)
+		assert.Empty(t, user.Email)
+	})
+
+	t.Run("непринятые токены", func(t *testing.T) {
+		noExp := claims()
+		noExp.ExpiresAt = nil
+		expired := claims()
+		expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+		wrongIss := claims()
+		wrongIss.Issuer = "evil"
+		wrongAud := claims()
+		wrongAud.Audience = jwt.ClaimStrings{"other-api"}
+		noSub := claims()
+		noSub.Subject = ""
+		noSid := claims()
+		noSid.SessionID = ""
+		badRole := claims()
+		badRole.Role = "root"
+		revoked := claims()
+		revoked.SessionID = revokedID.String()
+
+		cases := map[string]struct {
+			token   string
+			wantErr error
+		}{
+			"алгоритм none":      {sign(t, jwt.SigningMethodNone, "2025-02", jwt.UnsafeAllowNoneSignatureType, claims()), usecases.ErrUnauthorized},
+			"алгоритм HS512":     {sign(t, jwt.SigningMethodHS512, "2025-02", []byte("new-secret"), claims()), usecases.ErrUnauthorized},
+			"без kid":            {sign(t, jwt.SigningMethodHS256, "", []byte("new-secret"), claims()), usecases.ErrUnauthorized},
+			"неизвестный kid":    {sign(t, jwt.SigningMethodHS256, "2024-12", []byte("new-secret"), claims()), usecases.ErrUnauthorized},
+			"чужой ключ под kid": {sign(t, jwt.SigningMethodHS256, "2025-02", []byte("old-secret"), claims()), usecases.ErrUnauthorized},
+			"без exp":            {sign(t, jwt.SigningMethodHS256, "2025-02", []byte("new-secret"), noExp), usecases.ErrUnauthorized},
+			"истёк":              {sign(t, jwt.SigningMethodHS256, "2025-02", []byte("new-secret"), expired), usecases.ErrUnauthorized},
+			"чужой iss":          {sign(t, jwt.SigningMethodHS256, "2025-02", []byte("new-secret"), wrongIss), usecases.ErrUnauthorized},
+			"чужой aud":          {sign(t, jwt.SigningMethodHS256, "2025-02", []byte("new-secret"), wrongAud), usecases.ErrUnauthorized},
+			"без sub":            {sign(t, jwt.SigningMethodHS256, "2025-02", []byte("new-secret"), noSub), usecases.ErrUnauthorized},
+			"без sid":            {sign(t, jwt.SigningMethodHS256, "2025-02", []byte("new-secret"), noSid), usecases.ErrUnauthorized},
+			"неизвестная роль":   {sign(t, jwt.SigningMethodHS256, "2025-02", []byte("new-secret"), badRole), usecases.ErrUnauthorized},
+			"отозванная сессия":  {sign(t, jwt.SigningMethodHS256, "2025-02", []byte("new-secret"), revoked), usecases.ErrSessionRevoked},
+			"не JWT":             {"garbage", usecases.ErrUnauthorized},
+		}
+		for name, tc := range cases {
+			t.Run(name, func(t *testing.T) {
+				// Act
+				_, _, err := verifier.Verify(context.Background(), tc.token)
+
+				// Assert
+				require.ErrorIs(t, err, tc.wantErr)
+			})
+		}
+	})
+}
+
+// activeSessionStore — хранилище сессий для TokenIssuer, ничего не сохраняет
+type activeSessionStore struct{}
+
+func (activeSessionStore) CreateSession(context.Context, entities.Session) error { return nil }
+
+func (activeSessionStore) SaveRefreshToken(context.Context, entities.RefreshToken) error { return nil }
+
+func TestTokenVerifier_AsymmetricKeys(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rsaPubDER, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	require.NoError(t, err)
+	rsaPubPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: rsaPubDER})
+
+	// HS256-ключ остаётся на время перехода, ed-0 — выведенный из ротации ключ, только открытая часть
+	cfg := &configs.Config{
+		JWTSecret: "secret",
+		JWTKeyFiles: map[string]configs.JWTKey{
+			"rs-1": {KID: "rs-1", Alg: configs.JWTAlgRS256, Sign: rsaKey, Verify: &rsaKey.PublicKey},
+			"ed-0": {KID: "ed-0", Alg: configs.JWTAlgEdDSA, Verify: edPub},
+		},
+		JWTActiveKID:    "rs-1",
+		JWTIssuer:       "pvz-service",
+		JWTAudience:     "pvz-api",
+		AccessTokenTTL:  time.Minute,
+		RefreshTokenTTL: time.Hour,
+	}
+	verifier := controllers.NewTokenVerifier(cfg, activeSessions{})
+	userID := uuid.New()
+
+	claims := func() usecases.AccessClaims {
+		now := time.Now()
+		return usecases.AccessClaims{
+			Role:      entities.UserRoleModerator,
+			SessionID: uuid.NewString(),
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    "pvz-service",
+				Subject:   userID.String(),
+				Audience:  jwt.ClaimStrings{"pvz-api"},
+				IssuedAt:  jwt.NewNumericDate(now),
+				ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+			},
+		}
+	}
+	sign := func(t *testing.T, method jwt.SigningMethod, kid string, key any) string {
+		token := jwt.NewWithClaims(method, claims())
+		token.Header["kid"] = kid
+		s, err := token.SignedString(key)
+		require.NoError(t, err)
+		return s
+	}
+
+	t.Run("TokenIssuer подписывает активным RS256-ключом", func(t *testing.T) {
+		// Arrange
+		issuer := usecases.NewTokenIssuer(activeSessionStore{}, cfg)
+		pair, err := issuer.StartSession(context.Background(), entities.User{ID: userID, Role: entities.UserRoleModerator})
+		require.NoError(t, err)
+
+		// Act
+		parsed, _, err := jwt.NewParser().ParseUnverified(pair.AccessToken, &usecases.AccessClaims{})
+		require.NoError(t, err)
+		user, _, verifyErr := verifier.Verify(context.Background(), pair.AccessToken)
+
+		// Assert
+		assert.Equal(t, "RS256", parsed.Method.Alg())
+		assert.Equal(t, "rs-1", parsed.Header["kid"])
+		require.NoError(t, verifyErr)
+		assert.Equal(t, userID, user.ID)
+	})
+
+	t.Run("принимаются токены старого EdDSA и HS256 ключей", func(t *testing.T) {
+		// Act
+		_, _, errEd := verifier.Verify(context.Background(), sign(t, jwt.SigningMethodEdDSA, "ed-0", edPriv))
+		_, _, errHS := verifier.Verify(context.Background(), sign(t, jwt.SigningMethodHS256, configs.DefaultJWTKeyID, []byte("secret")))
+
+		// Assert
+		require.NoError(t, errEd)
+		require.NoError(t, errHS)
+	})
+
+	t.Run("алгоритм токена должен совпадать с алгоритмом ключа", func(t *testing.T) {
+		cases := map[string]string{
+			"HS256 с открытым RSA-ключом как секретом": sign(t, jwt.SigningMethodHS256, "rs-1", rsaPubPEM),
+			"RS256 под kid HS256-ключа":                sign(t, jwt.SigningMethodRS256, configs.DefaultJWTKeyID, rsaKey),
+			"EdDSA под kid RS256-ключа":                sign(t, jwt.SigningMethodEdDSA, "rs-1", edPriv),
+		}
+		for name, token := range cases {
+			t.Run(name, func(t *testing.T) {
+				// Act
+				_, _, err := verifier.Verify(context.Background(), token)
+
+				// Assert
+				require.ErrorIs(t, err, usecases.ErrUnauthorized)
+			})
+		}
+	})
+}
+
+func TestSecuredRoutesAuthMiddleware_Permissions(t *testing.T) {
+	// Arrange: политика из конфига оставила модератору только чтение ПВЗ
+	cfg := &configs.Config{
+		JWTSecret: "secret", JWTIssuer: "pvz-service", JWTAudience: "pvz-api",
+		AccessTokenTTL: time.Minute, RefreshTokenTTL: time.Hour,
+	}
+	authz := usecases.NewAuthorizer(map[entities.UserRole][]entities.Permission{
+		entities.UserRoleModerator: {entities.PermPVZRead},
+	}, nil)
+	createUC, listUC := new(mockCreatePVZUC), new(mockListPVZsUC)
+	listUC.On("Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]entities.PVZWithReceptions{}, nil)
+	pvzCtrl := controllers.NewPVZController(createUC, listUC, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	controllers.RegisterRoutes(r, controllers.NewServer(nil, pvzCtrl, nil, nil, nil), controllers.NewTokenVerifier(cfg, activeSessions{}), authz)
+
+	pair, err := usecases.NewTokenIssuer(activeSessionStore{}, cfg).
+		StartSession(context.Background(), entities.User{ID: uuid.New(), Role: entities.UserRoleModerator})
+	require.NoError(t, err)
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+pair.AccessToken)
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("нет права — 403 до разбора тела и вызова usecase", func(t *testing.T) {
+		// Act
+		w := do(http.MethodPost, "/pvz", `not json`)
+
+		// Assert
+		require.Equal(t, http.StatusForbidden, w.Code)
+		var body api.Error
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, api.ErrorCodeForbidden, body.Code)
+		assert.Contains(t, body.Message, "pvz:create")
+		createUC.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("без токена — 401 раньше проверки параметров", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(http.MethodGet, "/pvz?page=abc", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		r.ServeHTTP(w, req)
+
+		// Assert
+		require.Equal(t, http.StatusUnauthorized, w.Code)
+		var body api.Error
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, api.ErrorCodeUnauthorized, body.Code)
+		listUC.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("право есть — запрос доходит до usecase", func(t *testing.T) {
+		// Act
+		w := do(http.MethodGet, "/pvz", "")
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		listUC.AssertNumberOfCalls(t, "Execute", 1)
+	})
+}
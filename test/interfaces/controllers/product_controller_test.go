@@ -0,0 +1,185 @@
+package controllers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/api"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/controllers"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockAddProductUC struct{ mock.Mock }
+
+func (m *mockAddProductUC) Execute(ctx context.Context, user entities.User, pvzID uuid.UUID, productType entities.ProductType, barcode string) (entities.Product, error) {
+	args := m.Called(ctx, user, pvzID, productType, barcode)
+	return args.Get(0).(entities.Product), args.Error(1)
+}
+
+type mockAddProductsBatchUC struct{ mock.Mock }
+
+func (m *mockAddProductsBatchUC) Execute(ctx context.Context, user entities.User, pvzID uuid.UUID, items []usecases.ProductBatchItem) ([]usecases.ProductBatchResult, error) {
+	args := m.Called(ctx, user, pvzID, items)
+	return args.Get(0).([]usecases.ProductBatchResult), args.Error(1)
+}
+
+type mockFindProductsByBarcodeUC struct{ mock.Mock }
+
+func (m *mockFindProductsByBarcodeUC) Execute(ctx context.Context, user entities.User, barcode string) ([]entities.ProductLocation, error) {
+	args := m.Called(ctx, user, barcode)
+	return args.Get(0).([]entities.ProductLocation), args.Error(1)
+}
+
+func TestProductController_Barcode(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	staff := entities.User{Role: entities.UserRolePVZStaff}
+	anyCtx := mock.MatchedBy(func(ctx context.Context) bool { return true })
+
+	setup := func(add *mockAddProductUC, find *mockFindProductsByBarcodeUC) *gin.Engine {
+		ctrl := controllers.NewProductController(add, find, nil)
+		r := gin.New()
+		r.Use(controllers.ErrorHandlerMiddleware())
+		r.Use(func(ctx *gin.Context) { ctx.Set("user", staff) })
+		r.POST("/products", ctrl.Add)
+		r.GET("/products", func(ctx *gin.Context) {
+			ctrl.FindByBarcode(ctx, api.GetProductsParams{Barcode: ctx.Query("barcode")})
+		})
+		return r
+	}
+
+	t.Run("POST: штрихкод передаётся в usecase", func(t *testing.T) {
+		// Arrange
+		add := new(mockAddProductUC)
+		r := setup(add, nil)
+		pvzID := uuid.New()
+		product := entities.Product{ID: uuid.New(), ReceptionID: uuid.New(), Type: entities.ProductShoes, Position: 1, Barcode: "4601234567893"}
+		add.On("Execute", anyCtx, staff, pvzID, entities.ProductShoes, "4601234567893").Return(product, nil)
+		body, _ := json.Marshal(map[string]string{"pvzId": pvzID.String(), "type": "обувь", "barcode": "4601234567893"})
+
+		// Act
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/products", bytes.NewReader(body)))
+
+		// Assert
+		require.Equal(t, http.StatusCreated, w.Code)
+		var resp api.Product
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.NotNil(t, resp.Barcode)
+		assert.Equal(t, "4601234567893", *resp.Barcode)
+		add.AssertExpectations(t)
+	})
+
+	t.Run("POST: повторный штрихкод — 409", func(t *testing.T) {
+		// Arrange
+		add := new(mockAddProductUC)
+		r := setup(add, nil)
+		pvzID := uuid.New()
+		add.On("Execute", anyCtx, staff, pvzID, entities.ProductShoes, "4601234567893").Return(entities.Product{}, usecases.ErrDuplicateBarcode)
+		body, _ := json.Marshal(map[string]string{"pvzId": pvzID.String(), "type": "обувь", "barcode": "4601234567893"})
+
+		// Act
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/products", bytes.NewReader(body)))
+
+		// Assert
+		require.Equal(t, http.StatusConflict, w.Code)
+		add.AssertExpectations(t)
+	})
+
+	t.Run("GET: ПВЗ и приёмка посылки", func(t *testing.T) {
+		// Arrange
+		find := new(mockFindProductsByBarcodeUC)
+		r := setup(nil, find)
+		loc := entities.ProductLocation{
+			Product:   entities.Product{ID: uuid.New(), Type: entities.ProductShoes, Barcode: "4601234567893"},
+			Reception: entities.Reception{ID: uuid.New(), Status: entities.ReceptionInProgress},
+			PVZ:       entities.PVZ{ID: uuid.New(), City: entities.CityKazan},
+		}
+		loc.Product.ReceptionID = loc.Reception.ID
+		loc.Reception.PVZID = loc.PVZ.ID
+		find.On("Execute", anyCtx, staff, "4601234567893").Return([]entities.ProductLocation{loc}, nil)
+
+		// Act
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/products?barcode=4601234567893", nil))
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp []api.ProductLocation
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Len(t, resp, 1)
+		assert.Equal(t, loc.PVZ.ID, *resp[0].Pvz.Id)
+		assert.Equal(t, loc.Reception.ID, *resp[0].Reception.Id)
+		find.AssertExpectations(t)
+	})
+}
+
+func TestProductController_AddBatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	staff := entities.User{Role: entities.UserRolePVZStaff}
+	anyCtx := mock.MatchedBy(func(ctx context.Context) bool { return true })
+
+	setup := func(batch *mockAddProductsBatchUC, body string) *httptest.ResponseRecorder {
+		ctrl := controllers.NewProductController(nil, nil, batch)
+		r := gin.New()
+		r.Use(controllers.ErrorHandlerMiddleware())
+		r.POST("/products/batch", func(ctx *gin.Context) {
+			ctx.Set("user", staff)
+			ctrl.AddBatch(ctx)
+		})
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/products/batch", bytes.NewBufferString(body)))
+		return w
+	}
+
+	t.Run("результаты по каждому товару и счётчики", func(t *testing.T) {
+		// Arrange
+		batch := new(mockAddProductsBatchUC)
+		pvzID := uuid.New()
+		product := entities.Product{ID: uuid.New(), ReceptionID: uuid.New(), Type: entities.ProductShoes, Position: 1, Barcode: "111"}
+		items := []usecases.ProductBatchItem{{Type: entities.ProductShoes, Barcode: "111"}, {Type: "еда"}}
+		batch.On("Execute", anyCtx, staff, pvzID, items).
+			Return([]usecases.ProductBatchResult{{Product: &product}, {Err: usecases.ErrInvalidProductType}}, nil)
+
+		// Act
+		w := setup(batch, `{"pvzId":"`+pvzID.String()+`","products":[{"type":"обувь","barcode":"111"},{"type":"еда"}]}`)
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp api.ProductBatchResult
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, 1, resp.Accepted)
+		assert.Equal(t, 1, resp.Rejected)
+		require.Len(t, resp.Results, 2)
+		assert.Equal(t, product.ID, *resp.Results[0].Product.Id)
+		assert.Nil(t, resp.Results[0].Error)
+		assert.Equal(t, 1, resp.Results[1].Index)
+		assert.Equal(t, usecases.ErrInvalidProductType.Error(), *resp.Results[1].Error)
+		batch.AssertExpectations(t)
+	})
+
+	t.Run("нет открытой приёмки — 400", func(t *testing.T) {
+		// Arrange
+		batch := new(mockAddProductsBatchUC)
+		pvzID := uuid.New()
+		batch.On("Execute", anyCtx, staff, pvzID, []usecases.ProductBatchItem{{Type: entities.ProductShoes}}).
+			Return([]usecases.ProductBatchResult(nil), usecases.ErrNoReceptionForProduct)
+
+		// Act
+		w := setup(batch, `{"pvzId":"`+pvzID.String()+`","products":[{"type":"обувь"}]}`)
+
+		// Assert
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		batch.AssertExpectations(t)
+	})
+}
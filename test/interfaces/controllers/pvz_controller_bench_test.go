@@ -0,0 +1,61 @@
+package controllers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/api"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/controllers"
+)
+
+// stubListPVZsUC отдаёт заранее собранную страницу без обращения к БД
+type stubListPVZsUC struct{ page []entities.PVZWithReceptions }
+
+func (s stubListPVZsUC) Execute(context.Context, entities.User, *time.Time, *time.Time, int, int, bool) ([]entities.PVZWithReceptions, error) {
+	return s.page, nil
+}
+
+// BenchmarkPVZController_List — накладные расходы HTTP-слоя (маппинг в DTO и JSON)
+// на полной странице 30 ПВЗ × 5 приёмок × 50 товаров, без учёта БД
+func BenchmarkPVZController_List(b *testing.B) {
+	gin.SetMode(gin.ReleaseMode)
+	now := time.Now().UTC()
+	page := make([]entities.PVZWithReceptions, 0, 30)
+	for i := 0; i < 30; i++ {
+		pvz := entities.PVZWithReceptions{PVZ: entities.PVZ{ID: uuid.New(), RegistrationDate: now, City: entities.CityMoscow}}
+		for j := 0; j < 5; j++ {
+			rec := entities.ReceptionWithProducts{Reception: entities.Reception{ID: uuid.New(), PVZID: pvz.PVZ.ID, Status: entities.ReceptionClosed, DateTime: now}}
+			for k := 0; k < 50; k++ {
+				rec.Products = append(rec.Products, entities.Product{ID: uuid.New(), ReceptionID: rec.Reception.ID, Type: entities.ProductShoes, DateTime: now})
+			}
+			pvz.Receptions = append(pvz.Receptions, rec)
+		}
+		page = append(page, pvz)
+	}
+	ctrl := controllers.NewPVZController(nil, stubListPVZsUC{page: page}, nil, nil, nil, nil, nil, nil, nil, nil)
+	r := gin.New()
+	r.Use(controllers.ErrorHandlerMiddleware())
+	r.GET("/pvz", func(ctx *gin.Context) {
+		ctx.Set("user", entities.User{Role: entities.UserRoleModerator})
+		ctrl.List(ctx, api.GetPvzParams{Limit: intPtr(30)})
+	})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pvz", nil))
+			if w.Code != http.StatusOK {
+				b.Errorf("unexpected status %d", w.Code)
+				return
+			}
+		}
+	})
+}
@@ -12,7 +12,9 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/api"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/controllers"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -20,24 +22,16 @@ import (
 
 type mockCreatePVZUC struct{ mock.Mock }
 
-func (m *mockCreatePVZUC) Execute(ctx context.Context, user entities.User, city entities.City) (entities.PVZ, error) {
-	args := m.Called(ctx, user, city)
+func (m *mockCreatePVZUC) Execute(ctx context.Context, user entities.User, city entities.City, details entities.PVZDetails) (entities.PVZ, error) {
+	args := m.Called(ctx, user, city, details)
 	return args.Get(0).(entities.PVZ), args.Error(1)
 }
 
 type mockListPVZsUC struct{ mock.Mock }
 
-func (m *mockListPVZsUC) Execute(ctx context.Context, user entities.User, start, end *time.Time, page, limit int) ([]entities.PVZ, error) {
-	args := m.Called(ctx, user, start, end, page, limit)
-	return args.Get(0).([]entities.PVZ), args.Error(1)
-}
-func (m *mockListPVZsUC) GetReceptionsByPVZ(ctx context.Context, pvzID uuid.UUID) ([]entities.Reception, error) {
-	args := m.Called(ctx, pvzID)
-	return args.Get(0).([]entities.Reception), args.Error(1)
-}
-func (m *mockListPVZsUC) GetProductsByReception(ctx context.Context, recID uuid.UUID) ([]entities.Product, error) {
-	args := m.Called(ctx, recID)
-	return args.Get(0).([]entities.Product), args.Error(1)
+func (m *mockListPVZsUC) Execute(ctx context.Context, user entities.User, start, end *time.Time, page, limit int, includeArchived bool) ([]entities.PVZWithReceptions, error) {
+	args := m.Called(ctx, user, start, end, page, limit, includeArchived)
+	return args.Get(0).([]entities.PVZWithReceptions), args.Error(1)
 }
 
 type mockCloseReceptionUC struct{ mock.Mock }
@@ -54,11 +48,54 @@ func (m *mockDeleteLastProductUC) Execute(ctx context.Context, user entities.Use
 	return args.Error(0)
 }
 
+type mockGetPVZUC struct{ mock.Mock }
+
+func (m *mockGetPVZUC) Execute(ctx context.Context, user entities.User, pvzID uuid.UUID) (entities.PVZ, error) {
+	args := m.Called(ctx, user, pvzID)
+	return args.Get(0).(entities.PVZ), args.Error(1)
+}
+
+type mockUpdatePVZUC struct{ mock.Mock }
+
+func (m *mockUpdatePVZUC) Execute(ctx context.Context, user entities.User, pvzID uuid.UUID, patch usecases.PVZPatch) (entities.PVZ, error) {
+	args := m.Called(ctx, user, pvzID, patch)
+	return args.Get(0).(entities.PVZ), args.Error(1)
+}
+
+type mockArchivePVZUC struct{ mock.Mock }
+
+func (m *mockArchivePVZUC) Execute(ctx context.Context, user entities.User, pvzID uuid.UUID, archived bool) (entities.PVZ, error) {
+	args := m.Called(ctx, user, pvzID, archived)
+	return args.Get(0).(entities.PVZ), args.Error(1)
+}
+
+type mockFindNearestPVZsUC struct{ mock.Mock }
+
+func (m *mockFindNearestPVZsUC) Execute(ctx context.Context, user entities.User, point entities.GeoPoint, radius float64, limit int) ([]entities.NearestPVZ, error) {
+	args := m.Called(ctx, user, point, radius, limit)
+	return args.Get(0).([]entities.NearestPVZ), args.Error(1)
+}
+
+type mockAssignStaffUC struct{ mock.Mock }
+
+func (m *mockAssignStaffUC) Execute(ctx context.Context, user entities.User, pvzID, staffID uuid.UUID, assigned bool) error {
+	args := m.Called(ctx, user, pvzID, staffID, assigned)
+	return args.Error(0)
+}
+
+type mockListPVZStaffUC struct{ mock.Mock }
+
+func (m *mockListPVZStaffUC) Execute(ctx context.Context, user entities.User, pvzID uuid.UUID) ([]entities.StaffAssignment, error) {
+	args := m.Called(ctx, user, pvzID)
+	return args.Get(0).([]entities.StaffAssignment), args.Error(1)
+}
+
 func TestPVZController_Create(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	uc := new(mockCreatePVZUC)
-	ctrl := controllers.NewPVZController(uc, nil, nil, nil)
+	ctrl := controllers.NewPVZController(uc, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	r := gin.New()
+	r.Use(controllers.ErrorHandlerMiddleware())
 	r.POST("/pvz", func(ctx *gin.Context) {
 		ctx.Set("user", entities.User{Role: entities.UserRoleModerator})
 		ctrl.Create(ctx)
@@ -67,17 +104,22 @@ func TestPVZController_Create(t *testing.T) {
 	t.Run("happy path", func(t *testing.T) {
 		city := entities.City("Москва")
 		user := entities.User{Role: entities.UserRoleModerator}
-		pvz := entities.PVZ{ID: uuid.New(), City: city}
-		uc.On("Execute", mock.MatchedBy(func(ctx context.Context) bool { return true }), user, city).Return(pvz, nil)
-		body := `{"city":"Москва"}`
+		details := entities.PVZDetails{Address: "ул. Тверская, 7", Location: &entities.GeoPoint{Lat: 55.7575, Lon: 37.6134}}
+		pvz := entities.PVZ{ID: uuid.New(), City: city, PVZDetails: details}
+		uc.On("Execute", mock.MatchedBy(func(ctx context.Context) bool { return true }), user, city, details).Return(pvz, nil)
+		body := `{"city":"Москва","address":"ул. Тверская, 7","location":{"lat":55.7575,"lon":37.6134}}`
 		req := httptest.NewRequest(http.MethodPost, "/pvz", bytes.NewBufferString(body))
 		req.Header.Set("Content-Type", "application/json")
 		w := httptest.NewRecorder()
 		r.ServeHTTP(w, req)
 		require.Equal(t, 201, w.Code)
-		var resp entities.PVZ
+		var resp api.PVZ
 		_ = json.Unmarshal(w.Body.Bytes(), &resp)
-		require.Equal(t, city, resp.City)
+		require.Equal(t, string(city), resp.City)
+		require.Equal(t, pvz.ID, *resp.Id)
+		require.Equal(t, details.Address, *resp.Address)
+		require.Equal(t, api.GeoPoint{Lat: 55.7575, Lon: 37.6134}, *resp.Location)
+		require.Nil(t, resp.Name)
 		uc.AssertExpectations(t)
 	})
 
@@ -85,13 +127,14 @@ func TestPVZController_Create(t *testing.T) {
 		city := entities.City("Москва")
 		user := entities.User{Role: entities.UserRoleModerator}
 		uc := new(mockCreatePVZUC)
-		ctrl := controllers.NewPVZController(uc, nil, nil, nil)
+		ctrl := controllers.NewPVZController(uc, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 		r := gin.New()
+		r.Use(controllers.ErrorHandlerMiddleware())
 		r.POST("/pvz", func(ctx *gin.Context) {
 			ctx.Set("user", user)
 			ctrl.Create(ctx)
 		})
-		uc.On("Execute", mock.MatchedBy(func(ctx context.Context) bool { return true }), user, city).Return(entities.PVZ{}, assert.AnError)
+		uc.On("Execute", mock.MatchedBy(func(ctx context.Context) bool { return true }), user, city, entities.PVZDetails{}).Return(entities.PVZ{}, usecases.NewError(usecases.ErrForbidden, "forbidden"))
 		body := `{"city":"Москва"}`
 		req := httptest.NewRequest(http.MethodPost, "/pvz", bytes.NewBufferString(body))
 		req.Header.Set("Content-Type", "application/json")
@@ -103,43 +146,95 @@ func TestPVZController_Create(t *testing.T) {
 
 func TestPVZController_List(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	uc := new(mockListPVZsUC)
-	ctrl := controllers.NewPVZController(nil, uc, nil, nil)
-	r := gin.New()
-	r.GET("/pvz", func(ctx *gin.Context) {
-		ctx.Set("user", entities.User{Role: entities.UserRoleModerator})
-		ctrl.List(ctx)
+	setup := func(uc *mockListPVZsUC) *gin.Engine {
+		ctrl := controllers.NewPVZController(nil, uc, nil, nil, nil, nil, nil, nil, nil, nil)
+		r := gin.New()
+		r.Use(controllers.ErrorHandlerMiddleware())
+		r.GET("/pvz", func(ctx *gin.Context) {
+			ctx.Set("user", entities.User{Role: entities.UserRoleModerator})
+			ctrl.List(ctx, api.GetPvzParams{})
+		})
+		return r
+	}
+	user := entities.User{Role: entities.UserRoleModerator}
+
+	t.Run("happy path: ПВЗ с приёмками и товарами", func(t *testing.T) {
+		// Arrange
+		uc := new(mockListPVZsUC)
+		r := setup(uc)
+		pvzID := uuid.New()
+		recID := uuid.New()
+		productID := uuid.New()
+		list := []entities.PVZWithReceptions{{
+			PVZ: entities.PVZ{ID: pvzID, City: "Москва"},
+			Receptions: []entities.ReceptionWithProducts{{
+				Reception: entities.Reception{ID: recID, PVZID: pvzID, Status: entities.ReceptionInProgress},
+				Products:  []entities.Product{{ID: productID, ReceptionID: recID, Type: entities.ProductShoes}},
+			}},
+		}}
+		uc.On("Execute", mock.MatchedBy(func(ctx context.Context) bool { return true }), user, mock.Anything, mock.Anything, 1, 10, false).Return(list, nil)
+
+		// Act
+		req := httptest.NewRequest(http.MethodGet, "/pvz", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		// Assert
+		require.Equal(t, 200, w.Code)
+		var resp []api.PVZWithReceptions
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Len(t, resp, 1)
+		assert.Equal(t, pvzID, *resp[0].Pvz.Id)
+		require.Len(t, resp[0].Receptions, 1)
+		assert.Equal(t, recID, *resp[0].Receptions[0].Reception.Id)
+		require.Len(t, resp[0].Receptions[0].Products, 1)
+		assert.Equal(t, productID, *resp[0].Receptions[0].Products[0].Id)
+		uc.AssertExpectations(t)
 	})
 
-	user := entities.User{Role: entities.UserRoleModerator}
-	pvzID := uuid.New()
-	pvz := entities.PVZ{ID: pvzID, City: "Москва"}
-	recID := uuid.New()
-	rec := entities.Reception{ID: recID}
-	product := entities.Product{ID: uuid.New()}
-	uc.On("Execute", mock.MatchedBy(func(ctx context.Context) bool { return true }), user, mock.Anything, mock.Anything, 1, 10).Return([]entities.PVZ{pvz}, nil)
-	uc.On("GetReceptionsByPVZ", mock.MatchedBy(func(ctx context.Context) bool { return true }), pvzID).Return([]entities.Reception{rec}, nil)
-	uc.On("GetProductsByReception", mock.MatchedBy(func(ctx context.Context) bool { return true }), recID).Return([]entities.Product{product}, nil)
-
-	req := httptest.NewRequest(http.MethodGet, "/pvz", nil)
-	w := httptest.NewRecorder()
-	r.ServeHTTP(w, req)
-	require.Equal(t, 200, w.Code)
-	var resp []map[string]interface{}
-	_ = json.Unmarshal(w.Body.Bytes(), &resp)
-	assert.Len(t, resp, 1)
-	uc.AssertExpectations(t)
+	t.Run("ПВЗ без приёмок — пустые массивы, а не null", func(t *testing.T) {
+		// Arrange
+		uc := new(mockListPVZsUC)
+		r := setup(uc)
+		list := []entities.PVZWithReceptions{{PVZ: entities.PVZ{ID: uuid.New(), City: "Москва"}}}
+		uc.On("Execute", mock.Anything, user, mock.Anything, mock.Anything, 1, 10, false).Return(list, nil)
+
+		// Act
+		req := httptest.NewRequest(http.MethodGet, "/pvz", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		// Assert
+		require.Equal(t, 200, w.Code)
+		assert.Contains(t, w.Body.String(), `"receptions":[]`)
+	})
+
+	t.Run("ошибка чтения — 500, а не молча пустой список", func(t *testing.T) {
+		// Arrange
+		uc := new(mockListPVZsUC)
+		r := setup(uc)
+		uc.On("Execute", mock.Anything, user, mock.Anything, mock.Anything, 1, 10, false).Return([]entities.PVZWithReceptions(nil), assert.AnError)
+
+		// Act
+		req := httptest.NewRequest(http.MethodGet, "/pvz", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		// Assert
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+	})
 }
 
 func TestPVZController_CloseLastReception(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	t.Run("happy path", func(t *testing.T) {
 		uc := new(mockCloseReceptionUC)
-		ctrl := controllers.NewPVZController(nil, nil, uc, nil)
+		ctrl := controllers.NewPVZController(nil, nil, uc, nil, nil, nil, nil, nil, nil, nil)
 		r := gin.New()
+		r.Use(controllers.ErrorHandlerMiddleware())
 		r.POST("/pvz/:pvzId/close_last_reception", func(ctx *gin.Context) {
 			ctx.Set("user", entities.User{Role: entities.UserRolePVZStaff})
-			ctrl.CloseLastReception(ctx)
+			ctrl.CloseLastReception(ctx, uuid.MustParse(ctx.Param("pvzId")))
 		})
 		user := entities.User{Role: entities.UserRolePVZStaff}
 		pvzID := uuid.New()
@@ -150,23 +245,24 @@ func TestPVZController_CloseLastReception(t *testing.T) {
 		w := httptest.NewRecorder()
 		r.ServeHTTP(w, req)
 		require.Equal(t, 200, w.Code)
-		var resp entities.Reception
+		var resp api.Reception
 		_ = json.Unmarshal(w.Body.Bytes(), &resp)
-		require.Equal(t, rec.ID, resp.ID)
+		require.Equal(t, rec.ID, *resp.Id)
 		uc.AssertExpectations(t)
 	})
 
 	t.Run("ошибка usecase", func(t *testing.T) {
 		uc := new(mockCloseReceptionUC)
-		ctrl := controllers.NewPVZController(nil, nil, uc, nil)
+		ctrl := controllers.NewPVZController(nil, nil, uc, nil, nil, nil, nil, nil, nil, nil)
 		r := gin.New()
+		r.Use(controllers.ErrorHandlerMiddleware())
 		r.POST("/pvz/:pvzId/close_last_reception", func(ctx *gin.Context) {
 			ctx.Set("user", entities.User{Role: entities.UserRolePVZStaff})
-			ctrl.CloseLastReception(ctx)
+			ctrl.CloseLastReception(ctx, uuid.MustParse(ctx.Param("pvzId")))
 		})
 		user := entities.User{Role: entities.UserRolePVZStaff}
 		pvzID := uuid.New()
-		uc.On("Execute", mock.MatchedBy(func(ctx context.Context) bool { return true }), user, pvzID).Return(entities.Reception{}, assert.AnError)
+		uc.On("Execute", mock.MatchedBy(func(ctx context.Context) bool { return true }), user, pvzID).Return(entities.Reception{}, usecases.ErrNoReceptionToClose)
 		url := "/pvz/" + pvzID.String() + "/close_last_reception"
 		req := httptest.NewRequest(http.MethodPost, url, nil)
 		w := httptest.NewRecorder()
@@ -179,11 +275,12 @@ func TestPVZController_DeleteLastProduct(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	t.Run("happy path", func(t *testing.T) {
 		uc := new(mockDeleteLastProductUC)
-		ctrl := controllers.NewPVZController(nil, nil, nil, uc)
+		ctrl := controllers.NewPVZController(nil, nil, nil, uc, nil, nil, nil, nil, nil, nil)
 		r := gin.New()
+		r.Use(controllers.ErrorHandlerMiddleware())
 		r.POST("/pvz/:pvzId/delete_last_product", func(ctx *gin.Context) {
 			ctx.Set("user", entities.User{Role: entities.UserRolePVZStaff})
-			ctrl.DeleteLastProduct(ctx)
+			ctrl.DeleteLastProduct(ctx, uuid.MustParse(ctx.Param("pvzId")))
 		})
 		user := entities.User{Role: entities.UserRolePVZStaff}
 		pvzID := uuid.New()
@@ -201,15 +298,16 @@ func TestPVZController_DeleteLastProduct(t *testing.T) {
 
 	t.Run("ошибка usecase", func(t *testing.T) {
 		uc := new(mockDeleteLastProductUC)
-		ctrl := controllers.NewPVZController(nil, nil, nil, uc)
+		ctrl := controllers.NewPVZController(nil, nil, nil, uc, nil, nil, nil, nil, nil, nil)
 		r := gin.New()
+		r.Use(controllers.ErrorHandlerMiddleware())
 		r.POST("/pvz/:pvzId/delete_last_product", func(ctx *gin.Context) {
 			ctx.Set("user", entities.User{Role: entities.UserRolePVZStaff})
-			ctrl.DeleteLastProduct(ctx)
+			ctrl.DeleteLastProduct(ctx, uuid.MustParse(ctx.Param("pvzId")))
 		})
 		user := entities.User{Role: entities.UserRolePVZStaff}
 		pvzID := uuid.New()
-		uc.On("Execute", mock.MatchedBy(func(ctx context.Context) bool { return true }), user, pvzID).Return(assert.AnError)
+		uc.On("Execute", mock.MatchedBy(func(ctx context.Context) bool { return true }), user, pvzID).Return(usecases.ErrNoReceptionForDelete)
 		url := "/pvz/" + pvzID.String() + "/delete_last_product"
 		req := httptest.NewRequest(http.MethodPost, url, nil)
 		w := httptest.NewRecorder()
@@ -217,3 +315,228 @@ func TestPVZController_DeleteLastProduct(t *testing.T) {
 		require.Equal(t, 400, w.Code)
 	})
 }
+
+func TestPVZController_List_Pagination(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cases := map[string]api.GetPvzParams{
+		"page меньше 1":   {Page: intPtr(0)},
+		"limit больше 30": {Limit: intPtr(31)},
+		"limit меньше 1":  {Limit: intPtr(0)},
+	}
+	for name, params := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			uc := new(mockListPVZsUC)
+			ctrl := controllers.NewPVZController(nil, uc, nil, nil, nil, nil, nil, nil, nil, nil)
+			r := gin.New()
+			r.Use(controllers.ErrorHandlerMiddleware())
+			r.GET("/pvz", func(ctx *gin.Context) {
+				ctx.Set("user", entities.User{Role: entities.UserRoleModerator})
+				ctrl.List(ctx, params)
+			})
+
+			// Act
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pvz", nil))
+
+			// Assert
+			require.Equal(t, 400, w.Code)
+			uc.AssertNotCalled(t, "Execute")
+		})
+	}
+}
+
+func TestPVZController_GetUpdateArchive(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	moderator := entities.User{Role: entities.UserRoleModerator}
+	anyCtx := mock.MatchedBy(func(ctx context.Context) bool { return true })
+
+	setup := func(get *mockGetPVZUC, update *mockUpdatePVZUC, archive *mockArchivePVZUC) *gin.Engine {
+		ctrl := controllers.NewPVZController(nil, nil, nil, nil, get, update, archive, nil, nil, nil)
+		r := gin.New()
+		r.Use(controllers.ErrorHandlerMiddleware())
+		r.Use(func(ctx *gin.Context) { ctx.Set("user", moderator) })
+		r.GET("/pvz/:pvzId", func(ctx *gin.Context) { ctrl.Get(ctx, uuid.MustParse(ctx.Param("pvzId"))) })
+		r.PATCH("/pvz/:pvzId", func(ctx *gin.Context) { ctrl.Update(ctx, uuid.MustParse(ctx.Param("pvzId"))) })
+		r.POST("/pvz/:pvzId/archive", func(ctx *gin.Context) { ctrl.SetArchived(ctx, uuid.MustParse(ctx.Param("pvzId")), true) })
+		return r
+	}
+
+	t.Run("GET: ПВЗ не найден — 404", func(t *testing.T) {
+		// Arrange
+		get := new(mockGetPVZUC)
+		r := setup(get, nil, nil)
+		pvzID := uuid.New()
+		get.On("Execute", anyCtx, moderator, pvzID).Return(entities.PVZ{}, usecases.ErrPVZNotFound)
+
+		// Act
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pvz/"+pvzID.String(), nil))
+
+		// Assert
+		require.Equal(t, http.StatusNotFound, w.Code)
+		get.AssertExpectations(t)
+	})
+
+	t.Run("PATCH: город передаётся в usecase", func(t *testing.T) {
+		// Arrange
+		update := new(mockUpdatePVZUC)
+		r := setup(nil, update, nil)
+		pvzID := uuid.New()
+		city := entities.CityKazan
+		update.On("Execute", anyCtx, moderator, pvzID, usecases.PVZPatch{City: &city}).Return(entities.PVZ{ID: pvzID, City: city}, nil)
+
+		// Act
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPatch, "/pvz/"+pvzID.String(), bytes.NewBufferString(`{"city":"Казань"}`)))
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp api.PVZ
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "Казань", resp.City)
+		update.AssertExpectations(t)
+	})
+
+	t.Run("archive: открытая приёмка — 409", func(t *testing.T) {
+		// Arrange
+		archive := new(mockArchivePVZUC)
+		r := setup(nil, nil, archive)
+		pvzID := uuid.New()
+		archive.On("Execute", anyCtx, moderator, pvzID, true).Return(entities.PVZ{}, usecases.ErrPVZHasOpenReception)
+
+		// Act
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/pvz/"+pvzID.String()+"/archive", nil))
+
+		// Assert
+		require.Equal(t, http.StatusConflict, w.Code)
+		archive.AssertExpectations(t)
+	})
+}
+
+func TestPVZController_Nearest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	client := entities.User{Role: entities.UserRoleClient}
+	anyCtx := mock.MatchedBy(func(ctx context.Context) bool { return true })
+	point := entities.GeoPoint{Lat: 55.7558, Lon: 37.6173}
+
+	setup := func(uc *mockFindNearestPVZsUC, params api.GetPvzNearestParams) *httptest.ResponseRecorder {
+		ctrl := controllers.NewPVZController(nil, nil, nil, nil, nil, nil, nil, uc, nil, nil)
+		r := gin.New()
+		r.Use(controllers.ErrorHandlerMiddleware())
+		r.GET("/pvz/nearest", func(ctx *gin.Context) {
+			ctx.Set("user", client)
+			ctrl.Nearest(ctx, params)
+		})
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pvz/nearest", nil))
+		return w
+	}
+
+	t.Run("радиус и limit по умолчанию, пустой результат — пустой массив", func(t *testing.T) {
+		// Arrange
+		uc := new(mockFindNearestPVZsUC)
+		uc.On("Execute", anyCtx, client, point, 5000.0, 10).Return([]entities.NearestPVZ(nil), nil)
+
+		// Act
+		w := setup(uc, api.GetPvzNearestParams{Lat: point.Lat, Lon: point.Lon})
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		require.JSONEq(t, `[]`, w.Body.String())
+		uc.AssertExpectations(t)
+	})
+
+	t.Run("limit вне диапазона — 400 без вызова usecase", func(t *testing.T) {
+		// Arrange
+		uc := new(mockFindNearestPVZsUC)
+
+		// Act
+		w := setup(uc, api.GetPvzNearestParams{Lat: point.Lat, Lon: point.Lon, Limit: intPtr(31)})
+
+		// Assert
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		uc.AssertNotCalled(t, "Execute")
+	})
+}
+
+func intPtr(v int) *int { return &v }
+
+func TestPVZController_Staff(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	moderator := entities.User{ID: uuid.New(), Role: entities.UserRoleModerator}
+	anyCtx := mock.MatchedBy(func(ctx context.Context) bool { return true })
+
+	setup := func(assign *mockAssignStaffUC, list *mockListPVZStaffUC) *gin.Engine {
+		ctrl := controllers.NewPVZController(nil, nil, nil, nil, nil, nil, nil, nil, assign, list)
+		r := gin.New()
+		r.Use(controllers.ErrorHandlerMiddleware())
+		r.Use(func(ctx *gin.Context) { ctx.Set("user", moderator) })
+		r.GET("/pvz/:pvzId/staff", func(ctx *gin.Context) { ctrl.ListStaff(ctx, uuid.MustParse(ctx.Param("pvzId"))) })
+		r.PUT("/pvz/:pvzId/staff/:userId", func(ctx *gin.Context) {
+			ctrl.AssignStaff(ctx, uuid.MustParse(ctx.Param("pvzId")), uuid.MustParse(ctx.Param("userId")), true)
+		})
+		r.DELETE("/pvz/:pvzId/staff/:userId", func(ctx *gin.Context) {
+			ctrl.AssignStaff(ctx, uuid.MustParse(ctx.Param("pvzId")), uuid.MustParse(ctx.Param("userId")), false)
+		})
+		return r
+	}
+
+	t.Run("назначение — 204", func(t *testing.T) {
+		// Arrange
+		assign := new(mockAssignStaffUC)
+		r := setup(assign, nil)
+		pvzID, staffID := uuid.New(), uuid.New()
+		assign.On("Execute", anyCtx, moderator, pvzID, staffID, true).Return(nil)
+
+		// Act
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/pvz/"+pvzID.String()+"/staff/"+staffID.String(), nil))
+
+		// Assert
+		require.Equal(t, http.StatusNoContent, w.Code)
+		assert.Empty(t, w.Body.String())
+		assign.AssertExpectations(t)
+	})
+
+	t.Run("снятие неназначенного — 404", func(t *testing.T) {
+		// Arrange
+		assign := new(mockAssignStaffUC)
+		r := setup(assign, nil)
+		pvzID, staffID := uuid.New(), uuid.New()
+		assign.On("Execute", anyCtx, moderator, pvzID, staffID, false).Return(usecases.ErrStaffNotAssigned)
+
+		// Act
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/pvz/"+pvzID.String()+"/staff/"+staffID.String(), nil))
+
+		// Assert
+		require.Equal(t, http.StatusNotFound, w.Code)
+		assign.AssertExpectations(t)
+	})
+
+	t.Run("список назначений", func(t *testing.T) {
+		// Arrange
+		list := new(mockListPVZStaffUC)
+		r := setup(nil, list)
+		pvzID := uuid.New()
+		assignedAt := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+		staff := []entities.StaffAssignment{{PVZID: pvzID, UserID: uuid.New(), AssignedAt: assignedAt, AssignedBy: moderator.ID}}
+		list.On("Execute", anyCtx, moderator, pvzID).Return(staff, nil)
+
+		// Act
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pvz/"+pvzID.String()+"/staff", nil))
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp []api.StaffAssignment
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Len(t, resp, 1)
+		assert.Equal(t, staff[0].UserID, resp[0].UserId)
+		assert.Equal(t, moderator.ID, resp[0].AssignedBy)
+		assert.True(t, assignedAt.Equal(resp[0].AssignedAt))
+		list.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,347 @@
+package controllers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/api"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/controllers"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockGetReceptionUC struct{ mock.Mock }
+
+func (m *mockGetReceptionUC) Execute(ctx context.Context, user entities.User, receptionID uuid.UUID) (entities.ReceptionWithProducts, error) {
+	args := m.Called(ctx, user, receptionID)
+	return args.Get(0).(entities.ReceptionWithProducts), args.Error(1)
+}
+
+type mockListReceptionsUC struct{ mock.Mock }
+
+func (m *mockListReceptionsUC) Execute(ctx context.Context, user entities.User, pvzID uuid.UUID, filter usecases.ReceptionFilter) ([]entities.Reception, error) {
+	args := m.Called(ctx, user, pvzID, filter)
+	return args.Get(0).([]entities.Reception), args.Error(1)
+}
+
+type mockDeleteProductUC struct{ mock.Mock }
+
+func (m *mockDeleteProductUC) Execute(ctx context.Context, user entities.User, receptionID, productID uuid.UUID) (entities.ProductRemoval, error) {
+	args := m.Called(ctx, user, receptionID, productID)
+	return args.Get(0).(entities.ProductRemoval), args.Error(1)
+}
+
+type mockReopenReceptionUC struct{ mock.Mock }
+
+func (m *mockReopenReceptionUC) Execute(ctx context.Context, user entities.User, receptionID uuid.UUID, reason string) (entities.Reception, error) {
+	args := m.Called(ctx, user, receptionID, reason)
+	return args.Get(0).(entities.Reception), args.Error(1)
+}
+
+type mockListTransitionsUC struct{ mock.Mock }
+
+func (m *mockListTransitionsUC) Execute(ctx context.Context, user entities.User, receptionID uuid.UUID) ([]entities.ReceptionTransition, error) {
+	args := m.Called(ctx, user, receptionID)
+	return args.Get(0).([]entities.ReceptionTransition), args.Error(1)
+}
+
+type mockChangeStatusUC struct{ mock.Mock }
+
+func (m *mockChangeStatusUC) Execute(ctx context.Context, user entities.User, receptionID uuid.UUID, to entities.ReceptionStatus, reason string) (entities.Reception, error) {
+	args := m.Called(ctx, user, receptionID, to, reason)
+	return args.Get(0).(entities.Reception), args.Error(1)
+}
+
+func TestReceptionController_GetAndList(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	staff := entities.User{Role: entities.UserRolePVZStaff}
+	anyCtx := mock.MatchedBy(func(ctx context.Context) bool { return true })
+
+	setup := func(get *mockGetReceptionUC, list *mockListReceptionsUC, params api.GetPvzPvzIdReceptionsParams) *gin.Engine {
+		ctrl := controllers.NewReceptionController(nil, get, list, nil, nil, nil, nil)
+		r := gin.New()
+		r.Use(controllers.ErrorHandlerMiddleware())
+		r.Use(func(ctx *gin.Context) { ctx.Set("user", staff) })
+		r.GET("/receptions/:receptionId", func(ctx *gin.Context) { ctrl.Get(ctx, uuid.MustParse(ctx.Param("receptionId"))) })
+		r.GET("/pvz/:pvzId/receptions", func(ctx *gin.Context) { ctrl.ListByPVZ(ctx, uuid.MustParse(ctx.Param("pvzId")), params) })
+		return r
+	}
+
+	t.Run("GET: приёмка с товарами", func(t *testing.T) {
+		// Arrange
+		get := new(mockGetReceptionUC)
+		r := setup(get, nil, api.GetPvzPvzIdReceptionsParams{})
+		rec := entities.Reception{ID: uuid.New(), PVZID: uuid.New(), Status: entities.ReceptionClosed, DateTime: time.Now().UTC()}
+		product := entities.Product{ID: uuid.New(), ReceptionID: rec.ID, Type: entities.ProductShoes, DateTime: time.Now().UTC()}
+		get.On("Execute", anyCtx, staff, rec.ID).Return(entities.ReceptionWithProducts{Reception: rec, Products: []entities.Product{product}}, nil)
+
+		// Act
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/receptions/"+rec.ID.String(), nil))
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp api.ReceptionWithProducts
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, rec.ID, *resp.Reception.Id)
+		require.Len(t, resp.Products, 1)
+		assert.Equal(t, product.ID, *resp.Products[0].Id)
+		get.AssertExpectations(t)
+	})
+
+	t.Run("GET: приёмка не найдена — 404", func(t *testing.T) {
+		// Arrange
+		get := new(mockGetReceptionUC)
+		r := setup(get, nil, api.GetPvzPvzIdReceptionsParams{})
+		id := uuid.New()
+		get.On("Execute", anyCtx, staff, id).Return(entities.ReceptionWithProducts{}, usecases.ErrReceptionNotFound)
+
+		// Act
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/receptions/"+id.String(), nil))
+
+		// Assert
+		require.Equal(t, http.StatusNotFound, w.Code)
+		get.AssertExpectations(t)
+	})
+
+	t.Run("история: фильтры и пагинация по умолчанию, пустой результат — пустой массив", func(t *testing.T) {
+		// Arrange
+		list := new(mockListReceptionsUC)
+		pvzID := uuid.New()
+		start := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+		status := api.GetPvzPvzIdReceptionsParamsStatus("close")
+		r := setup(nil, list, api.GetPvzPvzIdReceptionsParams{Status: &status, StartDate: &start})
+		closed := entities.ReceptionClosed
+		list.On("Execute", anyCtx, staff, pvzID, usecases.ReceptionFilter{Status: &closed, StartDate: &start, Page: 1, Limit: 10}).
+			Return([]entities.Reception(nil), nil)
+
+		// Act
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pvz/"+pvzID.String()+"/receptions", nil))
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		require.JSONEq(t, `[]`, w.Body.String())
+		list.AssertExpectations(t)
+	})
+
+	t.Run("история: limit вне диапазона — 400 без вызова usecase", func(t *testing.T) {
+		// Arrange
+		list := new(mockListReceptionsUC)
+		r := setup(nil, list, api.GetPvzPvzIdReceptionsParams{Limit: intPtr(31)})
+
+		// Act
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pvz/"+uuid.NewString()+"/receptions", nil))
+
+		// Assert
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		list.AssertNotCalled(t, "Execute")
+	})
+}
+
+func TestReceptionController_DeleteProduct(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	staff := entities.User{ID: uuid.New(), Role: entities.UserRolePVZStaff}
+	anyCtx := mock.MatchedBy(func(ctx context.Context) bool { return true })
+
+	setup := func(del *mockDeleteProductUC) *gin.Engine {
+		ctrl := controllers.NewReceptionController(nil, nil, nil, del, nil, nil, nil)
+		r := gin.New()
+		r.Use(controllers.ErrorHandlerMiddleware())
+		r.Use(func(ctx *gin.Context) { ctx.Set("user", staff) })
+		r.DELETE("/receptions/:receptionId/products/:productId", func(ctx *gin.Context) {
+			ctrl.DeleteProduct(ctx, uuid.MustParse(ctx.Param("receptionId")), uuid.MustParse(ctx.Param("productId")))
+		})
+		return r
+	}
+
+	t.Run("товар удалён — в ответе товар и кто удалил", func(t *testing.T) {
+		// Arrange
+		del := new(mockDeleteProductUC)
+		r := setup(del)
+		product := entities.Product{ID: uuid.New(), ReceptionID: uuid.New(), Type: entities.ProductShoes, Position: 2, DateTime: time.Now().UTC()}
+		removal := entities.ProductRemoval{ID: uuid.New(), Product: product, RemovedBy: staff.ID, RemovedAt: time.Now().UTC()}
+		del.On("Execute", anyCtx, staff, product.ReceptionID, product.ID).Return(removal, nil)
+
+		// Act
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/receptions/"+product.ReceptionID.String()+"/products/"+product.ID.String(), nil))
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp api.ProductRemoval
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, product.ID, *resp.Product.Id)
+		assert.Equal(t, staff.ID, resp.RemovedBy)
+		del.AssertExpectations(t)
+	})
+
+	t.Run("приёмка закрыта — 400", func(t *testing.T) {
+		// Arrange
+		del := new(mockDeleteProductUC)
+		r := setup(del)
+		receptionID, productID := uuid.New(), uuid.New()
+		del.On("Execute", anyCtx, staff, receptionID, productID).Return(entities.ProductRemoval{}, usecases.ErrReceptionClosed)
+
+		// Act
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/receptions/"+receptionID.String()+"/products/"+productID.String(), nil))
+
+		// Assert
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		del.AssertExpectations(t)
+	})
+}
+
+func TestReceptionController_ReopenAndTransitions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	moderator := entities.User{ID: uuid.New(), Role: entities.UserRoleModerator}
+	anyCtx := mock.MatchedBy(func(ctx context.Context) bool { return true })
+
+	setup := func(reopen *mockReopenReceptionUC, history *mockListTransitionsUC) *gin.Engine {
+		ctrl := controllers.NewReceptionController(nil, nil, nil, nil, reopen, history, nil)
+		r := gin.New()
+		r.Use(controllers.ErrorHandlerMiddleware())
+		r.Use(func(ctx *gin.Context) { ctx.Set("user", moderator) })
+		r.POST("/receptions/:receptionId/reopen", func(ctx *gin.Context) { ctrl.Reopen(ctx, uuid.MustParse(ctx.Param("receptionId"))) })
+		r.GET("/receptions/:receptionId/transitions", func(ctx *gin.Context) { ctrl.Transitions(ctx, uuid.MustParse(ctx.Param("receptionId"))) })
+		return r
+	}
+
+	t.Run("переоткрытие: причина передаётся в usecase", func(t *testing.T) {
+		// Arrange
+		reopen := new(mockReopenReceptionUC)
+		r := setup(reopen, nil)
+		rec := entities.Reception{ID: uuid.New(), PVZID: uuid.New(), Status: entities.ReceptionInProgress, DateTime: time.Now().UTC()}
+		reopen.On("Execute", anyCtx, moderator, rec.ID, "машина ещё разгружается").Return(rec, nil)
+
+		// Act
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/receptions/"+rec.ID.String()+"/reopen", strings.NewReader(`{"reason":"машина ещё разгружается"}`)))
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp api.Reception
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, api.ReceptionStatusInProgress, resp.Status)
+		reopen.AssertExpectations(t)
+	})
+
+	t.Run("переоткрытие: окно истекло — 409, тело без reason — 400", func(t *testing.T) {
+		// Arrange
+		reopen := new(mockReopenReceptionUC)
+		r := setup(reopen, nil)
+		id := uuid.New()
+		reopen.On("Execute", anyCtx, moderator, id, "поздно").Return(entities.Reception{}, usecases.ErrReopenWindowExpired)
+
+		// Act
+		expired := httptest.NewRecorder()
+		r.ServeHTTP(expired, httptest.NewRequest(http.MethodPost, "/receptions/"+id.String()+"/reopen", strings.NewReader(`{"reason":"поздно"}`)))
+		broken := httptest.NewRecorder()
+		r.ServeHTTP(broken, httptest.NewRequest(http.MethodPost, "/receptions/"+id.String()+"/reopen", strings.NewReader(`{`)))
+
+		// Assert
+		require.Equal(t, http.StatusConflict, expired.Code)
+		require.Equal(t, http.StatusBadRequest, broken.Code)
+		reopen.AssertExpectations(t)
+	})
+
+	t.Run("история статусов", func(t *testing.T) {
+		// Arrange
+		history := new(mockListTransitionsUC)
+		r := setup(nil, history)
+		id := uuid.New()
+		history.On("Execute", anyCtx, moderator, id).Return([]entities.ReceptionTransition{
+			{ReceptionID: id, From: entities.ReceptionInProgress, To: entities.ReceptionClosed, ChangedBy: uuid.New(), ChangedAt: time.Now().UTC()},
+			{ReceptionID: id, From: entities.ReceptionClosed, To: entities.ReceptionInProgress, ChangedBy: moderator.ID, Reason: "причина", ChangedAt: time.Now().UTC()},
+		}, nil)
+
+		// Act
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/receptions/"+id.String()+"/transitions", nil))
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp []api.ReceptionTransition
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Len(t, resp, 2)
+		assert.Nil(t, resp[0].Reason)
+		require.NotNil(t, resp[1].Reason)
+		assert.Equal(t, "причина", *resp[1].Reason)
+		history.AssertExpectations(t)
+	})
+}
+
+func TestReceptionController_ChangeStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	staff := entities.User{ID: uuid.New(), Role: entities.UserRolePVZStaff}
+	anyCtx := mock.MatchedBy(func(ctx context.Context) bool { return true })
+
+	setup := func(status *mockChangeStatusUC) *gin.Engine {
+		ctrl := controllers.NewReceptionController(nil, nil, nil, nil, nil, nil, status)
+		r := gin.New()
+		r.Use(controllers.ErrorHandlerMiddleware())
+		r.Use(func(ctx *gin.Context) { ctx.Set("user", staff) })
+		r.POST("/receptions/:receptionId/pause", func(ctx *gin.Context) { ctrl.Pause(ctx, uuid.MustParse(ctx.Param("receptionId"))) })
+		r.POST("/receptions/:receptionId/resume", func(ctx *gin.Context) { ctrl.Resume(ctx, uuid.MustParse(ctx.Param("receptionId"))) })
+		r.POST("/receptions/:receptionId/cancel", func(ctx *gin.Context) { ctrl.Cancel(ctx, uuid.MustParse(ctx.Param("receptionId"))) })
+		return r
+	}
+
+	t.Run("пауза и продолжение передают целевой статус", func(t *testing.T) {
+		// Arrange
+		status := new(mockChangeStatusUC)
+		r := setup(status)
+		rec := entities.Reception{ID: uuid.New(), PVZID: uuid.New(), Status: entities.ReceptionPaused, DateTime: time.Now().UTC()}
+		status.On("Execute", anyCtx, staff, rec.ID, entities.ReceptionPaused, "").Return(rec, nil)
+		status.On("Execute", anyCtx, staff, rec.ID, entities.ReceptionInProgress, "").Return(entities.Reception{}, usecases.ErrTransitionNotAllowed)
+
+		// Act
+		paused := httptest.NewRecorder()
+		r.ServeHTTP(paused, httptest.NewRequest(http.MethodPost, "/receptions/"+rec.ID.String()+"/pause", nil))
+		resumed := httptest.NewRecorder()
+		r.ServeHTTP(resumed, httptest.NewRequest(http.MethodPost, "/receptions/"+rec.ID.String()+"/resume", nil))
+
+		// Assert
+		require.Equal(t, http.StatusOK, paused.Code)
+		var resp api.Reception
+		require.NoError(t, json.Unmarshal(paused.Body.Bytes(), &resp))
+		assert.Equal(t, api.ReceptionStatusPaused, resp.Status)
+		require.Equal(t, http.StatusConflict, resumed.Code)
+		status.AssertExpectations(t)
+	})
+
+	t.Run("отмена: причина передаётся в usecase, тело без reason — 400", func(t *testing.T) {
+		// Arrange
+		status := new(mockChangeStatusUC)
+		r := setup(status)
+		rec := entities.Reception{ID: uuid.New(), PVZID: uuid.New(), Status: entities.ReceptionCancelled, DateTime: time.Now().UTC()}
+		status.On("Execute", anyCtx, staff, rec.ID, entities.ReceptionCancelled, "машина уехала").Return(rec, nil)
+
+		// Act
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/receptions/"+rec.ID.String()+"/cancel", strings.NewReader(`{"reason":"машина уехала"}`)))
+		broken := httptest.NewRecorder()
+		r.ServeHTTP(broken, httptest.NewRequest(http.MethodPost, "/receptions/"+rec.ID.String()+"/cancel", strings.NewReader(`{`)))
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp api.Reception
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, api.ReceptionStatusCancelled, resp.Status)
+		require.Equal(t, http.StatusBadRequest, broken.Code)
+		status.AssertExpectations(t)
+	})
+}
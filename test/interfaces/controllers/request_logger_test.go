@@ -0,0 +1,70 @@
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/interfaces/controllers"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestLoggerMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// parseLines разбирает JSON-логи построчно
+	parseLines := func(t *testing.T, buf *bytes.Buffer) []map[string]any {
+		var res []map[string]any
+		for _, line := range bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n")) {
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal(line, &entry))
+			res = append(res, entry)
+		}
+		return res
+	}
+
+	t.Run("request_id из заголовка доходит до логгера в контексте", func(t *testing.T) {
+		var buf bytes.Buffer
+		r := gin.New()
+		r.Use(controllers.RequestLoggerMiddleware(logger.NewWithWriter(&buf, "info", "json")))
+		r.GET("/ping", func(ctx *gin.Context) {
+			logger.FromContext(ctx.Request.Context()).Info("inside handler")
+			ctx.Status(http.StatusOK)
+		})
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		req.Header.Set(controllers.RequestIDHeader, "scanner-42")
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		require.Equal(t, "scanner-42", w.Header().Get(controllers.RequestIDHeader))
+		entries := parseLines(t, &buf)
+		require.Len(t, entries, 2)
+		assert.Equal(t, "inside handler", entries[0]["msg"])
+		assert.Equal(t, "scanner-42", entries[0]["request_id"])
+		assert.Equal(t, "request completed", entries[1]["msg"])
+		assert.Equal(t, float64(200), entries[1]["status"])
+	})
+
+	t.Run("request_id генерируется, если его нет", func(t *testing.T) {
+		var buf bytes.Buffer
+		r := gin.New()
+		r.Use(controllers.RequestLoggerMiddleware(logger.NewWithWriter(&buf, "info", "json")))
+		r.GET("/ping", func(ctx *gin.Context) { ctx.Status(http.StatusNotFound) })
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ping", nil))
+
+		requestID := w.Header().Get(controllers.RequestIDHeader)
+		require.NotEmpty(t, requestID)
+		entries := parseLines(t, &buf)
+		require.Len(t, entries, 1)
+		assert.Equal(t, requestID, entries[0]["request_id"])
+		assert.Equal(t, "WARN", entries[0]["level"])
+	})
+}
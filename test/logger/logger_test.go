@@ -0,0 +1,51 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLevel(t *testing.T) {
+	assert.Equal(t, slog.LevelDebug, logger.ParseLevel("debug"))
+	assert.Equal(t, slog.LevelWarn, logger.ParseLevel("WARN"))
+	assert.Equal(t, slog.LevelError, logger.ParseLevel("error"))
+	assert.Equal(t, slog.LevelInfo, logger.ParseLevel(""))
+	assert.Equal(t, slog.LevelInfo, logger.ParseLevel("unknown"))
+}
+
+func TestNewWithWriter_JSONAndLevel(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	l := logger.NewWithWriter(&buf, "warn", "json")
+
+	// Act
+	l.Info("skipped")
+	l.Warn("kept", slog.String("pvz_id", "42"))
+
+	// Assert: info отфильтрован, warn записан в JSON
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 1)
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(lines[0], &entry))
+	assert.Equal(t, "kept", entry["msg"])
+	assert.Equal(t, "42", entry["pvz_id"])
+}
+
+func TestFromContext(t *testing.T) {
+	var buf bytes.Buffer
+	l := logger.NewWithWriter(&buf, "info", "json").With(slog.String("request_id", "req-1"))
+
+	ctx := logger.WithContext(context.Background(), l)
+	logger.FromContext(ctx).Info("hello")
+
+	assert.Contains(t, buf.String(), `"request_id":"req-1"`)
+	// Без логгера в контексте — slog.Default()
+	assert.Equal(t, slog.Default(), logger.FromContext(context.Background()))
+}
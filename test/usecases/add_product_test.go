@@ -23,7 +23,7 @@ type mockReceptionRepoForAdd struct {
 	getActiveFn func(ctx context.Context, pvzID uuid.UUID) (*entities.Reception, error)
 }
 
-func (m *mockReceptionRepoForAdd) GetActive(ctx context.Context, pvzID uuid.UUID) (*entities.Reception, error) {
+func (m *mockReceptionRepoForAdd) GetActiveForUpdate(ctx context.Context, pvzID uuid.UUID) (*entities.Reception, error) {
 	return m.getActiveFn(ctx, pvzID)
 }
 
@@ -34,6 +34,7 @@ func TestAddProductUseCase_Execute(t *testing.T) {
 	product := entities.Product{ID: uuid.New(), ReceptionID: pvzID, Type: entities.ProductElectronics}
 	user := entities.User{Role: entities.UserRolePVZStaff}
 
+	metrics := &countingMetrics{}
 	uc := usecases.NewAddProductUseCase(
 		&mockProductRepo{saveFn: func(ctx context.Context, p entities.Product) (entities.Product, error) {
 			return product, nil
@@ -41,26 +42,32 @@ func TestAddProductUseCase_Execute(t *testing.T) {
 		&mockReceptionRepoForAdd{getActiveFn: func(ctx context.Context, id uuid.UUID) (*entities.Reception, error) {
 			return rec, nil
 		}},
+		usecases.DefaultCatalog(),
+		usecases.NopTxManager{},
+		metrics,
+		testAuthz,
 	)
 
 	ctx := context.Background()
 
 	// Act
-	res, err := uc.Execute(ctx, user, pvzID, entities.ProductElectronics)
+	res, err := uc.Execute(ctx, user, pvzID, entities.ProductElectronics, "")
 
 	// Assert
 	require.NoError(t, err)
 	require.Equal(t, product, res)
+	require.Equal(t, 1, metrics.products)
 
 	// Не pvz_staff
 	user.Role = entities.UserRoleClient
-	_, err = uc.Execute(ctx, user, pvzID, entities.ProductElectronics)
-	assert.Error(t, err)
+	_, err = uc.Execute(ctx, user, pvzID, entities.ProductElectronics, "")
+	assert.ErrorIs(t, err, usecases.ErrForbidden)
 
 	// Некорректный тип
 	user.Role = entities.UserRolePVZStaff
-	_, err = uc.Execute(ctx, user, pvzID, "еда")
-	assert.Error(t, err)
+	_, err = uc.Execute(ctx, user, pvzID, "еда", "")
+	assert.ErrorIs(t, err, usecases.ErrInvalidProductType)
+	assert.ErrorIs(t, err, usecases.ErrValidation)
 
 	// Нет открытой приёмки
 	uc = usecases.NewAddProductUseCase(
@@ -70,7 +77,94 @@ func TestAddProductUseCase_Execute(t *testing.T) {
 		&mockReceptionRepoForAdd{getActiveFn: func(ctx context.Context, id uuid.UUID) (*entities.Reception, error) {
 			return nil, nil
 		}},
+		usecases.DefaultCatalog(),
+		usecases.NopTxManager{},
+		metrics,
+		testAuthz,
 	)
-	_, err = uc.Execute(ctx, user, pvzID, entities.ProductElectronics)
-	assert.Error(t, err)
+	_, err = uc.Execute(ctx, user, pvzID, entities.ProductElectronics, "")
+	assert.ErrorIs(t, err, usecases.ErrNoOpenReception)
+	assert.Equal(t, 1, metrics.products)
+
+	// Приёмка на паузе — сканирование остановлено
+	uc = usecases.NewAddProductUseCase(
+		&mockProductRepo{saveFn: func(ctx context.Context, p entities.Product) (entities.Product, error) {
+			return product, nil
+		}},
+		&mockReceptionRepoForAdd{getActiveFn: func(ctx context.Context, id uuid.UUID) (*entities.Reception, error) {
+			return &entities.Reception{ID: rec.ID, Status: entities.ReceptionPaused}, nil
+		}},
+		usecases.DefaultCatalog(),
+		usecases.NopTxManager{},
+		metrics,
+		testAuthz,
+	)
+	_, err = uc.Execute(ctx, user, pvzID, entities.ProductElectronics, "")
+	assert.ErrorIs(t, err, usecases.ErrReceptionPaused)
+	assert.Equal(t, 1, metrics.products)
+}
+
+func TestAddProductUseCase_Barcode(t *testing.T) {
+	ctx := context.Background()
+	staff := entities.User{Role: entities.UserRolePVZStaff}
+	rec := &entities.Reception{ID: uuid.New(), Status: entities.ReceptionInProgress}
+	newUseCase := func(repo *mockProductRepo) *usecases.AddProductUseCase {
+		return usecases.NewAddProductUseCase(
+			repo,
+			&mockReceptionRepoForAdd{getActiveFn: func(ctx context.Context, id uuid.UUID) (*entities.Reception, error) {
+				return rec, nil
+			}},
+			usecases.DefaultCatalog(),
+			usecases.NopTxManager{},
+			usecases.NopMetrics{},
+			testAuthz,
+		)
+	}
+
+	t.Run("штрихкод без пробелов по краям сохраняется", func(t *testing.T) {
+		// Arrange
+		var saved entities.Product
+		uc := newUseCase(&mockProductRepo{saveFn: func(ctx context.Context, p entities.Product) (entities.Product, error) {
+			saved = p
+			return p, nil
+		}})
+
+		// Act
+		res, err := uc.Execute(ctx, staff, uuid.New(), entities.ProductShoes, " 4601234567893 ")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "4601234567893", saved.Barcode)
+		assert.Equal(t, "4601234567893", res.Barcode)
+	})
+
+	t.Run("повторное сканирование — конфликт", func(t *testing.T) {
+		// Arrange
+		uc := newUseCase(&mockProductRepo{saveFn: func(ctx context.Context, p entities.Product) (entities.Product, error) {
+			return entities.Product{}, usecases.ErrDuplicateBarcode
+		}})
+
+		// Act
+		_, err := uc.Execute(ctx, staff, uuid.New(), entities.ProductShoes, "4601234567893")
+
+		// Assert
+		assert.ErrorIs(t, err, usecases.ErrDuplicateBarcode)
+		assert.ErrorIs(t, err, usecases.ErrConflict)
+	})
+
+	t.Run("некорректный штрихкод не доходит до репозитория", func(t *testing.T) {
+		// Arrange
+		calls := 0
+		uc := newUseCase(&mockProductRepo{saveFn: func(ctx context.Context, p entities.Product) (entities.Product, error) {
+			calls++
+			return p, nil
+		}})
+
+		// Act
+		_, err := uc.Execute(ctx, staff, uuid.New(), entities.ProductShoes, "460 123")
+
+		// Assert
+		assert.ErrorIs(t, err, usecases.ErrInvalidBarcode)
+		assert.Zero(t, calls)
+	})
 }
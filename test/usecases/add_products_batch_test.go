@@ -0,0 +1,119 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockProductRepoForBatch помнит уже отсканированные штрихкоды и назначает позиции как PGProductRepository
+type mockProductRepoForBatch struct {
+	scanned []string
+	saved   []entities.Product
+	batches int
+}
+
+func (m *mockProductRepoForBatch) ExistingBarcodes(_ context.Context, _ uuid.UUID, barcodes []string) ([]string, error) {
+	var res []string
+	for _, b := range barcodes {
+		for _, s := range m.scanned {
+			if b == s {
+				res = append(res, b)
+			}
+		}
+	}
+	return res, nil
+}
+
+func (m *mockProductRepoForBatch) SaveBatch(_ context.Context, receptionID uuid.UUID, products []entities.Product) ([]entities.Product, error) {
+	m.batches++
+	for _, p := range products {
+		p.ReceptionID = receptionID
+		p.Position = len(m.saved) + 1
+		m.saved = append(m.saved, p)
+	}
+	return m.saved[len(m.saved)-len(products):], nil
+}
+
+func TestAddProductsBatchUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	staff := entities.User{Role: entities.UserRolePVZStaff}
+	rec := &entities.Reception{ID: uuid.New(), Status: entities.ReceptionInProgress}
+	openReception := &mockReceptionRepoForAdd{getActiveFn: func(ctx context.Context, id uuid.UUID) (*entities.Reception, error) {
+		return rec, nil
+	}}
+
+	t.Run("некорректные товары отклоняются поштучно, остальные сохраняются одним пакетом", func(t *testing.T) {
+		// Arrange
+		repo := &mockProductRepoForBatch{scanned: []string{"111"}}
+		metrics := &countingMetrics{}
+		uc := usecases.NewAddProductsBatchUseCase(repo, openReception, usecases.DefaultCatalog(), usecases.NopTxManager{}, metrics, testAuthz)
+		items := []usecases.ProductBatchItem{
+			{Type: entities.ProductElectronics, Barcode: "222"},
+			{Type: entities.ProductShoes, Barcode: "111"},
+			{Type: "еда"},
+			{Type: entities.ProductClothes, Barcode: " 222 "},
+			{Type: entities.ProductClothes, Barcode: "460 123"},
+			{Type: entities.ProductClothes},
+		}
+
+		// Act
+		res, err := uc.Execute(ctx, staff, uuid.New(), items)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, res, len(items))
+		require.NotNil(t, res[0].Product)
+		assert.Equal(t, "222", res[0].Product.Barcode)
+		assert.Equal(t, 1, res[0].Product.Position)
+		assert.ErrorIs(t, res[1].Err, usecases.ErrDuplicateBarcode)
+		assert.ErrorIs(t, res[2].Err, usecases.ErrInvalidProductType)
+		assert.ErrorIs(t, res[3].Err, usecases.ErrDuplicateBarcode)
+		assert.ErrorIs(t, res[4].Err, usecases.ErrInvalidBarcode)
+		require.NotNil(t, res[5].Product)
+		assert.Equal(t, 2, res[5].Product.Position)
+		assert.Equal(t, rec.ID, res[5].Product.ReceptionID)
+		assert.Equal(t, 1, repo.batches)
+		assert.Equal(t, 2, metrics.products)
+	})
+
+	t.Run("все товары отклонены — вставки нет", func(t *testing.T) {
+		// Arrange
+		repo := &mockProductRepoForBatch{}
+		uc := usecases.NewAddProductsBatchUseCase(repo, openReception, usecases.DefaultCatalog(), usecases.NopTxManager{}, usecases.NopMetrics{}, testAuthz)
+
+		// Act
+		res, err := uc.Execute(ctx, staff, uuid.New(), []usecases.ProductBatchItem{{Type: "еда"}})
+
+		// Assert
+		require.NoError(t, err)
+		assert.ErrorIs(t, res[0].Err, usecases.ErrInvalidProductType)
+		assert.Zero(t, repo.batches)
+	})
+
+	t.Run("ошибки всего запроса", func(t *testing.T) {
+		// Arrange
+		repo := &mockProductRepoForBatch{}
+		noReception := &mockReceptionRepoForAdd{getActiveFn: func(ctx context.Context, id uuid.UUID) (*entities.Reception, error) {
+			return nil, nil
+		}}
+		uc := usecases.NewAddProductsBatchUseCase(repo, noReception, usecases.DefaultCatalog(), usecases.NopTxManager{}, usecases.NopMetrics{}, testAuthz)
+		one := []usecases.ProductBatchItem{{Type: entities.ProductShoes}}
+
+		// Act & Assert
+		_, err := uc.Execute(ctx, staff, uuid.New(), one)
+		assert.ErrorIs(t, err, usecases.ErrNoOpenReception)
+		_, err = uc.Execute(ctx, staff, uuid.New(), nil)
+		assert.ErrorIs(t, err, usecases.ErrInvalidBatchSize)
+		_, err = uc.Execute(ctx, staff, uuid.New(), make([]usecases.ProductBatchItem, usecases.ProductBatchMaxSize+1))
+		assert.ErrorIs(t, err, usecases.ErrInvalidBatchSize)
+		_, err = uc.Execute(ctx, entities.User{Role: entities.UserRoleModerator}, uuid.New(), one)
+		assert.ErrorIs(t, err, usecases.ErrForbidden)
+		assert.Zero(t, repo.batches)
+	})
+}
@@ -0,0 +1,76 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockReceptionRepoForArchive struct{ active *entities.Reception }
+
+func (m *mockReceptionRepoForArchive) GetActive(_ context.Context, _ uuid.UUID) (*entities.Reception, error) {
+	return m.active, nil
+}
+
+func TestArchivePVZUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	moderator := entities.User{Role: entities.UserRoleModerator}
+
+	t.Run("архивация и возврат в работу идемпотентны", func(t *testing.T) {
+		// Arrange
+		pvzID := uuid.New()
+		repo := &mockPVZRepoForUpdate{pvz: &entities.PVZ{ID: pvzID, City: entities.CityMoscow}}
+		uc := usecases.NewArchivePVZUseCase(repo, &mockReceptionRepoForArchive{}, usecases.NopTxManager{}, testAuthz)
+
+		// Act
+		archived, err := uc.Execute(ctx, moderator, pvzID, true)
+		require.NoError(t, err)
+		again, err := uc.Execute(ctx, moderator, pvzID, true)
+		require.NoError(t, err)
+
+		// Assert
+		require.True(t, archived.IsArchived())
+		assert.Equal(t, *archived.ArchivedAt, *again.ArchivedAt)
+		assert.Equal(t, 1, repo.updates)
+
+		restored, err := uc.Execute(ctx, moderator, pvzID, false)
+		require.NoError(t, err)
+		assert.False(t, restored.IsArchived())
+		_, err = uc.Execute(ctx, moderator, pvzID, false)
+		require.NoError(t, err)
+		assert.Equal(t, 2, repo.updates)
+	})
+
+	t.Run("ПВЗ с открытой приёмкой не архивируется", func(t *testing.T) {
+		// Arrange
+		pvzID := uuid.New()
+		repo := &mockPVZRepoForUpdate{pvz: &entities.PVZ{ID: pvzID, City: entities.CityMoscow}}
+		receptions := &mockReceptionRepoForArchive{active: &entities.Reception{ID: uuid.New(), PVZID: pvzID, Status: entities.ReceptionInProgress}}
+		uc := usecases.NewArchivePVZUseCase(repo, receptions, usecases.NopTxManager{}, testAuthz)
+
+		// Act
+		_, err := uc.Execute(ctx, moderator, pvzID, true)
+
+		// Assert
+		assert.ErrorIs(t, err, usecases.ErrPVZHasOpenReception)
+		assert.ErrorIs(t, err, usecases.ErrConflict)
+		assert.Zero(t, repo.updates)
+	})
+
+	t.Run("ошибки доступа и отсутствие ПВЗ", func(t *testing.T) {
+		// Arrange
+		repo := &mockPVZRepoForUpdate{}
+		uc := usecases.NewArchivePVZUseCase(repo, &mockReceptionRepoForArchive{}, usecases.NopTxManager{}, testAuthz)
+
+		// Act & Assert
+		_, err := uc.Execute(ctx, entities.User{Role: entities.UserRolePVZStaff}, uuid.New(), true)
+		assert.ErrorIs(t, err, usecases.ErrForbidden)
+		_, err = uc.Execute(ctx, moderator, uuid.New(), true)
+		assert.ErrorIs(t, err, usecases.ErrPVZNotFound)
+	})
+}
@@ -0,0 +1,161 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockStaffRepo хранит назначения в памяти в порядке добавления
+type mockStaffRepo struct{ assignments []entities.StaffAssignment }
+
+func (m *mockStaffRepo) Assign(_ context.Context, a entities.StaffAssignment) error {
+	for _, cur := range m.assignments {
+		if cur.PVZID == a.PVZID && cur.UserID == a.UserID {
+			return nil
+		}
+	}
+	m.assignments = append(m.assignments, a)
+	return nil
+}
+
+func (m *mockStaffRepo) Unassign(_ context.Context, pvzID, userID uuid.UUID) (bool, error) {
+	for i, cur := range m.assignments {
+		if cur.PVZID == pvzID && cur.UserID == userID {
+			m.assignments = append(m.assignments[:i], m.assignments[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *mockStaffRepo) ListByPVZ(_ context.Context, pvzID uuid.UUID) ([]entities.StaffAssignment, error) {
+	res := []entities.StaffAssignment{}
+	for _, cur := range m.assignments {
+		if cur.PVZID == pvzID {
+			res = append(res, cur)
+		}
+	}
+	return res, nil
+}
+
+// mockUsersForStaff — пользователи по id
+type mockUsersForStaff map[uuid.UUID]entities.User
+
+func (m mockUsersForStaff) GetByID(_ context.Context, id uuid.UUID) (*entities.User, error) {
+	u, ok := m[id]
+	if !ok {
+		return nil, nil
+	}
+	return &u, nil
+}
+
+// mockPVZRepoForStaff возвращает ПВЗ по id, nil — ПВЗ не найден
+type mockPVZRepoForStaff struct{ pvz *entities.PVZ }
+
+func (m *mockPVZRepoForStaff) GetByID(_ context.Context, _ uuid.UUID) (*entities.PVZ, error) {
+	return m.pvz, nil
+}
+
+func TestAssignStaffUseCase_Execute(t *testing.T) {
+	moderator := entities.User{ID: uuid.New(), Role: entities.UserRoleModerator}
+	staffID, clientID := uuid.New(), uuid.New()
+	users := mockUsersForStaff{
+		staffID:  {ID: staffID, Role: entities.UserRolePVZStaff},
+		clientID: {ID: clientID, Role: entities.UserRoleClient},
+	}
+
+	t.Run("назначение, повтор и снятие", func(t *testing.T) {
+		// Arrange
+		pvzID := uuid.New()
+		repo := &mockStaffRepo{}
+		pvzRepo := &mockPVZRepoForReception{pvz: &entities.PVZ{ID: pvzID}}
+		uc := usecases.NewAssignStaffUseCase(repo, users, pvzRepo, usecases.NopTxManager{}, testAuthz)
+		ctx := context.Background()
+
+		// Act
+		err1 := uc.Execute(ctx, moderator, pvzID, staffID, true)
+		err2 := uc.Execute(ctx, moderator, pvzID, staffID, true)
+
+		// Assert
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		require.Len(t, repo.assignments, 1)
+		assert.Equal(t, staffID, repo.assignments[0].UserID)
+		assert.Equal(t, moderator.ID, repo.assignments[0].AssignedBy)
+		assert.False(t, repo.assignments[0].AssignedAt.IsZero())
+
+		// Act
+		err := uc.Execute(ctx, moderator, pvzID, staffID, false)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, repo.assignments)
+		assert.ErrorIs(t, uc.Execute(ctx, moderator, pvzID, staffID, false), usecases.ErrStaffNotAssigned)
+	})
+
+	t.Run("ошибки", func(t *testing.T) {
+		// Arrange
+		repo := &mockStaffRepo{}
+		pvzRepo := &mockPVZRepoForReception{}
+		uc := usecases.NewAssignStaffUseCase(repo, users, pvzRepo, usecases.NopTxManager{}, testAuthz)
+		ctx := context.Background()
+		staff := entities.User{ID: staffID, Role: entities.UserRolePVZStaff}
+
+		// Act & Assert
+		assert.ErrorIs(t, uc.Execute(ctx, staff, uuid.New(), staffID, true), usecases.ErrForbidden)
+		assert.ErrorIs(t, uc.Execute(ctx, moderator, uuid.New(), staffID, true), usecases.ErrPVZNotFound)
+
+		pvzRepo.pvz = &entities.PVZ{ID: uuid.New()}
+		err := uc.Execute(ctx, moderator, pvzRepo.pvz.ID, uuid.New(), true)
+		assert.ErrorIs(t, err, usecases.ErrUserNotFound)
+		assert.ErrorIs(t, err, usecases.ErrNotFound)
+		err = uc.Execute(ctx, moderator, pvzRepo.pvz.ID, clientID, true)
+		assert.ErrorIs(t, err, usecases.ErrNotPVZStaff)
+		assert.ErrorIs(t, err, usecases.ErrValidation)
+
+		archivedAt := entities.NowUTC()
+		pvzRepo.pvz = &entities.PVZ{ID: uuid.New(), ArchivedAt: &archivedAt}
+		err = uc.Execute(ctx, moderator, pvzRepo.pvz.ID, staffID, true)
+		assert.ErrorIs(t, err, usecases.ErrPVZArchived)
+		assert.ErrorIs(t, err, usecases.ErrConflict)
+		assert.Empty(t, repo.assignments)
+	})
+}
+
+func TestListPVZStaffUseCase_Execute(t *testing.T) {
+	// Arrange
+	pvzID := uuid.New()
+	moderator := entities.User{ID: uuid.New(), Role: entities.UserRoleModerator}
+	repo := &mockStaffRepo{assignments: []entities.StaffAssignment{
+		{PVZID: pvzID, UserID: uuid.New()},
+		{PVZID: uuid.New(), UserID: uuid.New()},
+		{PVZID: pvzID, UserID: uuid.New()},
+	}}
+	pvzRepo := &mockPVZRepoForStaff{pvz: &entities.PVZ{ID: pvzID}}
+	uc := usecases.NewListPVZStaffUseCase(pvzRepo, repo, testAuthz)
+	ctx := context.Background()
+
+	// Act
+	staff, err := uc.Execute(ctx, moderator, pvzID)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, staff, 2)
+	assert.Equal(t, repo.assignments[0].UserID, staff[0].UserID)
+	assert.Equal(t, repo.assignments[2].UserID, staff[1].UserID)
+
+	// Не модератор
+	_, err = uc.Execute(ctx, entities.User{Role: entities.UserRolePVZStaff}, pvzID)
+	assert.ErrorIs(t, err, usecases.ErrForbidden)
+
+	// ПВЗ не найден
+	pvzRepo.pvz = nil
+	_, err = uc.Execute(ctx, moderator, pvzID)
+	assert.ErrorIs(t, err, usecases.ErrPVZNotFound)
+}
@@ -0,0 +1,121 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staffCheckerFunc — заглушка usecases.StaffAssignmentChecker
+type staffCheckerFunc func(userID, pvzID uuid.UUID) (bool, error)
+
+func (f staffCheckerFunc) IsAssigned(_ context.Context, userID, pvzID uuid.UUID) (bool, error) {
+	return f(userID, pvzID)
+}
+
+// allAssigned — любой пользователь назначен на любой ПВЗ
+var allAssigned = staffCheckerFunc(func(uuid.UUID, uuid.UUID) (bool, error) { return true, nil })
+
+// noneAssigned — никто ни на какой ПВЗ не назначен
+var noneAssigned = staffCheckerFunc(func(uuid.UUID, uuid.UUID) (bool, error) { return false, nil })
+
+// testAuthz — Authorizer со встроенной политикой, как в проде без RBAC_POLICY_FILE; назначения не ограничивают
+var testAuthz = usecases.NewAuthorizer(entities.DefaultRolePermissions(), allAssigned)
+
+func TestAuthorizer(t *testing.T) {
+	t.Run("встроенная политика", func(t *testing.T) {
+		cases := []struct {
+			role entities.UserRole
+			perm entities.Permission
+			want bool
+		}{
+			{entities.UserRoleModerator, entities.PermPVZCreate, true},
+			{entities.UserRolePVZStaff, entities.PermPVZCreate, false},
+			{entities.UserRolePVZStaff, entities.PermReceptionCreate, true},
+			{entities.UserRoleModerator, entities.PermReceptionCreate, false},
+			{entities.UserRoleClient, entities.PermPVZRead, false},
+			{entities.UserRoleClient, entities.PermPVZNearest, true},
+			{entities.UserRoleClient, entities.PermCatalogRead, true},
+			{entities.UserRolePVZStaff, entities.PermCatalogManage, false},
+			{entities.UserRoleModerator, entities.PermPVZManageStaff, true},
+			{entities.UserRolePVZStaff, entities.PermPVZManageStaff, false},
+			{"root", entities.PermPVZRead, false},
+		}
+		for _, tc := range cases {
+			t.Run(string(tc.role)+" "+string(tc.perm), func(t *testing.T) {
+				// Act & Assert
+				assert.Equal(t, tc.want, testAuthz.Can(tc.role, tc.perm))
+			})
+		}
+	})
+
+	t.Run("новая роль задаётся только политикой", func(t *testing.T) {
+		// Arrange
+		policy := entities.DefaultRolePermissions()
+		policy["auditor"] = []entities.Permission{entities.PermPVZRead, entities.PermReceptionRead}
+		authz := usecases.NewAuthorizer(policy, allAssigned)
+		auditor := entities.User{Role: "auditor"}
+		repo := &mockPVZRepoForList{
+			listFn: func(context.Context, *time.Time, *time.Time, int, int) ([]entities.PVZWithReceptions, error) {
+				return nil, nil
+			},
+		}
+		uc := usecases.NewListPVZsUseCase(repo, authz)
+
+		// Act
+		_, listErr := uc.Execute(context.Background(), auditor, nil, nil, 1, 10, false)
+		createErr := authz.Authorize(context.Background(), auditor, entities.PermPVZCreate)
+
+		// Assert
+		require.NoError(t, listErr)
+		require.ErrorIs(t, createErr, usecases.ErrForbidden)
+		assert.Contains(t, createErr.Error(), "pvz:create")
+	})
+
+	t.Run("доступ к ПВЗ только для назначенных", func(t *testing.T) {
+		// Arrange
+		staff := entities.User{ID: uuid.New(), Role: entities.UserRolePVZStaff}
+		pvzID := uuid.New()
+		var gotUser, gotPVZ uuid.UUID
+		authz := usecases.NewAuthorizer(entities.DefaultRolePermissions(), staffCheckerFunc(func(userID, id uuid.UUID) (bool, error) {
+			gotUser, gotPVZ = userID, id
+			return id == pvzID, nil
+		}))
+
+		// Act
+		okErr := authz.AuthorizePVZ(context.Background(), staff, entities.PermReceptionCreate, pvzID)
+		otherErr := authz.AuthorizePVZ(context.Background(), staff, entities.PermReceptionCreate, uuid.New())
+		permErr := authz.AuthorizePVZ(context.Background(), entities.User{Role: entities.UserRoleModerator}, entities.PermReceptionCreate, pvzID)
+
+		// Assert
+		require.NoError(t, okErr)
+		assert.Equal(t, staff.ID, gotUser)
+		assert.NotEqual(t, pvzID, gotPVZ)
+		require.ErrorIs(t, otherErr, usecases.ErrNotAssignedToPVZ)
+		require.ErrorIs(t, otherErr, usecases.ErrForbidden)
+		require.ErrorIs(t, permErr, usecases.ErrForbidden)
+		assert.Contains(t, permErr.Error(), "reception:create")
+	})
+
+	t.Run("все права каталога кому-то выданы", func(t *testing.T) {
+		// Arrange
+		granted := map[entities.Permission]bool{}
+		for _, perms := range entities.DefaultRolePermissions() {
+			for _, p := range perms {
+				require.True(t, entities.ValidatePermission(p), p)
+				granted[p] = true
+			}
+		}
+
+		// Assert
+		for _, p := range entities.AllPermissions() {
+			assert.True(t, granted[p], p)
+		}
+	})
+}
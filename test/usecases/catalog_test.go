@@ -0,0 +1,253 @@
+package usecases_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memCatalogRepo — справочник в памяти, считает обращения к List
+type memCatalogRepo struct {
+	mu      sync.Mutex
+	entries []entities.CatalogEntry
+	lists   int
+	listErr error
+}
+
+func (r *memCatalogRepo) List(_ context.Context, kind entities.CatalogKind) ([]entities.CatalogEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lists++
+	if r.listErr != nil {
+		return nil, r.listErr
+	}
+	var res []entities.CatalogEntry
+	for _, e := range r.entries {
+		if e.Kind == kind {
+			res = append(res, e)
+		}
+	}
+	return res, nil
+}
+
+func (r *memCatalogRepo) Add(_ context.Context, kind entities.CatalogKind, name string) (entities.CatalogEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range r.entries {
+		if e.Kind == kind && e.Name == name {
+			return entities.CatalogEntry{}, usecases.ErrCatalogEntryExists
+		}
+	}
+	e := entities.CatalogEntry{ID: int64(len(r.entries) + 1), Kind: kind, Name: name, Active: true}
+	r.entries = append(r.entries, e)
+	return e, nil
+}
+
+func (r *memCatalogRepo) Update(_ context.Context, kind entities.CatalogKind, id int64, patch usecases.CatalogEntryPatch) (*entities.CatalogEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.entries {
+		e := &r.entries[i]
+		if e.Kind != kind || e.ID != id {
+			continue
+		}
+		if patch.Name != nil {
+			e.Name = *patch.Name
+		}
+		if patch.Active != nil {
+			e.Active = *patch.Active
+		}
+		res := *e
+		return &res, nil
+	}
+	return nil, nil
+}
+
+func newMemCatalogRepo() *memCatalogRepo {
+	return &memCatalogRepo{entries: []entities.CatalogEntry{
+		{ID: 1, Kind: entities.CatalogCity, Name: "Москва", Active: true},
+		{ID: 2, Kind: entities.CatalogCity, Name: "Тверь", Active: false},
+		{ID: 3, Kind: entities.CatalogProductType, Name: "обувь", Active: true},
+	}}
+}
+
+func TestCatalogCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("только активные элементы, справочник читается один раз", func(t *testing.T) {
+		// Arrange
+		repo := newMemCatalogRepo()
+		cache := usecases.NewCatalogCache(repo, 0)
+
+		// Act
+		moscow, err1 := cache.IsAllowed(ctx, entities.CatalogCity, "Москва")
+		tver, err2 := cache.IsAllowed(ctx, entities.CatalogCity, "Тверь")
+		shoes, err3 := cache.IsAllowed(ctx, entities.CatalogCity, "обувь")
+
+		// Assert
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		require.NoError(t, err3)
+		assert.True(t, moscow)
+		assert.False(t, tver, "отключённый город")
+		assert.False(t, shoes, "тип товара не город")
+		assert.Equal(t, 1, repo.lists)
+	})
+
+	t.Run("Invalidate перечитывает справочник", func(t *testing.T) {
+		// Arrange
+		repo := newMemCatalogRepo()
+		cache := usecases.NewCatalogCache(repo, 0)
+		_, _ = cache.IsAllowed(ctx, entities.CatalogCity, "Новосибирск")
+		_, _ = repo.Add(ctx, entities.CatalogCity, "Новосибирск")
+
+		// Act
+		before, _ := cache.IsAllowed(ctx, entities.CatalogCity, "Новосибирск")
+		cache.Invalidate(entities.CatalogCity)
+		after, err := cache.IsAllowed(ctx, entities.CatalogCity, "Новосибирск")
+
+		// Assert
+		require.NoError(t, err)
+		assert.False(t, before)
+		assert.True(t, after)
+		assert.Equal(t, 2, repo.lists)
+	})
+
+	t.Run("по истечении ttl справочник перечитывается", func(t *testing.T) {
+		// Arrange
+		repo := newMemCatalogRepo()
+		cache := usecases.NewCatalogCache(repo, 10*time.Millisecond)
+		_, _ = cache.IsAllowed(ctx, entities.CatalogCity, "Москва")
+
+		// Act
+		time.Sleep(20 * time.Millisecond)
+		_, err := cache.IsAllowed(ctx, entities.CatalogCity, "Москва")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 2, repo.lists)
+	})
+
+	t.Run("ошибка репозитория не кэшируется", func(t *testing.T) {
+		// Arrange
+		repo := newMemCatalogRepo()
+		repo.listErr = assert.AnError
+		cache := usecases.NewCatalogCache(repo, 0)
+
+		// Act
+		_, err := cache.IsAllowed(ctx, entities.CatalogCity, "Москва")
+		repo.listErr = nil
+		ok, err2 := cache.IsAllowed(ctx, entities.CatalogCity, "Москва")
+
+		// Assert
+		assert.ErrorIs(t, err, assert.AnError)
+		require.NoError(t, err2)
+		assert.True(t, ok)
+	})
+}
+
+func TestListCatalogUseCase_Execute(t *testing.T) {
+	// Arrange
+	uc := usecases.NewListCatalogUseCase(newMemCatalogRepo(), testAuthz)
+	ctx := context.Background()
+
+	// Act
+	forModerator, err1 := uc.Execute(ctx, entities.User{Role: entities.UserRoleModerator}, entities.CatalogCity)
+	forStaff, err2 := uc.Execute(ctx, entities.User{Role: entities.UserRolePVZStaff}, entities.CatalogCity)
+	_, errKind := uc.Execute(ctx, entities.User{Role: entities.UserRoleModerator}, "users")
+
+	// Assert
+	require.NoError(t, err1)
+	require.NoError(t, err2)
+	assert.Len(t, forModerator, 2, "модератор видит и отключённые")
+	require.Len(t, forStaff, 1)
+	assert.Equal(t, "Москва", forStaff[0].Name)
+	assert.ErrorIs(t, errKind, usecases.ErrUnknownCatalog)
+}
+
+// spyInvalidator запоминает сброшенные справочники
+type spyInvalidator struct{ kinds []entities.CatalogKind }
+
+func (s *spyInvalidator) Invalidate(kind entities.CatalogKind) { s.kinds = append(s.kinds, kind) }
+
+func TestAddCatalogEntryUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	moderator := entities.User{Role: entities.UserRoleModerator}
+
+	t.Run("модератор добавляет элемент, кэш сбрасывается", func(t *testing.T) {
+		// Arrange
+		spy := &spyInvalidator{}
+		uc := usecases.NewAddCatalogEntryUseCase(newMemCatalogRepo(), spy, testAuthz)
+
+		// Act
+		entry, err := uc.Execute(ctx, moderator, entities.CatalogProductType, "  косметика ")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "косметика", entry.Name)
+		assert.True(t, entry.Active)
+		assert.Equal(t, []entities.CatalogKind{entities.CatalogProductType}, spy.kinds)
+	})
+
+	t.Run("ошибки", func(t *testing.T) {
+		// Arrange
+		spy := &spyInvalidator{}
+		uc := usecases.NewAddCatalogEntryUseCase(newMemCatalogRepo(), spy, testAuthz)
+
+		// Act & Assert
+		_, err := uc.Execute(ctx, entities.User{Role: entities.UserRolePVZStaff}, entities.CatalogCity, "Омск")
+		assert.ErrorIs(t, err, usecases.ErrForbidden)
+		_, err = uc.Execute(ctx, moderator, "users", "Омск")
+		assert.ErrorIs(t, err, usecases.ErrUnknownCatalog)
+		_, err = uc.Execute(ctx, moderator, entities.CatalogCity, " ")
+		assert.ErrorIs(t, err, usecases.ErrValidation)
+		_, err = uc.Execute(ctx, moderator, entities.CatalogCity, "Москва")
+		assert.ErrorIs(t, err, usecases.ErrConflict)
+		assert.Empty(t, spy.kinds)
+	})
+}
+
+func TestUpdateCatalogEntryUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	moderator := entities.User{Role: entities.UserRoleModerator}
+	name := func(s string) *string { return &s }
+	active := func(b bool) *bool { return &b }
+
+	t.Run("переименование и включение", func(t *testing.T) {
+		// Arrange
+		spy := &spyInvalidator{}
+		uc := usecases.NewUpdateCatalogEntryUseCase(newMemCatalogRepo(), spy, testAuthz)
+
+		// Act
+		entry, err := uc.Execute(ctx, moderator, entities.CatalogCity, 2, usecases.CatalogEntryPatch{Name: name(" Тверь-2 "), Active: active(true)})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, entities.CatalogEntry{ID: 2, Kind: entities.CatalogCity, Name: "Тверь-2", Active: true}, entry)
+		assert.Equal(t, []entities.CatalogKind{entities.CatalogCity}, spy.kinds)
+	})
+
+	t.Run("ошибки", func(t *testing.T) {
+		// Arrange
+		spy := &spyInvalidator{}
+		uc := usecases.NewUpdateCatalogEntryUseCase(newMemCatalogRepo(), spy, testAuthz)
+		disable := usecases.CatalogEntryPatch{Active: active(false)}
+
+		// Act & Assert
+		_, err := uc.Execute(ctx, entities.User{Role: entities.UserRoleClient}, entities.CatalogCity, 1, disable)
+		assert.ErrorIs(t, err, usecases.ErrForbidden)
+		_, err = uc.Execute(ctx, moderator, entities.CatalogCity, 1, usecases.CatalogEntryPatch{})
+		assert.ErrorIs(t, err, usecases.ErrEmptyCatalogPatch)
+		_, err = uc.Execute(ctx, moderator, entities.CatalogCity, 1, usecases.CatalogEntryPatch{Name: name("")})
+		assert.ErrorIs(t, err, usecases.ErrInvalidCatalogName)
+		_, err = uc.Execute(ctx, moderator, entities.CatalogCity, 3, disable)
+		assert.ErrorIs(t, err, usecases.ErrCatalogEntryNotFound, "id из другого справочника")
+		assert.Empty(t, spy.kinds)
+	})
+}
@@ -0,0 +1,119 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockProductRepoForVoid struct {
+	receptionID uuid.UUID
+	removedBy   uuid.UUID
+	calls       int
+}
+
+func (m *mockProductRepoForVoid) VoidByReception(_ context.Context, receptionID, removedBy uuid.UUID, _ string, _ time.Time) (int, error) {
+	m.receptionID, m.removedBy = receptionID, removedBy
+	m.calls++
+	return 3, nil
+}
+
+func TestChangeReceptionStatusUseCase_Execute(t *testing.T) {
+	staff := entities.User{ID: uuid.New(), Role: entities.UserRolePVZStaff, Email: "staff@avito.ru"}
+	setup := func(status entities.ReceptionStatus) (*usecases.ChangeReceptionStatusUseCase, *mockReceptionRepoForReopen, *mockProductRepoForVoid, entities.Reception) {
+		rec := entities.Reception{ID: uuid.New(), PVZID: uuid.New(), Status: status, DateTime: time.Now().UTC()}
+		repo := &mockReceptionRepoForReopen{receptions: map[uuid.UUID]entities.Reception{rec.ID: rec}}
+		products := &mockProductRepoForVoid{}
+		return usecases.NewChangeReceptionStatusUseCase(repo, products, usecases.NopTxManager{}, testAuthz), repo, products, rec
+	}
+
+	t.Run("пауза и продолжение пишутся в историю", func(t *testing.T) {
+		// Arrange
+		uc, repo, products, rec := setup(entities.ReceptionInProgress)
+
+		// Act
+		paused, errPause := uc.Execute(context.Background(), staff, rec.ID, entities.ReceptionPaused, "")
+		resumed, errResume := uc.Execute(context.Background(), staff, rec.ID, entities.ReceptionInProgress, "")
+
+		// Assert
+		require.NoError(t, errPause)
+		require.NoError(t, errResume)
+		assert.Equal(t, entities.ReceptionPaused, paused.Status)
+		assert.Equal(t, entities.ReceptionInProgress, resumed.Status)
+		require.Len(t, repo.transitions, 2)
+		assert.Equal(t, entities.ReceptionInProgress, repo.transitions[0].From)
+		assert.Equal(t, entities.ReceptionPaused, repo.transitions[0].To)
+		assert.Equal(t, entities.ReceptionPaused, repo.transitions[1].From)
+		assert.Equal(t, entities.ReceptionInProgress, repo.transitions[1].To)
+		assert.Equal(t, staff.ID, repo.transitions[1].ChangedBy)
+		assert.Zero(t, products.calls)
+	})
+
+	t.Run("отмена аннулирует товары и требует причину", func(t *testing.T) {
+		// Arrange
+		uc, repo, products, rec := setup(entities.ReceptionPaused)
+
+		// Act
+		_, errNoReason := uc.Execute(context.Background(), staff, rec.ID, entities.ReceptionCancelled, " ")
+		cancelled, err := uc.Execute(context.Background(), staff, rec.ID, entities.ReceptionCancelled, "машина уехала")
+
+		// Assert
+		require.ErrorIs(t, errNoReason, usecases.ErrInvalidReason)
+		require.NoError(t, err)
+		assert.Equal(t, entities.ReceptionCancelled, cancelled.Status)
+		assert.Equal(t, 1, products.calls)
+		assert.Equal(t, rec.ID, products.receptionID)
+		assert.Equal(t, staff.ID, products.removedBy)
+		require.Len(t, repo.transitions, 1)
+		assert.Equal(t, "машина уехала", repo.transitions[0].Reason)
+	})
+
+	t.Run("недопустимые переходы — конфликт без изменений", func(t *testing.T) {
+		cases := map[string]struct {
+			from entities.ReceptionStatus
+			to   entities.ReceptionStatus
+		}{
+			"пауза закрытой":       {entities.ReceptionClosed, entities.ReceptionPaused},
+			"продолжение открытой": {entities.ReceptionInProgress, entities.ReceptionInProgress},
+			"продолжение закрытой": {entities.ReceptionClosed, entities.ReceptionInProgress},
+			"отмена отменённой":    {entities.ReceptionCancelled, entities.ReceptionCancelled},
+			"пауза отменённой":     {entities.ReceptionCancelled, entities.ReceptionPaused},
+		}
+		for name, tc := range cases {
+			t.Run(name, func(t *testing.T) {
+				// Arrange
+				uc, repo, products, rec := setup(tc.from)
+
+				// Act
+				_, err := uc.Execute(context.Background(), staff, rec.ID, tc.to, "причина")
+
+				// Assert
+				require.ErrorIs(t, err, usecases.ErrTransitionNotAllowed)
+				assert.Equal(t, tc.from, repo.receptions[rec.ID].Status)
+				assert.Empty(t, repo.transitions)
+				assert.Zero(t, products.calls)
+			})
+		}
+	})
+
+	t.Run("роль, неизвестный статус и неизвестная приёмка", func(t *testing.T) {
+		// Arrange
+		uc, _, _, rec := setup(entities.ReceptionInProgress)
+
+		// Act
+		_, errRole := uc.Execute(context.Background(), entities.User{Role: entities.UserRoleModerator}, rec.ID, entities.ReceptionPaused, "")
+		_, errStatus := uc.Execute(context.Background(), staff, rec.ID, entities.ReceptionClosed, "")
+		_, errMissing := uc.Execute(context.Background(), staff, uuid.New(), entities.ReceptionPaused, "")
+
+		// Assert
+		require.ErrorIs(t, errRole, usecases.ErrForbidden)
+		require.ErrorIs(t, errStatus, usecases.ErrInvalidReceptionStatus)
+		require.ErrorIs(t, errMissing, usecases.ErrReceptionNotFound)
+	})
+}
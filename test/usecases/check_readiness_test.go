@@ -0,0 +1,94 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockHealthRepo struct {
+	pingErr      error
+	state        usecases.MigrationState
+	migrationErr error
+	stateCalls   int
+}
+
+func (m *mockHealthRepo) Ping(context.Context) error { return m.pingErr }
+func (m *mockHealthRepo) MigrationState(context.Context) (usecases.MigrationState, error) {
+	m.stateCalls++
+	return m.state, m.migrationErr
+}
+
+func TestCheckReadinessUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("БД доступна, схема применена — готов", func(t *testing.T) {
+		// Arrange
+		uc := usecases.NewCheckReadinessUseCase(&mockHealthRepo{state: usecases.MigrationState{Version: 3, Latest: 3}})
+
+		// Act
+		res := uc.Execute(ctx)
+
+		// Assert
+		assert.True(t, res.Ready)
+		assert.Equal(t, int64(3), res.Migrations.Version)
+	})
+
+	t.Run("БД недоступна — не готов, миграции не проверяются", func(t *testing.T) {
+		// Arrange
+		repo := &mockHealthRepo{pingErr: assert.AnError}
+		uc := usecases.NewCheckReadinessUseCase(repo)
+
+		// Act
+		res := uc.Execute(ctx)
+
+		// Assert
+		assert.False(t, res.Ready)
+		assert.ErrorIs(t, res.DatabaseErr, assert.AnError)
+		assert.Equal(t, 0, repo.stateCalls)
+	})
+
+	cases := map[string]*mockHealthRepo{
+		"миграции не применялись":    {state: usecases.MigrationState{Latest: 3}},
+		"схема отстаёт от бинарника": {state: usecases.MigrationState{Version: 2, Latest: 3}},
+		"таблица версий недоступна":  {migrationErr: assert.AnError},
+	}
+	for name, repo := range cases {
+		t.Run(name+" — не готов", func(t *testing.T) {
+			// Arrange
+			uc := usecases.NewCheckReadinessUseCase(repo)
+
+			// Act
+			res := uc.Execute(ctx)
+
+			// Assert
+			assert.False(t, res.Ready)
+		})
+	}
+
+	t.Run("схема новее бинарника (раскатка) — готов", func(t *testing.T) {
+		// Arrange
+		uc := usecases.NewCheckReadinessUseCase(&mockHealthRepo{state: usecases.MigrationState{Version: 4, Latest: 3}})
+
+		// Act
+		res := uc.Execute(ctx)
+
+		// Assert
+		assert.True(t, res.Ready)
+	})
+
+	t.Run("после BeginShutdown — не готов", func(t *testing.T) {
+		// Arrange
+		uc := usecases.NewCheckReadinessUseCase(&mockHealthRepo{state: usecases.MigrationState{Version: 3, Latest: 3}})
+
+		// Act
+		uc.BeginShutdown()
+		res := uc.Execute(ctx)
+
+		// Assert
+		assert.False(t, res.Ready)
+		assert.True(t, res.ShuttingDown)
+	})
+}
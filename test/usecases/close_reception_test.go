@@ -14,20 +14,25 @@ import (
 type mockReceptionRepoForClose struct {
 	getActiveFn func(ctx context.Context, pvzID uuid.UUID) (*entities.Reception, error)
 	saveFn      func(ctx context.Context, reception entities.Reception) (entities.Reception, error)
+	transitions []entities.ReceptionTransition
 }
 
-func (m *mockReceptionRepoForClose) GetActive(ctx context.Context, pvzID uuid.UUID) (*entities.Reception, error) {
+func (m *mockReceptionRepoForClose) GetActiveForUpdate(ctx context.Context, pvzID uuid.UUID) (*entities.Reception, error) {
 	return m.getActiveFn(ctx, pvzID)
 }
 func (m *mockReceptionRepoForClose) Save(ctx context.Context, reception entities.Reception) (entities.Reception, error) {
 	return m.saveFn(ctx, reception)
 }
+func (m *mockReceptionRepoForClose) SaveTransition(_ context.Context, t entities.ReceptionTransition) error {
+	m.transitions = append(m.transitions, t)
+	return nil
+}
 
 func TestCloseReceptionUseCase_Execute(t *testing.T) {
 	// Arrange
 	pvzID := uuid.New()
 	rec := entities.Reception{ID: uuid.New(), PVZID: pvzID, Status: entities.ReceptionInProgress}
-	user := entities.User{Role: entities.UserRolePVZStaff}
+	user := entities.User{ID: uuid.New(), Role: entities.UserRolePVZStaff}
 
 	repo := &mockReceptionRepoForClose{
 		getActiveFn: func(ctx context.Context, id uuid.UUID) (*entities.Reception, error) {
@@ -37,7 +42,7 @@ func TestCloseReceptionUseCase_Execute(t *testing.T) {
 			return r, nil
 		},
 	}
-	uc := usecases.NewCloseReceptionUseCase(repo)
+	uc := usecases.NewCloseReceptionUseCase(repo, usecases.NopTxManager{}, testAuthz)
 	ctx := context.Background()
 
 	// Act
@@ -46,11 +51,17 @@ func TestCloseReceptionUseCase_Execute(t *testing.T) {
 	// Assert
 	require.NoError(t, err)
 	require.Equal(t, entities.ReceptionClosed, closed.Status)
+	require.NotNil(t, closed.ClosedAt)
+	require.Len(t, repo.transitions, 1)
+	assert.Equal(t, entities.ReceptionInProgress, repo.transitions[0].From)
+	assert.Equal(t, entities.ReceptionClosed, repo.transitions[0].To)
+	assert.Equal(t, user.ID, repo.transitions[0].ChangedBy)
+	assert.Equal(t, *closed.ClosedAt, repo.transitions[0].ChangedAt)
 
 	// Не pvz_staff
 	user.Role = entities.UserRoleClient
 	_, err = uc.Execute(ctx, user, pvzID)
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, usecases.ErrForbidden)
 
 	// Нет открытой приёмки
 	repo.getActiveFn = func(ctx context.Context, id uuid.UUID) (*entities.Reception, error) {
@@ -58,7 +69,7 @@ func TestCloseReceptionUseCase_Execute(t *testing.T) {
 	}
 	user.Role = entities.UserRolePVZStaff
 	_, err = uc.Execute(ctx, user, pvzID)
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, usecases.ErrNoReceptionToClose)
 
 	// Уже закрыта
 	repo.getActiveFn = func(ctx context.Context, id uuid.UUID) (*entities.Reception, error) {
@@ -66,5 +77,5 @@ func TestCloseReceptionUseCase_Execute(t *testing.T) {
 		return r, nil
 	}
 	_, err = uc.Execute(ctx, user, pvzID)
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, usecases.ErrNoOpenReception)
 }
@@ -1,12 +1,15 @@
 package usecases_test
 
 import (
+	"bytes"
 	"context"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/logger"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -20,6 +23,15 @@ func (m *mockPVZRepoForCreate) Save(ctx context.Context, pvz entities.PVZ) (enti
 	return m.saveFn(ctx, pvz)
 }
 
+// countingMetrics считает вызовы бизнес-метрик
+type countingMetrics struct {
+	pvz, receptions, products int
+}
+
+func (m *countingMetrics) IncPVZCreated()       { m.pvz++ }
+func (m *countingMetrics) IncReceptionCreated() { m.receptions++ }
+func (m *countingMetrics) IncProductAdded()     { m.products++ }
+
 func TestCreatePVZUseCase_Execute(t *testing.T) {
 	// Arrange
 	pvz := entities.PVZ{ID: uuid.New(), City: entities.CityMoscow, RegistrationDate: time.Now()}
@@ -30,22 +42,79 @@ func TestCreatePVZUseCase_Execute(t *testing.T) {
 			return pvz, nil
 		},
 	}
-	uc := usecases.NewCreatePVZUseCase(repo)
+	metrics := &countingMetrics{}
+	uc := usecases.NewCreatePVZUseCase(repo, usecases.DefaultCatalog(), metrics, testAuthz)
 	ctx := context.Background()
 
 	// Act
-	res, err := uc.Execute(ctx, user, entities.CityMoscow)
+	res, err := uc.Execute(ctx, user, entities.CityMoscow, entities.PVZDetails{})
 
 	// Assert
 	require.NoError(t, err)
 	require.Equal(t, entities.CityMoscow, res.City)
+	require.Equal(t, 1, metrics.pvz)
 
-	// Некорректный город
-	_, err = uc.Execute(ctx, user, "Тверь")
-	assert.Error(t, err)
+	// Города нет в справочнике
+	_, err = uc.Execute(ctx, user, "Тверь", entities.PVZDetails{})
+	assert.ErrorIs(t, err, usecases.ErrInvalidCity)
+	assert.ErrorIs(t, err, usecases.ErrValidation)
+
+	// Город добавлен в справочник — ПВЗ создаётся без релиза
+	withTver := usecases.NewCreatePVZUseCase(repo, usecases.StaticCatalog{entities.CatalogCity: {"Тверь"}}, metrics, testAuthz)
+	_, err = withTver.Execute(ctx, user, "Тверь", entities.PVZDetails{})
+	require.NoError(t, err)
+	require.Equal(t, 2, metrics.pvz)
 
-	// Не модератор
+	// Не модератор — отказ логируется с полями через логгер из контекста
+	var buf bytes.Buffer
+	logCtx := logger.WithContext(ctx, logger.NewWithWriter(&buf, "info", "json"))
 	user.Role = entities.UserRoleClient
-	_, err = uc.Execute(ctx, user, entities.CityMoscow)
+	_, err = uc.Execute(logCtx, user, entities.CityMoscow, entities.PVZDetails{})
+	assert.ErrorIs(t, err, usecases.ErrForbidden)
+	assert.Contains(t, buf.String(), `"msg":"permission denied"`)
+	assert.Contains(t, buf.String(), `"permission":"pvz:create"`)
+	assert.Contains(t, buf.String(), `"user_role":"client"`)
+
+	// Ошибка репозитория — метрика не увеличивается
+	user.Role = entities.UserRoleModerator
+	repo.saveFn = func(ctx context.Context, p entities.PVZ) (entities.PVZ, error) {
+		return entities.PVZ{}, assert.AnError
+	}
+	_, err = uc.Execute(ctx, user, entities.CityMoscow, entities.PVZDetails{})
 	assert.Error(t, err)
+	assert.Equal(t, 2, metrics.pvz)
+}
+
+func TestCreatePVZUseCase_Details(t *testing.T) {
+	// Arrange
+	var saved entities.PVZ
+	repo := &mockPVZRepoForCreate{
+		saveFn: func(ctx context.Context, p entities.PVZ) (entities.PVZ, error) {
+			saved = p
+			return p, nil
+		},
+	}
+	uc := usecases.NewCreatePVZUseCase(repo, usecases.DefaultCatalog(), usecases.NopMetrics{}, testAuthz)
+	ctx := context.Background()
+	moderator := entities.User{Role: entities.UserRoleModerator}
+	details := entities.PVZDetails{
+		Name:         "  ПВЗ на Тверской ",
+		Address:      "ул. Тверская, 7",
+		WorkingHours: "ежедневно 09:00–21:00",
+		Location:     &entities.GeoPoint{Lat: 55.7575, Lon: 37.6134},
+	}
+
+	// Act
+	_, err := uc.Execute(ctx, moderator, entities.CityMoscow, details)
+
+	// Assert: пробелы по краям убираются, координаты сохраняются
+	require.NoError(t, err)
+	assert.Equal(t, "ПВЗ на Тверской", saved.Name)
+	assert.Equal(t, details.Location, saved.Location)
+
+	_, err = uc.Execute(ctx, moderator, entities.CityMoscow, entities.PVZDetails{Location: &entities.GeoPoint{Lat: 91, Lon: 0}})
+	assert.ErrorIs(t, err, usecases.ErrInvalidLocation)
+	_, err = uc.Execute(ctx, moderator, entities.CityMoscow, entities.PVZDetails{Name: strings.Repeat("я", entities.PVZNameMaxLen+1)})
+	assert.ErrorIs(t, err, usecases.ErrInvalidPVZDetails)
+	assert.ErrorIs(t, err, usecases.ErrValidation)
 }
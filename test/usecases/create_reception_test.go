@@ -23,6 +23,13 @@ func (m *mockReceptionRepoForCreate) Save(ctx context.Context, reception entitie
 	return m.saveFn(ctx, reception)
 }
 
+// mockPVZRepoForReception возвращает ПВЗ по id, nil — ПВЗ не найден
+type mockPVZRepoForReception struct{ pvz *entities.PVZ }
+
+func (m *mockPVZRepoForReception) GetByIDForShare(_ context.Context, _ uuid.UUID) (*entities.PVZ, error) {
+	return m.pvz, nil
+}
+
 func TestCreateReceptionUseCase_Execute(t *testing.T) {
 	// Arrange
 	pvzID := uuid.New()
@@ -37,7 +44,9 @@ func TestCreateReceptionUseCase_Execute(t *testing.T) {
 			return rec, nil
 		},
 	}
-	uc := usecases.NewCreateReceptionUseCase(repo)
+	pvzRepo := &mockPVZRepoForReception{pvz: &entities.PVZ{ID: pvzID, City: entities.CityMoscow}}
+	metrics := &countingMetrics{}
+	uc := usecases.NewCreateReceptionUseCase(repo, pvzRepo, usecases.NopTxManager{}, metrics, testAuthz)
 	ctx := context.Background()
 
 	// Act
@@ -47,11 +56,12 @@ func TestCreateReceptionUseCase_Execute(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, rec.PVZID, result.PVZID)
 	require.Equal(t, entities.ReceptionInProgress, result.Status)
+	require.Equal(t, 1, metrics.receptions)
 
 	// Не pvz_staff
 	user.Role = entities.UserRoleClient
 	_, err = uc.Execute(ctx, user, pvzID)
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, usecases.ErrForbidden)
 
 	// Уже есть открытая приёмка
 	repo.getActiveFn = func(ctx context.Context, id uuid.UUID) (*entities.Reception, error) {
@@ -60,5 +70,31 @@ func TestCreateReceptionUseCase_Execute(t *testing.T) {
 	}
 	user.Role = entities.UserRolePVZStaff
 	_, err = uc.Execute(ctx, user, pvzID)
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, usecases.ErrReceptionAlreadyOpen)
+	assert.ErrorIs(t, err, usecases.ErrConflict)
+	assert.Equal(t, 1, metrics.receptions)
+
+	// Сотрудник не назначен на ПВЗ
+	repo.getActiveFn = func(ctx context.Context, id uuid.UUID) (*entities.Reception, error) {
+		return nil, nil
+	}
+	notAssignedUC := usecases.NewCreateReceptionUseCase(repo, pvzRepo, usecases.NopTxManager{}, metrics, usecases.NewAuthorizer(entities.DefaultRolePermissions(), noneAssigned))
+	_, err = notAssignedUC.Execute(ctx, user, pvzID)
+	assert.ErrorIs(t, err, usecases.ErrNotAssignedToPVZ)
+	assert.ErrorIs(t, err, usecases.ErrForbidden)
+	assert.Equal(t, 1, metrics.receptions)
+
+	// ПВЗ в архиве
+	archivedAt := entities.NowUTC()
+	pvzRepo.pvz.ArchivedAt = &archivedAt
+	_, err = uc.Execute(ctx, user, pvzID)
+	assert.ErrorIs(t, err, usecases.ErrPVZArchived)
+	assert.ErrorIs(t, err, usecases.ErrConflict)
+
+	// ПВЗ не найден
+	pvzRepo.pvz = nil
+	_, err = uc.Execute(ctx, user, pvzID)
+	assert.ErrorIs(t, err, usecases.ErrPVZNotFound)
+	assert.ErrorIs(t, err, usecases.ErrNotFound)
+	assert.Equal(t, 1, metrics.receptions)
 }
@@ -24,7 +24,7 @@ type mockReceptionRepoForDelete struct {
 	getActiveFn func(ctx context.Context, pvzID uuid.UUID) (*entities.Reception, error)
 }
 
-func (m *mockReceptionRepoForDelete) GetActive(ctx context.Context, pvzID uuid.UUID) (*entities.Reception, error) {
+func (m *mockReceptionRepoForDelete) GetActiveForUpdate(ctx context.Context, pvzID uuid.UUID) (*entities.Reception, error) {
 	return m.getActiveFn(ctx, pvzID)
 }
 
@@ -71,7 +71,7 @@ func TestDeleteLastProductUseCase_Execute(t *testing.T) {
 		},
 	}
 
-	uc := usecases.NewDeleteLastProductUseCase(productRepo, receptionRepo)
+	uc := usecases.NewDeleteLastProductUseCase(productRepo, receptionRepo, usecases.NopTxManager{}, testAuthz)
 	ctx := context.Background()
 
 	// Act
@@ -80,7 +80,7 @@ func TestDeleteLastProductUseCase_Execute(t *testing.T) {
 	// Assert
 	require.NoError(t, err)
 
-	// Проверка, что метод GetActive вызывается корректно
+	// Проверка, что метод GetActiveForUpdate вызывается корректно
 	receptionRepo.getActiveFn = func(ctx context.Context, id uuid.UUID) (*entities.Reception, error) {
 		assert.Equal(t, pvzID, id)
 		return activeReception, nil
@@ -99,7 +99,8 @@ func TestDeleteLastProductUseCase_Execute(t *testing.T) {
 	user.Role = entities.UserRoleClient
 	err = uc.Execute(ctx, user, pvzID)
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "только сотрудник ПВЗ может удалять товары")
+	assert.Contains(t, err.Error(), "product:delete")
+	assert.ErrorIs(t, err, usecases.ErrForbidden)
 
 	// Тест: Нет открытой приёмки
 	user.Role = entities.UserRolePVZStaff
@@ -109,6 +110,7 @@ func TestDeleteLastProductUseCase_Execute(t *testing.T) {
 	err = uc.Execute(ctx, user, pvzID)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "нет открытой приёмки")
+	assert.ErrorIs(t, err, usecases.ErrNoOpenReception)
 
 	// Тест: Закрытая приемка
 	closedReception := &entities.Reception{
@@ -142,4 +144,5 @@ func TestDeleteLastProductUseCase_Execute(t *testing.T) {
 	err = uc.Execute(ctx, user, pvzID)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "нет товаров для удаления")
+	assert.ErrorIs(t, err, usecases.ErrNotFound)
 }
@@ -0,0 +1,133 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockProductRepoForDeleteByID struct {
+	products map[uuid.UUID]entities.Product
+	removals []entities.ProductRemoval
+	deleted  []uuid.UUID
+}
+
+func (m *mockProductRepoForDeleteByID) GetByID(_ context.Context, id uuid.UUID) (*entities.Product, error) {
+	p, ok := m.products[id]
+	if !ok {
+		return nil, nil
+	}
+	return &p, nil
+}
+
+func (m *mockProductRepoForDeleteByID) Delete(_ context.Context, id uuid.UUID) error {
+	delete(m.products, id)
+	m.deleted = append(m.deleted, id)
+	return nil
+}
+
+func (m *mockProductRepoForDeleteByID) SaveRemoval(_ context.Context, removal entities.ProductRemoval) error {
+	m.removals = append(m.removals, removal)
+	return nil
+}
+
+type mockReceptionRepoForDeleteByID struct {
+	receptions map[uuid.UUID]entities.Reception
+}
+
+func (m *mockReceptionRepoForDeleteByID) GetByIDForUpdate(_ context.Context, id uuid.UUID) (*entities.Reception, error) {
+	rec, ok := m.receptions[id]
+	if !ok {
+		return nil, nil
+	}
+	return &rec, nil
+}
+
+func TestDeleteProductUseCase_Execute(t *testing.T) {
+	staff := entities.User{ID: uuid.New(), Role: entities.UserRolePVZStaff, Email: "staff@avito.ru"}
+	open := entities.Reception{ID: uuid.New(), PVZID: uuid.New(), Status: entities.ReceptionInProgress, DateTime: time.Now().UTC()}
+	closed := entities.Reception{ID: uuid.New(), PVZID: open.PVZID, Status: entities.ReceptionClosed, DateTime: time.Now().UTC()}
+
+	setup := func() (*usecases.DeleteProductUseCase, *mockProductRepoForDeleteByID, entities.Product, entities.Product) {
+		inOpen := entities.Product{ID: uuid.New(), ReceptionID: open.ID, Type: entities.ProductShoes, Position: 1, Barcode: "4601234567893"}
+		inClosed := entities.Product{ID: uuid.New(), ReceptionID: closed.ID, Type: entities.ProductClothes, Position: 1}
+		products := &mockProductRepoForDeleteByID{products: map[uuid.UUID]entities.Product{inOpen.ID: inOpen, inClosed.ID: inClosed}}
+		receptions := &mockReceptionRepoForDeleteByID{receptions: map[uuid.UUID]entities.Reception{open.ID: open, closed.ID: closed}}
+		return usecases.NewDeleteProductUseCase(products, receptions, usecases.NopTxManager{}, testAuthz), products, inOpen, inClosed
+	}
+
+	t.Run("удаляет товар и записывает, кто его удалил", func(t *testing.T) {
+		// Arrange
+		uc, products, product, _ := setup()
+
+		// Act
+		removal, err := uc.Execute(context.Background(), staff, open.ID, product.ID)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, product, removal.Product)
+		assert.Equal(t, staff.ID, removal.RemovedBy)
+		assert.Equal(t, staff.Email, removal.RemovedByEmail)
+		assert.NotEqual(t, uuid.Nil, removal.ID)
+		assert.WithinDuration(t, time.Now(), removal.RemovedAt, time.Minute)
+		assert.Equal(t, []uuid.UUID{product.ID}, products.deleted)
+		assert.Equal(t, []entities.ProductRemoval{removal}, products.removals)
+	})
+
+	t.Run("не сотрудник ПВЗ — запрещено", func(t *testing.T) {
+		// Arrange
+		uc, products, product, _ := setup()
+		moderator := entities.User{ID: uuid.New(), Role: entities.UserRoleModerator}
+
+		// Act
+		_, err := uc.Execute(context.Background(), moderator, open.ID, product.ID)
+
+		// Assert
+		require.ErrorIs(t, err, usecases.ErrForbidden)
+		assert.Empty(t, products.deleted)
+	})
+
+	t.Run("приёмка закрыта", func(t *testing.T) {
+		// Arrange
+		uc, products, _, product := setup()
+
+		// Act
+		_, err := uc.Execute(context.Background(), staff, closed.ID, product.ID)
+
+		// Assert
+		require.ErrorIs(t, err, usecases.ErrReceptionClosed)
+		assert.Empty(t, products.deleted)
+		assert.Empty(t, products.removals)
+	})
+
+	t.Run("приёмка не найдена", func(t *testing.T) {
+		// Arrange
+		uc, _, product, _ := setup()
+
+		// Act
+		_, err := uc.Execute(context.Background(), staff, uuid.New(), product.ID)
+
+		// Assert
+		require.ErrorIs(t, err, usecases.ErrReceptionNotFound)
+	})
+
+	t.Run("товар из другой приёмки или несуществующий — не найден", func(t *testing.T) {
+		// Arrange
+		uc, products, _, foreign := setup()
+
+		// Act
+		_, errForeign := uc.Execute(context.Background(), staff, open.ID, foreign.ID)
+		_, errMissing := uc.Execute(context.Background(), staff, open.ID, uuid.New())
+
+		// Assert
+		require.ErrorIs(t, errForeign, usecases.ErrProductNotFound)
+		require.ErrorIs(t, errMissing, usecases.ErrProductNotFound)
+		assert.Empty(t, products.deleted)
+	})
+}
@@ -3,18 +3,32 @@ package usecases_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/configs"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
 	"github.com/stretchr/testify/require"
 )
 
+// memDummyUsers запоминает сохранённых тестовых пользователей по id
+type memDummyUsers map[uuid.UUID]entities.User
+
+func (m memDummyUsers) EnsureUser(_ context.Context, user entities.User) error {
+	if _, ok := m[user.ID]; !ok {
+		m[user.ID] = user
+	}
+	return nil
+}
+
 func TestDummyLoginUseCase_Execute(t *testing.T) {
 	// Arrange
-	cfg := &configs.Config{JWTSecret: "testsecret"}
-	uc := usecases.NewDummyLoginUseCase(cfg)
+	cfg := &configs.Config{JWTSecret: "testsecret", AccessTokenTTL: 15 * time.Minute, RefreshTokenTTL: time.Hour}
+	sessions := newMemSessionRepo()
+	users := memDummyUsers{}
+	uc := usecases.NewDummyLoginUseCase(users, usecases.NewTokenIssuer(sessions, cfg))
 	ctx := context.Background()
 
 	tests := []struct {
@@ -31,7 +45,7 @@ func TestDummyLoginUseCase_Execute(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Act
-			tokenStr, err := uc.Execute(ctx, tt.role)
+			pair, err := uc.Execute(ctx, tt.role)
 
 			// Assert
 			if tt.wantErr {
@@ -39,10 +53,11 @@ func TestDummyLoginUseCase_Execute(t *testing.T) {
 				return
 			}
 			require.NoError(t, err)
-			require.NotEmpty(t, tokenStr)
+			require.NotEmpty(t, pair.AccessToken)
+			require.Equal(t, tt.role, sessions.byToken(t, pair.RefreshToken).Role)
 
 			// Проверяем, что токен валиден и роль совпадает
-			parsed, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
+			parsed, err := jwt.Parse(pair.AccessToken, func(token *jwt.Token) (interface{}, error) {
 				return []byte(cfg.JWTSecret), nil
 			})
 			require.NoError(t, err)
@@ -58,6 +73,14 @@ func TestDummyLoginUseCase_Execute(t *testing.T) {
 			_, hasIat := claims["iat"]
 			require.True(t, hasExp)
 			require.True(t, hasIat)
+			// userId в ответе совпадает с sub токена, пользователь сохранён с ролью
+			require.Equal(t, pair.UserID.String(), claims["sub"])
+			require.Equal(t, tt.role, users[pair.UserID].Role)
+
+			// У роли один пользователь: повторный вход — тот же id
+			again, err := uc.Execute(ctx, tt.role)
+			require.NoError(t, err)
+			require.Equal(t, pair.UserID, again.UserID)
 		})
 	}
 }
@@ -0,0 +1,61 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockPVZRepoForNearest struct {
+	calls  int
+	radius float64
+	limit  int
+}
+
+func (m *mockPVZRepoForNearest) Nearest(_ context.Context, _ entities.GeoPoint, radius float64, limit int) ([]entities.NearestPVZ, error) {
+	m.calls++
+	m.radius, m.limit = radius, limit
+	return []entities.NearestPVZ{{Distance: 120}}, nil
+}
+
+func TestFindNearestPVZsUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	point := entities.GeoPoint{Lat: 55.7558, Lon: 37.6173}
+
+	t.Run("клиент ищет ближайшие ПВЗ", func(t *testing.T) {
+		// Arrange
+		repo := &mockPVZRepoForNearest{}
+		uc := usecases.NewFindNearestPVZsUseCase(repo, testAuthz)
+
+		// Act
+		res, err := uc.Execute(ctx, entities.User{Role: entities.UserRoleClient}, point, 3000, 5)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, 3000.0, repo.radius)
+		assert.Equal(t, 5, repo.limit)
+	})
+
+	t.Run("некорректные параметры не доходят до репозитория", func(t *testing.T) {
+		// Arrange
+		repo := &mockPVZRepoForNearest{}
+		uc := usecases.NewFindNearestPVZsUseCase(repo, testAuthz)
+		staff := entities.User{Role: entities.UserRolePVZStaff}
+
+		// Act & Assert
+		_, err := uc.Execute(ctx, staff, entities.GeoPoint{Lat: -91, Lon: 0}, 3000, 5)
+		assert.ErrorIs(t, err, usecases.ErrInvalidLocation)
+		_, err = uc.Execute(ctx, staff, point, 0, 5)
+		assert.ErrorIs(t, err, usecases.ErrInvalidRadius)
+		_, err = uc.Execute(ctx, staff, point, usecases.NearestMaxRadius+1, 5)
+		assert.ErrorIs(t, err, usecases.ErrInvalidRadius)
+		_, err = uc.Execute(ctx, entities.User{Role: "admin"}, point, 3000, 5)
+		assert.ErrorIs(t, err, usecases.ErrForbidden)
+		assert.Zero(t, repo.calls)
+	})
+}
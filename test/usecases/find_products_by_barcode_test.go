@@ -0,0 +1,57 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockProductRepoForBarcode struct {
+	barcode string
+	calls   int
+}
+
+func (m *mockProductRepoForBarcode) FindByBarcode(_ context.Context, barcode string) ([]entities.ProductLocation, error) {
+	m.calls++
+	m.barcode = barcode
+	return []entities.ProductLocation{{Product: entities.Product{ID: uuid.New(), Barcode: barcode}}}, nil
+}
+
+func TestFindProductsByBarcodeUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("модератор ищет посылку по штрихкоду", func(t *testing.T) {
+		// Arrange
+		repo := &mockProductRepoForBarcode{}
+		uc := usecases.NewFindProductsByBarcodeUseCase(repo, testAuthz)
+
+		// Act
+		res, err := uc.Execute(ctx, entities.User{Role: entities.UserRoleModerator}, " 4601234567893 ")
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, "4601234567893", repo.barcode)
+	})
+
+	t.Run("пустой или некорректный штрихкод и чужая роль не доходят до репозитория", func(t *testing.T) {
+		// Arrange
+		repo := &mockProductRepoForBarcode{}
+		uc := usecases.NewFindProductsByBarcodeUseCase(repo, testAuthz)
+		staff := entities.User{Role: entities.UserRolePVZStaff}
+
+		// Act & Assert
+		_, err := uc.Execute(ctx, staff, "  ")
+		assert.ErrorIs(t, err, usecases.ErrBarcodeRequired)
+		_, err = uc.Execute(ctx, staff, "штрихкод")
+		assert.ErrorIs(t, err, usecases.ErrInvalidBarcode)
+		_, err = uc.Execute(ctx, entities.User{Role: entities.UserRoleClient}, "4601234567893")
+		assert.ErrorIs(t, err, usecases.ErrForbidden)
+		assert.Zero(t, repo.calls)
+	})
+}
@@ -0,0 +1,73 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockReceptionRepoForGet хранит одну приёмку; nil — приёмка не найдена
+type mockReceptionRepoForGet struct{ rec *entities.Reception }
+
+func (m *mockReceptionRepoForGet) GetByID(_ context.Context, _ uuid.UUID) (*entities.Reception, error) {
+	return m.rec, nil
+}
+
+type mockProductRepoForList struct {
+	products []entities.Product
+	calls    int
+}
+
+func (m *mockProductRepoForList) ListByReception(_ context.Context, _ uuid.UUID) ([]entities.Product, error) {
+	m.calls++
+	return m.products, nil
+}
+
+func TestGetReceptionUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	rec := entities.Reception{ID: uuid.New(), PVZID: uuid.New(), Status: entities.ReceptionClosed, DateTime: time.Now()}
+	products := []entities.Product{{ID: uuid.New(), ReceptionID: rec.ID, Type: entities.ProductShoes, Position: 1}}
+
+	t.Run("модератор получает приёмку с товарами", func(t *testing.T) {
+		// Arrange
+		uc := usecases.NewGetReceptionUseCase(&mockReceptionRepoForGet{rec: &rec}, &mockProductRepoForList{products: products}, testAuthz)
+
+		// Act
+		res, err := uc.Execute(ctx, entities.User{Role: entities.UserRoleModerator}, rec.ID)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, rec.ID, res.Reception.ID)
+		assert.Equal(t, products, res.Products)
+	})
+
+	t.Run("приёмка не найдена", func(t *testing.T) {
+		// Arrange
+		productRepo := &mockProductRepoForList{}
+		uc := usecases.NewGetReceptionUseCase(&mockReceptionRepoForGet{}, productRepo, testAuthz)
+
+		// Act
+		_, err := uc.Execute(ctx, entities.User{Role: entities.UserRolePVZStaff}, uuid.New())
+
+		// Assert
+		assert.ErrorIs(t, err, usecases.ErrReceptionNotFound)
+		assert.Zero(t, productRepo.calls)
+	})
+
+	t.Run("клиенту запрещено", func(t *testing.T) {
+		// Arrange
+		uc := usecases.NewGetReceptionUseCase(&mockReceptionRepoForGet{rec: &rec}, &mockProductRepoForList{products: products}, testAuthz)
+
+		// Act
+		_, err := uc.Execute(ctx, entities.User{Role: entities.UserRoleClient}, rec.ID)
+
+		// Assert
+		assert.ErrorIs(t, err, usecases.ErrForbidden)
+	})
+}
@@ -9,114 +9,71 @@ import (
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
 	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
-// Моки для тестирования Execute
 type mockPVZRepoForList struct {
-	listFn func(ctx context.Context, startDate, endDate *time.Time, page, limit int) ([]entities.PVZ, error)
+	listFn func(ctx context.Context, startDate, endDate *time.Time, page, limit int) ([]entities.PVZWithReceptions, error)
+	calls  int
 }
 
-func (m *mockPVZRepoForList) List(ctx context.Context, startDate, endDate *time.Time, page, limit int) ([]entities.PVZ, error) {
+func (m *mockPVZRepoForList) ListWithReceptions(ctx context.Context, startDate, endDate *time.Time, page, limit int, _ bool) ([]entities.PVZWithReceptions, error) {
+	m.calls++
 	return m.listFn(ctx, startDate, endDate, page, limit)
 }
 
-// Моки для тестирования GetReceptionsByPVZ и GetProductsByReception
-type mockReceptionRepoForList struct{ mock.Mock }
-
-func (m *mockReceptionRepoForList) ListByPVZ(ctx context.Context, pvzID uuid.UUID) ([]entities.Reception, error) {
-	args := m.Called(ctx, pvzID)
-	return args.Get(0).([]entities.Reception), args.Error(1)
-}
-
-// остальные методы не нужны для этого теста
-func (m *mockReceptionRepoForList) Save(context.Context, entities.Reception) (entities.Reception, error) {
-	return entities.Reception{}, nil
-}
-func (m *mockReceptionRepoForList) CloseLast(context.Context, uuid.UUID) (entities.Reception, error) {
-	return entities.Reception{}, nil
-}
-func (m *mockReceptionRepoForList) GetActive(context.Context, uuid.UUID) (*entities.Reception, error) {
-	return nil, nil
-}
-
-type mockProductRepoForList struct{ mock.Mock }
-
-func (m *mockProductRepoForList) ListByReception(ctx context.Context, receptionID uuid.UUID) ([]entities.Product, error) {
-	args := m.Called(ctx, receptionID)
-	return args.Get(0).([]entities.Product), args.Error(1)
-}
-
-// остальные методы не нужны для этого теста
-func (m *mockProductRepoForList) Save(context.Context, entities.Product) (entities.Product, error) {
-	return entities.Product{}, nil
-}
-func (m *mockProductRepoForList) DeleteLast(context.Context, uuid.UUID) error { return nil }
-
 func TestListPVZsUseCase_Execute(t *testing.T) {
 	// Arrange
 	pvz := entities.PVZ{ID: uuid.New(), City: entities.CityMoscow}
+	rec := entities.Reception{ID: uuid.New(), PVZID: pvz.ID}
 	user := entities.User{Role: entities.UserRolePVZStaff}
 
 	repo := &mockPVZRepoForList{
-		listFn: func(ctx context.Context, startDate, endDate *time.Time, page, limit int) ([]entities.PVZ, error) {
-			return []entities.PVZ{pvz}, nil
+		listFn: func(ctx context.Context, startDate, endDate *time.Time, page, limit int) ([]entities.PVZWithReceptions, error) {
+			return []entities.PVZWithReceptions{{
+				PVZ:        pvz,
+				Receptions: []entities.ReceptionWithProducts{{Reception: rec}},
+			}}, nil
 		},
 	}
-	uc := usecases.NewListPVZsUseCase(repo, nil, nil)
+	uc := usecases.NewListPVZsUseCase(repo, testAuthz)
 	ctx := context.Background()
 
 	// Act
-	res, err := uc.Execute(ctx, user, nil, nil, 1, 10)
+	res, err := uc.Execute(ctx, user, nil, nil, 1, 10, false)
 
 	// Assert
 	require.NoError(t, err)
 	require.Len(t, res, 1)
-	require.Equal(t, pvz.ID, res[0].ID)
+	require.Equal(t, pvz.ID, res[0].PVZ.ID)
+	require.Len(t, res[0].Receptions, 1)
+	require.Equal(t, rec.ID, res[0].Receptions[0].Reception.ID)
 
 	// Модератор тоже может
 	user.Role = entities.UserRoleModerator
-	res, err = uc.Execute(ctx, user, nil, nil, 1, 10)
+	res, err = uc.Execute(ctx, user, nil, nil, 1, 10, false)
 	require.NoError(t, err)
 	require.Len(t, res, 1)
 
-	// Не staff/moderator
+	// Не staff/moderator — репозиторий не вызывается
 	user.Role = "hacker"
-	_, err = uc.Execute(ctx, user, nil, nil, 1, 10)
-	assert.Error(t, err)
-}
-
-func TestListPVZsUseCase_GetReceptionsByPVZ(t *testing.T) {
-	ctx := context.Background()
-	pvzID := uuid.New()
-	recs := []entities.Reception{{ID: uuid.New()}, {ID: uuid.New()}}
-	repo := new(mockReceptionRepoForList)
-	repo.On("ListByPVZ", ctx, pvzID).Return(recs, nil)
-	uc := usecases.NewListPVZsUseCase(nil, repo, nil)
-
-	// Act
-	got, err := uc.GetReceptionsByPVZ(ctx, pvzID)
-
-	// Assert
-	require.NoError(t, err)
-	require.Equal(t, recs, got)
-	repo.AssertExpectations(t)
+	_, err = uc.Execute(ctx, user, nil, nil, 1, 10, false)
+	assert.ErrorIs(t, err, usecases.ErrForbidden)
+	assert.Equal(t, 2, repo.calls)
 }
 
-func TestListPVZsUseCase_GetProductsByReception(t *testing.T) {
-	ctx := context.Background()
-	recID := uuid.New()
-	products := []entities.Product{{ID: uuid.New()}, {ID: uuid.New()}}
-	repo := new(mockProductRepoForList)
-	repo.On("ListByReception", ctx, recID).Return(products, nil)
-	uc := usecases.NewListPVZsUseCase(nil, nil, repo)
+func TestListPVZsUseCase_Execute_RepoError(t *testing.T) {
+	// Arrange
+	repo := &mockPVZRepoForList{
+		listFn: func(ctx context.Context, startDate, endDate *time.Time, page, limit int) ([]entities.PVZWithReceptions, error) {
+			return nil, assert.AnError
+		},
+	}
+	uc := usecases.NewListPVZsUseCase(repo, testAuthz)
 
 	// Act
-	got, err := uc.GetProductsByReception(ctx, recID)
+	_, err := uc.Execute(context.Background(), entities.User{Role: entities.UserRoleModerator}, nil, nil, 1, 10, false)
 
 	// Assert
-	require.NoError(t, err)
-	require.Equal(t, products, got)
-	repo.AssertExpectations(t)
+	assert.ErrorIs(t, err, assert.AnError)
 }
@@ -0,0 +1,74 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockReceptionRepoForHistory struct {
+	calls  int
+	filter usecases.ReceptionFilter
+}
+
+func (m *mockReceptionRepoForHistory) ListByPVZ(_ context.Context, pvzID uuid.UUID, filter usecases.ReceptionFilter) ([]entities.Reception, error) {
+	m.calls++
+	m.filter = filter
+	return []entities.Reception{{ID: uuid.New(), PVZID: pvzID}}, nil
+}
+
+func TestListReceptionsUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	staff := entities.User{Role: entities.UserRolePVZStaff}
+
+	t.Run("сотрудник получает историю архивного ПВЗ, фильтр передаётся в репозиторий", func(t *testing.T) {
+		// Arrange
+		pvz := entities.PVZ{ID: uuid.New()}
+		pvz.Archive(pvz.RegistrationDate)
+		repo := &mockReceptionRepoForHistory{}
+		uc := usecases.NewListReceptionsUseCase(&mockPVZRepoForUpdate{pvz: &pvz}, repo, testAuthz)
+		status := entities.ReceptionClosed
+		filter := usecases.ReceptionFilter{Status: &status, Page: 2, Limit: 5}
+
+		// Act
+		res, err := uc.Execute(ctx, staff, pvz.ID, filter)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, filter, repo.filter)
+	})
+
+	t.Run("ПВЗ не найден", func(t *testing.T) {
+		// Arrange
+		repo := &mockReceptionRepoForHistory{}
+		uc := usecases.NewListReceptionsUseCase(&mockPVZRepoForUpdate{}, repo, testAuthz)
+
+		// Act
+		_, err := uc.Execute(ctx, staff, uuid.New(), usecases.ReceptionFilter{})
+
+		// Assert
+		assert.ErrorIs(t, err, usecases.ErrPVZNotFound)
+		assert.Zero(t, repo.calls)
+	})
+
+	t.Run("неизвестный статус и чужая роль не доходят до репозитория", func(t *testing.T) {
+		// Arrange
+		pvz := entities.PVZ{ID: uuid.New()}
+		repo := &mockReceptionRepoForHistory{}
+		uc := usecases.NewListReceptionsUseCase(&mockPVZRepoForUpdate{pvz: &pvz}, repo, testAuthz)
+		status := entities.ReceptionStatus("draft")
+
+		// Act & Assert
+		_, err := uc.Execute(ctx, staff, pvz.ID, usecases.ReceptionFilter{Status: &status})
+		assert.ErrorIs(t, err, usecases.ErrInvalidReceptionStatus)
+		_, err = uc.Execute(ctx, entities.User{Role: entities.UserRoleClient}, pvz.ID, usecases.ReceptionFilter{})
+		assert.ErrorIs(t, err, usecases.ErrForbidden)
+		assert.Zero(t, repo.calls)
+	})
+}
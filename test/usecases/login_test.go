@@ -25,7 +25,8 @@ func (m *mockUserRepoForLogin) GetByEmail(ctx context.Context, email string) (*e
 
 func TestLoginUseCase_Execute(t *testing.T) {
 	// Arrange
-	cfg := &configs.Config{JWTSecret: "testsecret"}
+	cfg := &configs.Config{JWTSecret: "testsecret", AccessTokenTTL: 15 * time.Minute, RefreshTokenTTL: time.Hour}
+	sessions := newMemSessionRepo()
 	user := &entities.User{ID: uuid.New(), Email: "test@avito.ru", Role: entities.UserRoleModerator, RegistrationDate: time.Now()}
 	password := "password123"
 	hash, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -38,21 +39,21 @@ func TestLoginUseCase_Execute(t *testing.T) {
 			return nil, "", nil
 		},
 	}
-	uc := usecases.NewLoginUseCase(repo, cfg)
+	uc := usecases.NewLoginUseCase(repo, usecases.NewTokenIssuer(sessions, cfg))
 	ctx := context.Background()
 
 	tests := []struct {
 		name     string
 		email    string
 		password string
-		wantErr  bool
+		wantErr  error
 		checkJWT bool
 	}{
-		{"ok", user.Email, password, false, true},
-		{"wrong password", user.Email, "wrongpass", true, false},
-		{"not found", "notfound@avito.ru", password, true, false},
-		{"empty email", "", password, true, false},
-		{"empty password", user.Email, "", true, false},
+		{"ok", user.Email, password, nil, true},
+		{"wrong password", user.Email, "wrongpass", usecases.ErrInvalidCredentials, false},
+		{"not found", "notfound@avito.ru", password, usecases.ErrInvalidCredentials, false},
+		{"empty email", "", password, usecases.ErrCredentialsRequired, false},
+		{"empty password", user.Email, "", usecases.ErrCredentialsRequired, false},
 	}
 
 	for _, tt := range tests {
@@ -61,15 +62,17 @@ func TestLoginUseCase_Execute(t *testing.T) {
 			token, err := uc.Execute(ctx, tt.email, tt.password)
 
 			// Assert
-			if tt.wantErr {
-				assert.Error(t, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
 				return
 			}
 			require.NoError(t, err)
-			require.NotEmpty(t, token)
+			require.NotEmpty(t, token.AccessToken)
+			require.NotEmpty(t, token.RefreshToken)
+			assert.Equal(t, 15*time.Minute, token.ExpiresIn)
 
 			if tt.checkJWT {
-				parsed, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
+				parsed, err := jwt.Parse(token.AccessToken, func(token *jwt.Token) (interface{}, error) {
 					return []byte(cfg.JWTSecret), nil
 				})
 				require.NoError(t, err)
@@ -77,6 +80,10 @@ func TestLoginUseCase_Execute(t *testing.T) {
 				require.True(t, ok)
 				assert.Equal(t, user.Email, claims["email"])
 				assert.Equal(t, string(user.Role), claims["role"])
+				assert.Equal(t, user.ID.String(), claims["sub"])
+				session := sessions.byToken(t, token.RefreshToken)
+				assert.Equal(t, session.ID.String(), claims["sid"])
+				assert.Equal(t, user.ID, session.UserID)
 			}
 		})
 	}
@@ -0,0 +1,189 @@
+package usecases_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/configs"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memSessionRepo — сессии и refresh-токены в памяти
+type memSessionRepo struct {
+	mu       sync.Mutex
+	sessions map[uuid.UUID]entities.Session
+	tokens   map[string]entities.RefreshToken
+}
+
+func newMemSessionRepo() *memSessionRepo {
+	return &memSessionRepo{sessions: map[uuid.UUID]entities.Session{}, tokens: map[string]entities.RefreshToken{}}
+}
+
+func (m *memSessionRepo) CreateSession(_ context.Context, s entities.Session) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sessions[s.ID] = s
+	return nil
+}
+
+func (m *memSessionRepo) SaveRefreshToken(_ context.Context, t entities.RefreshToken) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tokens[t.Hash] = t
+	return nil
+}
+
+func (m *memSessionRepo) GetRefreshTokenForUpdate(_ context.Context, hash string) (*entities.RefreshToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t, ok := m.tokens[hash]
+	if !ok {
+		return nil, nil
+	}
+	return &t, nil
+}
+
+func (m *memSessionRepo) MarkRefreshTokenUsed(_ context.Context, hash string, usedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t := m.tokens[hash]
+	t.UsedAt = &usedAt
+	m.tokens[hash] = t
+	return nil
+}
+
+func (m *memSessionRepo) GetSession(_ context.Context, id uuid.UUID) (*entities.Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.sessions[id]
+	if !ok {
+		return nil, nil
+	}
+	return &s, nil
+}
+
+func (m *memSessionRepo) RevokeSession(_ context.Context, id uuid.UUID, revokedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if s, ok := m.sessions[id]; ok && s.RevokedAt == nil {
+		s.RevokedAt = &revokedAt
+		m.sessions[id] = s
+	}
+	return nil
+}
+
+func (m *memSessionRepo) RevokeUserSessions(_ context.Context, userID uuid.UUID, revokedAt time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var revoked int64
+	for id, s := range m.sessions {
+		if s.UserID == userID && s.RevokedAt == nil {
+			s.RevokedAt = &revokedAt
+			m.sessions[id] = s
+			revoked++
+		}
+	}
+	return revoked, nil
+}
+
+// byToken возвращает сессию, которой выдан refresh-токен
+func (m *memSessionRepo) byToken(t *testing.T, refreshToken string) entities.Session {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	token, ok := m.tokens[usecases.HashRefreshToken(refreshToken)]
+	require.True(t, ok, "refresh-токен не сохранён")
+	return m.sessions[token.SessionID]
+}
+
+func TestRefreshTokenUseCase_Execute(t *testing.T) {
+	cfg := &configs.Config{JWTSecret: "testsecret", AccessTokenTTL: 15 * time.Minute, RefreshTokenTTL: time.Hour}
+	user := entities.User{ID: uuid.New(), Email: "staff@avito.ru", Role: entities.UserRolePVZStaff}
+	setup := func() (*usecases.RefreshTokenUseCase, *memSessionRepo, usecases.TokenPair) {
+		sessions := newMemSessionRepo()
+		issuer := usecases.NewTokenIssuer(sessions, cfg)
+		pair, err := issuer.StartSession(context.Background(), user)
+		require.NoError(t, err)
+		return usecases.NewRefreshTokenUseCase(sessions, issuer, usecases.NopTxManager{}), sessions, pair
+	}
+
+	t.Run("обмен выдаёт новую пару в той же сессии", func(t *testing.T) {
+		// Arrange
+		uc, sessions, pair := setup()
+		session := sessions.byToken(t, pair.RefreshToken)
+
+		// Act
+		next, err := uc.Execute(context.Background(), pair.RefreshToken)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotEqual(t, pair.RefreshToken, next.RefreshToken)
+		assert.Equal(t, session.ID, sessions.byToken(t, next.RefreshToken).ID)
+		parsed, err := jwt.Parse(next.AccessToken, func(token *jwt.Token) (interface{}, error) {
+			return []byte(cfg.JWTSecret), nil
+		})
+		require.NoError(t, err)
+		claims := parsed.Claims.(jwt.MapClaims)
+		assert.Equal(t, session.ID.String(), claims["sid"])
+		assert.Equal(t, user.ID.String(), claims["sub"])
+		assert.Equal(t, string(user.Role), claims["role"])
+	})
+
+	t.Run("повторный обмен отзывает сессию", func(t *testing.T) {
+		// Arrange
+		uc, sessions, pair := setup()
+		next, err := uc.Execute(context.Background(), pair.RefreshToken)
+		require.NoError(t, err)
+
+		// Act
+		_, errReuse := uc.Execute(context.Background(), pair.RefreshToken)
+		_, errNext := uc.Execute(context.Background(), next.RefreshToken)
+
+		// Assert
+		require.ErrorIs(t, errReuse, usecases.ErrSessionRevoked)
+		require.ErrorIs(t, errNext, usecases.ErrSessionRevoked)
+		session := sessions.byToken(t, pair.RefreshToken)
+		assert.True(t, session.IsRevoked())
+	})
+
+	t.Run("неизвестный, пустой и истёкший токен", func(t *testing.T) {
+		// Arrange
+		uc, sessions, pair := setup()
+		hash := usecases.HashRefreshToken(pair.RefreshToken)
+		expired := sessions.tokens[hash]
+		expired.ExpiresAt = time.Now().Add(-time.Second)
+		sessions.tokens[hash] = expired
+
+		// Act
+		_, errUnknown := uc.Execute(context.Background(), "garbage")
+		_, errEmpty := uc.Execute(context.Background(), "")
+		_, errExpired := uc.Execute(context.Background(), pair.RefreshToken)
+
+		// Assert
+		require.ErrorIs(t, errUnknown, usecases.ErrInvalidRefreshToken)
+		require.ErrorIs(t, errEmpty, usecases.ErrRefreshTokenRequired)
+		require.ErrorIs(t, errExpired, usecases.ErrInvalidRefreshToken)
+	})
+
+	t.Run("после выхода обмен невозможен", func(t *testing.T) {
+		// Arrange
+		uc, sessions, pair := setup()
+		session := sessions.byToken(t, pair.RefreshToken)
+
+		// Act
+		errLogout := usecases.NewLogoutUseCase(sessions).Execute(context.Background(), session.ID)
+		errAgain := usecases.NewLogoutUseCase(sessions).Execute(context.Background(), session.ID)
+		_, err := uc.Execute(context.Background(), pair.RefreshToken)
+
+		// Assert
+		require.NoError(t, errLogout)
+		require.NoError(t, errAgain)
+		require.ErrorIs(t, err, usecases.ErrSessionRevoked)
+	})
+}
@@ -50,18 +50,19 @@ func TestRegisterUseCase_Execute(t *testing.T) {
 		return &user, nil
 	}
 	_, err = uc.Execute(ctx, "test@avito.ru", "password", entities.UserRoleClient)
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, usecases.ErrEmailTaken)
+	assert.ErrorIs(t, err, usecases.ErrConflict)
 
 	// Некорректная роль
 	repo.getByEmailFn = func(ctx context.Context, email string) (*entities.User, error) {
 		return nil, nil
 	}
 	_, err = uc.Execute(ctx, "test@avito.ru", "password", "hacker")
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, usecases.ErrValidation)
 
 	// Пустой email
 	_, err = uc.Execute(ctx, "", "password", entities.UserRoleClient)
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, usecases.ErrCredentialsRequired)
 
 	// Пустой пароль
 	_, err = uc.Execute(ctx, "test@avito.ru", "", entities.UserRoleClient)
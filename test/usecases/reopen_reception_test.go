@@ -0,0 +1,203 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockReceptionRepoForReopen struct {
+	receptions  map[uuid.UUID]entities.Reception
+	transitions []entities.ReceptionTransition
+}
+
+func (m *mockReceptionRepoForReopen) GetByIDForUpdate(_ context.Context, id uuid.UUID) (*entities.Reception, error) {
+	rec, ok := m.receptions[id]
+	if !ok {
+		return nil, nil
+	}
+	return &rec, nil
+}
+
+func (m *mockReceptionRepoForReopen) GetActive(_ context.Context, pvzID uuid.UUID) (*entities.Reception, error) {
+	for _, rec := range m.receptions {
+		if rec.PVZID == pvzID && rec.IsOpen() {
+			return &rec, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockReceptionRepoForReopen) Save(_ context.Context, rec entities.Reception) (entities.Reception, error) {
+	m.receptions[rec.ID] = rec
+	return rec, nil
+}
+
+func (m *mockReceptionRepoForReopen) SaveTransition(_ context.Context, t entities.ReceptionTransition) error {
+	m.transitions = append(m.transitions, t)
+	return nil
+}
+
+func TestReopenReceptionUseCase_Execute(t *testing.T) {
+	moderator := entities.User{ID: uuid.New(), Role: entities.UserRoleModerator}
+	pvzID := uuid.New()
+	closedAgo := func(d time.Duration) entities.Reception {
+		closedAt := time.Now().UTC().Add(-d)
+		return entities.Reception{ID: uuid.New(), PVZID: pvzID, Status: entities.ReceptionClosed, DateTime: closedAt.Add(-time.Hour), ClosedAt: &closedAt}
+	}
+	setup := func(pvz *entities.PVZ, receptions ...entities.Reception) (*usecases.ReopenReceptionUseCase, *mockReceptionRepoForReopen) {
+		repo := &mockReceptionRepoForReopen{receptions: map[uuid.UUID]entities.Reception{}}
+		for _, rec := range receptions {
+			repo.receptions[rec.ID] = rec
+		}
+		return usecases.NewReopenReceptionUseCase(repo, &mockPVZRepoForReception{pvz: pvz}, usecases.NopTxManager{}, 30*time.Minute, testAuthz), repo
+	}
+	pvz := &entities.PVZ{ID: pvzID, City: entities.CityMoscow}
+
+	t.Run("переоткрывает приёмку и пишет причину в историю", func(t *testing.T) {
+		// Arrange
+		rec := closedAgo(10 * time.Minute)
+		uc, repo := setup(pvz, rec)
+
+		// Act
+		reopened, err := uc.Execute(context.Background(), moderator, rec.ID, "  машина ещё разгружается ")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, entities.ReceptionInProgress, reopened.Status)
+		assert.Nil(t, reopened.ClosedAt)
+		assert.Equal(t, entities.ReceptionInProgress, repo.receptions[rec.ID].Status)
+		require.Len(t, repo.transitions, 1)
+		tr := repo.transitions[0]
+		assert.Equal(t, rec.ID, tr.ReceptionID)
+		assert.Equal(t, entities.ReceptionClosed, tr.From)
+		assert.Equal(t, entities.ReceptionInProgress, tr.To)
+		assert.Equal(t, moderator.ID, tr.ChangedBy)
+		assert.Equal(t, "машина ещё разгружается", tr.Reason)
+	})
+
+	t.Run("не модератор — запрещено", func(t *testing.T) {
+		// Arrange
+		rec := closedAgo(time.Minute)
+		uc, repo := setup(pvz, rec)
+
+		// Act
+		_, err := uc.Execute(context.Background(), entities.User{Role: entities.UserRolePVZStaff}, rec.ID, "причина")
+
+		// Assert
+		require.ErrorIs(t, err, usecases.ErrForbidden)
+		assert.Empty(t, repo.transitions)
+	})
+
+	t.Run("без причины — ошибка валидации", func(t *testing.T) {
+		// Arrange
+		rec := closedAgo(time.Minute)
+		uc, _ := setup(pvz, rec)
+
+		// Act
+		_, err := uc.Execute(context.Background(), moderator, rec.ID, "  ")
+
+		// Assert
+		require.ErrorIs(t, err, usecases.ErrInvalidReason)
+	})
+
+	t.Run("окно переоткрытия истекло", func(t *testing.T) {
+		// Arrange
+		rec := closedAgo(31 * time.Minute)
+		uc, repo := setup(pvz, rec)
+
+		// Act
+		_, err := uc.Execute(context.Background(), moderator, rec.ID, "причина")
+
+		// Assert
+		require.ErrorIs(t, err, usecases.ErrReopenWindowExpired)
+		assert.Equal(t, entities.ReceptionClosed, repo.receptions[rec.ID].Status)
+	})
+
+	t.Run("на ПВЗ уже есть открытая приёмка", func(t *testing.T) {
+		// Arrange
+		rec := closedAgo(time.Minute)
+		active := entities.Reception{ID: uuid.New(), PVZID: pvzID, Status: entities.ReceptionInProgress, DateTime: time.Now().UTC()}
+		uc, repo := setup(pvz, rec, active)
+
+		// Act
+		_, err := uc.Execute(context.Background(), moderator, rec.ID, "причина")
+
+		// Assert
+		require.ErrorIs(t, err, usecases.ErrReceptionAlreadyOpen)
+		assert.Empty(t, repo.transitions)
+	})
+
+	t.Run("приёмка открыта, не найдена или ПВЗ в архиве", func(t *testing.T) {
+		// Arrange
+		open := entities.Reception{ID: uuid.New(), PVZID: pvzID, Status: entities.ReceptionInProgress}
+		rec := closedAgo(time.Minute)
+		archivedAt := time.Now().UTC()
+		uc, _ := setup(pvz, open)
+		ucArchived, _ := setup(&entities.PVZ{ID: pvzID, City: entities.CityMoscow, ArchivedAt: &archivedAt}, rec)
+
+		// Act
+		_, errOpen := uc.Execute(context.Background(), moderator, open.ID, "причина")
+		_, errMissing := uc.Execute(context.Background(), moderator, uuid.New(), "причина")
+		_, errArchived := ucArchived.Execute(context.Background(), moderator, rec.ID, "причина")
+
+		// Assert
+		require.ErrorIs(t, errOpen, usecases.ErrReceptionNotClosed)
+		require.ErrorIs(t, errMissing, usecases.ErrReceptionNotFound)
+		require.ErrorIs(t, errArchived, usecases.ErrPVZArchived)
+	})
+}
+
+type mockReceptionRepoForTransitions struct {
+	rec         *entities.Reception
+	transitions []entities.ReceptionTransition
+}
+
+func (m *mockReceptionRepoForTransitions) GetByID(_ context.Context, _ uuid.UUID) (*entities.Reception, error) {
+	return m.rec, nil
+}
+
+func (m *mockReceptionRepoForTransitions) ListTransitions(_ context.Context, _ uuid.UUID) ([]entities.ReceptionTransition, error) {
+	return m.transitions, nil
+}
+
+func TestListReceptionTransitionsUseCase_Execute(t *testing.T) {
+	rec := &entities.Reception{ID: uuid.New(), Status: entities.ReceptionInProgress}
+	history := []entities.ReceptionTransition{
+		{ReceptionID: rec.ID, From: entities.ReceptionInProgress, To: entities.ReceptionClosed},
+		{ReceptionID: rec.ID, From: entities.ReceptionClosed, To: entities.ReceptionInProgress, Reason: "причина"},
+	}
+
+	t.Run("сотрудник и модератор видят историю", func(t *testing.T) {
+		// Arrange
+		uc := usecases.NewListReceptionTransitionsUseCase(&mockReceptionRepoForTransitions{rec: rec, transitions: history}, testAuthz)
+
+		for _, role := range []entities.UserRole{entities.UserRolePVZStaff, entities.UserRoleModerator} {
+			// Act
+			got, err := uc.Execute(context.Background(), entities.User{Role: role}, rec.ID)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, history, got)
+		}
+	})
+
+	t.Run("клиенту запрещено, неизвестная приёмка — не найдена", func(t *testing.T) {
+		// Arrange
+		uc := usecases.NewListReceptionTransitionsUseCase(&mockReceptionRepoForTransitions{}, testAuthz)
+
+		// Act
+		_, errRole := uc.Execute(context.Background(), entities.User{Role: entities.UserRoleClient}, rec.ID)
+		_, errMissing := uc.Execute(context.Background(), entities.User{Role: entities.UserRoleModerator}, rec.ID)
+
+		// Assert
+		require.ErrorIs(t, errRole, usecases.ErrForbidden)
+		require.ErrorIs(t, errMissing, usecases.ErrReceptionNotFound)
+	})
+}
@@ -0,0 +1,65 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/configs"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevokeUserSessionsUseCase_Execute(t *testing.T) {
+	cfg := &configs.Config{JWTSecret: "testsecret", AccessTokenTTL: 15 * time.Minute, RefreshTokenTTL: time.Hour}
+	moderator := entities.User{ID: uuid.New(), Role: entities.UserRoleModerator}
+	staff := entities.User{ID: uuid.New(), Email: "staff@avito.ru", Role: entities.UserRolePVZStaff}
+	other := entities.User{ID: uuid.New(), Email: "other@avito.ru", Role: entities.UserRolePVZStaff}
+	setup := func() (*memSessionRepo, *usecases.TokenIssuer) {
+		sessions := newMemSessionRepo()
+		return sessions, usecases.NewTokenIssuer(sessions, cfg)
+	}
+
+	t.Run("модератор завершает все сессии пользователя", func(t *testing.T) {
+		// Arrange: две сессии сотрудника и одна чужая
+		sessions, issuer := setup()
+		first, err := issuer.StartSession(context.Background(), staff)
+		require.NoError(t, err)
+		second, err := issuer.StartSession(context.Background(), staff)
+		require.NoError(t, err)
+		foreign, err := issuer.StartSession(context.Background(), other)
+		require.NoError(t, err)
+		uc := usecases.NewRevokeUserSessionsUseCase(sessions, testAuthz)
+
+		// Act
+		err = uc.Execute(context.Background(), moderator, staff.ID)
+		errAgain := uc.Execute(context.Background(), moderator, staff.ID)
+		_, errRefresh := usecases.NewRefreshTokenUseCase(sessions, issuer, usecases.NopTxManager{}).Execute(context.Background(), first.RefreshToken)
+
+		// Assert
+		require.NoError(t, err)
+		require.NoError(t, errAgain)
+		assert.NotNil(t, sessions.byToken(t, first.RefreshToken).RevokedAt)
+		assert.NotNil(t, sessions.byToken(t, second.RefreshToken).RevokedAt)
+		assert.Nil(t, sessions.byToken(t, foreign.RefreshToken).RevokedAt)
+		require.ErrorIs(t, errRefresh, usecases.ErrSessionRevoked)
+	})
+
+	t.Run("без права session:revoke — forbidden", func(t *testing.T) {
+		// Arrange
+		sessions, issuer := setup()
+		pair, err := issuer.StartSession(context.Background(), other)
+		require.NoError(t, err)
+		uc := usecases.NewRevokeUserSessionsUseCase(sessions, testAuthz)
+
+		// Act
+		err = uc.Execute(context.Background(), staff, other.ID)
+
+		// Assert
+		assert.ErrorIs(t, err, usecases.ErrForbidden)
+		assert.Nil(t, sessions.byToken(t, pair.RefreshToken).RevokedAt)
+	})
+}
@@ -0,0 +1,139 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type txMarker struct{}
+
+// fakeTx помечает ctx внутри WithinTx и может имитировать ошибку коммита
+type fakeTx struct {
+	calls     int
+	commitErr error
+}
+
+func (f *fakeTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	f.calls++
+	if err := fn(context.WithValue(ctx, txMarker{}, true)); err != nil {
+		return err
+	}
+	return f.commitErr
+}
+
+func inTx(ctx context.Context) bool {
+	v, _ := ctx.Value(txMarker{}).(bool)
+	return v
+}
+
+func TestUseCases_RunInsideTransaction(t *testing.T) {
+	ctx := context.Background()
+	pvzID := uuid.New()
+	staff := entities.User{Role: entities.UserRolePVZStaff}
+	openRec := func(ctx context.Context, _ uuid.UUID) (*entities.Reception, error) {
+		assert.True(t, inTx(ctx), "приёмка должна блокироваться внутри транзакции")
+		return &entities.Reception{ID: uuid.New(), PVZID: pvzID, Status: entities.ReceptionInProgress}, nil
+	}
+
+	t.Run("AddProduct: проверка приёмки и вставка в одной транзакции", func(t *testing.T) {
+		// Arrange
+		tx := &fakeTx{}
+		metrics := &countingMetrics{}
+		uc := usecases.NewAddProductUseCase(
+			&mockProductRepo{saveFn: func(ctx context.Context, p entities.Product) (entities.Product, error) {
+				assert.True(t, inTx(ctx))
+				return p, nil
+			}},
+			&mockReceptionRepoForAdd{getActiveFn: openRec},
+			usecases.DefaultCatalog(), tx, metrics,
+			testAuthz,
+		)
+
+		// Act
+		_, err := uc.Execute(ctx, staff, pvzID, entities.ProductShoes, "")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1, tx.calls)
+		assert.Equal(t, 1, metrics.products)
+	})
+
+	t.Run("AddProduct: ошибка коммита — товар не считается добавленным", func(t *testing.T) {
+		// Arrange
+		tx := &fakeTx{commitErr: assert.AnError}
+		metrics := &countingMetrics{}
+		uc := usecases.NewAddProductUseCase(
+			&mockProductRepo{saveFn: func(ctx context.Context, p entities.Product) (entities.Product, error) { return p, nil }},
+			&mockReceptionRepoForAdd{getActiveFn: openRec},
+			usecases.DefaultCatalog(), tx, metrics,
+			testAuthz,
+		)
+
+		// Act
+		_, err := uc.Execute(ctx, staff, pvzID, entities.ProductShoes, "")
+
+		// Assert
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, 0, metrics.products)
+	})
+
+	t.Run("DeleteLastProduct: проверка приёмки и удаление в одной транзакции", func(t *testing.T) {
+		// Arrange
+		tx := &fakeTx{}
+		uc := usecases.NewDeleteLastProductUseCase(
+			&mockProductRepoForDelete{deleteLastFn: func(ctx context.Context, recID uuid.UUID) (*entities.Product, error) {
+				assert.True(t, inTx(ctx))
+				return &entities.Product{ID: uuid.New(), ReceptionID: recID}, nil
+			}},
+			&mockReceptionRepoForDelete{getActiveFn: openRec},
+			tx,
+			testAuthz,
+		)
+
+		// Act
+		err := uc.Execute(ctx, staff, pvzID)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1, tx.calls)
+	})
+
+	t.Run("CloseReception: проверка и сохранение в одной транзакции", func(t *testing.T) {
+		// Arrange
+		tx := &fakeTx{}
+		uc := usecases.NewCloseReceptionUseCase(&mockReceptionRepoForClose{
+			getActiveFn: openRec,
+			saveFn: func(ctx context.Context, rec entities.Reception) (entities.Reception, error) {
+				assert.True(t, inTx(ctx))
+				return rec, nil
+			},
+		}, tx, testAuthz)
+
+		// Act
+		closed, err := uc.Execute(ctx, staff, pvzID)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, entities.ReceptionClosed, closed.Status)
+		assert.Equal(t, 1, tx.calls)
+	})
+
+	t.Run("отказ по роли — транзакция не открывается", func(t *testing.T) {
+		// Arrange
+		tx := &fakeTx{}
+		uc := usecases.NewCloseReceptionUseCase(&mockReceptionRepoForClose{}, tx, testAuthz)
+
+		// Act
+		_, err := uc.Execute(ctx, entities.User{Role: entities.UserRoleClient}, pvzID)
+
+		// Assert
+		assert.ErrorIs(t, err, usecases.ErrForbidden)
+		assert.Equal(t, 0, tx.calls)
+	})
+}
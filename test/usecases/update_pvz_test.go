@@ -0,0 +1,122 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/entities"
+	"github.com/nikborovets/backend-trainee-assignment-spring-2025/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockPVZRepoForUpdate хранит один ПВЗ; nil — ПВЗ не найден
+type mockPVZRepoForUpdate struct {
+	pvz     *entities.PVZ
+	updates int
+}
+
+func (m *mockPVZRepoForUpdate) GetByID(_ context.Context, _ uuid.UUID) (*entities.PVZ, error) {
+	if m.pvz == nil {
+		return nil, nil
+	}
+	pvz := *m.pvz
+	return &pvz, nil
+}
+
+func (m *mockPVZRepoForUpdate) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.PVZ, error) {
+	return m.GetByID(ctx, id)
+}
+
+func (m *mockPVZRepoForUpdate) Update(_ context.Context, pvz entities.PVZ) (entities.PVZ, error) {
+	m.updates++
+	m.pvz = &pvz
+	return pvz, nil
+}
+
+func TestUpdatePVZUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	moderator := entities.User{Role: entities.UserRoleModerator}
+	kazan := entities.CityKazan
+
+	t.Run("модератор меняет город", func(t *testing.T) {
+		// Arrange
+		pvzID := uuid.New()
+		repo := &mockPVZRepoForUpdate{pvz: &entities.PVZ{ID: pvzID, City: entities.CityMoscow}}
+		uc := usecases.NewUpdatePVZUseCase(repo, usecases.DefaultCatalog(), usecases.NopTxManager{}, testAuthz)
+
+		// Act
+		updated, err := uc.Execute(ctx, moderator, pvzID, usecases.PVZPatch{City: &kazan})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, entities.CityKazan, updated.City)
+		assert.Equal(t, 1, repo.updates)
+	})
+
+	t.Run("меняются только переданные поля описания", func(t *testing.T) {
+		// Arrange
+		pvzID := uuid.New()
+		location := &entities.GeoPoint{Lat: 55.7575, Lon: 37.6134}
+		repo := &mockPVZRepoForUpdate{pvz: &entities.PVZ{ID: pvzID, City: entities.CityMoscow, PVZDetails: entities.PVZDetails{Name: "Тверская", Location: location}}}
+		uc := usecases.NewUpdatePVZUseCase(repo, usecases.DefaultCatalog(), usecases.NopTxManager{}, testAuthz)
+		address := " ул. Тверская, 7 "
+
+		// Act
+		updated, err := uc.Execute(ctx, moderator, pvzID, usecases.PVZPatch{Address: &address})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, entities.CityMoscow, updated.City)
+		assert.Equal(t, "Тверская", updated.Name)
+		assert.Equal(t, "ул. Тверская, 7", updated.Address)
+		assert.Equal(t, location, updated.Location)
+
+		_, err = uc.Execute(ctx, moderator, pvzID, usecases.PVZPatch{Location: &entities.GeoPoint{Lat: 0, Lon: 181}})
+		assert.ErrorIs(t, err, usecases.ErrInvalidLocation)
+		assert.Equal(t, 1, repo.updates)
+	})
+
+	t.Run("ошибки", func(t *testing.T) {
+		// Arrange
+		pvzID := uuid.New()
+		repo := &mockPVZRepoForUpdate{pvz: &entities.PVZ{ID: pvzID, City: entities.CityMoscow}}
+		uc := usecases.NewUpdatePVZUseCase(repo, usecases.DefaultCatalog(), usecases.NopTxManager{}, testAuthz)
+		unknown := entities.City("Тверь")
+
+		// Act & Assert
+		_, err := uc.Execute(ctx, entities.User{Role: entities.UserRolePVZStaff}, pvzID, usecases.PVZPatch{City: &kazan})
+		assert.ErrorIs(t, err, usecases.ErrForbidden)
+		_, err = uc.Execute(ctx, moderator, pvzID, usecases.PVZPatch{})
+		assert.ErrorIs(t, err, usecases.ErrEmptyPVZPatch)
+		_, err = uc.Execute(ctx, moderator, pvzID, usecases.PVZPatch{City: &unknown})
+		assert.ErrorIs(t, err, usecases.ErrInvalidCity)
+		repo.pvz = nil
+		_, err = uc.Execute(ctx, moderator, pvzID, usecases.PVZPatch{City: &kazan})
+		assert.ErrorIs(t, err, usecases.ErrPVZNotFound)
+		assert.Zero(t, repo.updates)
+	})
+}
+
+func TestGetPVZUseCase_Execute(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	pvzID := uuid.New()
+	archivedAt := entities.NowUTC()
+	repo := &mockPVZRepoForUpdate{pvz: &entities.PVZ{ID: pvzID, City: entities.CityMoscow, ArchivedAt: &archivedAt}}
+	uc := usecases.NewGetPVZUseCase(repo, testAuthz)
+
+	// Act
+	pvz, err := uc.Execute(ctx, entities.User{Role: entities.UserRolePVZStaff}, pvzID)
+
+	// Assert: архивный ПВЗ доступен по id
+	require.NoError(t, err)
+	assert.True(t, pvz.IsArchived())
+
+	_, err = uc.Execute(ctx, entities.User{Role: entities.UserRoleClient}, pvzID)
+	assert.ErrorIs(t, err, usecases.ErrForbidden)
+	repo.pvz = nil
+	_, err = uc.Execute(ctx, entities.User{Role: entities.UserRoleModerator}, pvzID)
+	assert.ErrorIs(t, err, usecases.ErrPVZNotFound)
+}